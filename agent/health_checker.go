@@ -0,0 +1,204 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/lagopus/vrrpd/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// HealthCheckerModuleName HealthChecker module name.
+	HealthCheckerModuleName = "HealthCheckerModule"
+)
+
+type healthCheck struct {
+	*models.HealthCheck
+	up        bool
+	riseCount uint8
+	fallCount uint8
+	vrrps     map[string]*VRRP
+}
+
+// HealthChecker Runner of health check commands.
+type HealthChecker struct {
+	checkTable  map[string]*healthCheck
+	stopChannel chan bool
+	isRunning   bool
+	wg          *sync.WaitGroup
+	lock        sync.Mutex
+}
+
+// NewHealthChecker New HealthChecker module.
+func NewHealthChecker(hcs map[string]*models.HealthCheck,
+	wg *sync.WaitGroup) *HealthChecker {
+	checkTable := map[string]*healthCheck{}
+	for name, hc := range hcs {
+		checkTable[name] = &healthCheck{
+			HealthCheck: hc.Copy(),
+			// health check is up until it fails.
+			up:    true,
+			vrrps: map[string]*VRRP{},
+		}
+	}
+
+	hcr := &HealthChecker{
+		checkTable:  checkTable,
+		stopChannel: make(chan bool),
+		wg:          wg,
+	}
+	return hcr
+}
+
+// runHealthCheck Run command of health check,
+// and report whether exit status is 0 within timeout.
+func runHealthCheck(hc *models.HealthCheck) bool {
+	ctx, cancel := context.WithTimeout(context.Background(),
+		time.Duration(hc.Timeout)*time.Second)
+	defer cancel()
+
+	if err := exec.CommandContext(ctx, "/bin/sh", "-c", hc.Command).Run(); err != nil {
+		log.Debugf("Health check %s failed: %v", hc.Name, err)
+		return false
+	}
+
+	return true
+}
+
+func (hcr *HealthChecker) updateStateNoLock(c *healthCheck, ok bool) {
+	if ok {
+		c.fallCount = 0
+		if c.riseCount < c.Rise {
+			c.riseCount++
+		}
+		if c.up || c.riseCount < c.Rise {
+			return
+		}
+	} else {
+		c.riseCount = 0
+		if c.fallCount < c.Fall {
+			c.fallCount++
+		}
+		if !c.up || c.fallCount < c.Fall {
+			return
+		}
+	}
+
+	c.up = ok
+	log.Infof("Health check %s: up=%t", c.Name, c.up)
+	for _, v := range c.vrrps {
+		v.SetHealthCheckState(c.Name, c.Weight, c.up)
+	}
+}
+
+// Health check loop.
+func (hcr *HealthChecker) healthCheckLoop(c *healthCheck, stopChannel chan bool) {
+	defer hcr.wg.Done()
+
+	ticker := time.NewTicker(time.Duration(c.Interval) * time.Second)
+	for {
+		select {
+		case <-ticker.C:
+			// command is run without lock.
+			ok := runHealthCheck(c.HealthCheck)
+
+			hcr.lock.Lock()
+			hcr.updateStateNoLock(c, ok)
+			hcr.lock.Unlock()
+		case <-stopChannel:
+			log.Infof("Stop HealthCheckLoop(%s).", c.Name)
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// Start Start health checks.
+func (hcr *HealthChecker) Start() error {
+	hcr.lock.Lock()
+	defer hcr.lock.Unlock()
+
+	if hcr.isRunning == false {
+		for _, c := range hcr.checkTable {
+			hcr.wg.Add(1)
+			go hcr.healthCheckLoop(c, hcr.stopChannel)
+		}
+		hcr.isRunning = true
+	}
+
+	return nil
+}
+
+// Stop Stop health checks.
+func (hcr *HealthChecker) Stop() {
+	hcr.lock.Lock()
+	defer hcr.lock.Unlock()
+
+	if hcr.isRunning == true {
+		// stop all loops.
+		close(hcr.stopChannel)
+		hcr.stopChannel = make(chan bool)
+		hcr.isRunning = false
+	}
+}
+
+// Resume Resume module.
+func (hcr *HealthChecker) Resume() error {
+	// implement if necessary.
+	return nil
+}
+
+// Suspend Suspend module.
+func (hcr *HealthChecker) Suspend() error {
+	// implement if necessary.
+	return nil
+}
+
+// Name Module name.
+func (hcr *HealthChecker) Name() string {
+	return HealthCheckerModuleName
+}
+
+// AddCheckTable Add VRRP to health check,
+// and notify current state to VRRP.
+func (hcr *HealthChecker) AddCheckTable(name string, v *VRRP) {
+	hcr.lock.Lock()
+	defer hcr.lock.Unlock()
+
+	c, ok := hcr.checkTable[name]
+	if !ok {
+		log.Errorf("Unknown health check: %s", name)
+		return
+	}
+	c.vrrps[v.objID] = v
+	v.SetHealthCheckState(c.Name, c.Weight, c.up)
+}
+
+// DeleteCheckTable Delete VRRP from all health checks.
+func (hcr *HealthChecker) DeleteCheckTable(v *VRRP) {
+	hcr.lock.Lock()
+	defer hcr.lock.Unlock()
+
+	for _, c := range hcr.checkTable {
+		delete(c.vrrps, v.objID)
+	}
+}
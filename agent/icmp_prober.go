@@ -0,0 +1,311 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lagopus/vrrpd/packets"
+	"github.com/lagopus/vrrpd/rpc"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ICMPProberModuleName ICMPProber module name.
+	ICMPProberModuleName = "ICMPProberModule"
+	// ProbeInterval Interval of ICMP echo probe.
+	ProbeInterval = time.Second
+	// ProbeFallCount Number of consecutive losses to declare target down.
+	ProbeFallCount = 3
+)
+
+// probe target.
+// Probes are sent to MAC address of next hop, which is target itself
+// if target is on-link, otherwise gateway.
+type probeTarget struct {
+	subifName string
+	target    net.IP
+	nextHop   net.IP
+	srcIP     net.IP
+	srcMAC    net.HardwareAddr
+	dstMAC    net.HardwareAddr
+	seq       uint16
+	waiting   bool
+	lossCount uint8
+	up        bool
+	vrrps     map[string]*VRRP
+}
+
+// ICMPProber Prober of reachability by ICMP(v6) echo.
+type ICMPProber struct {
+	probeTable  map[string]*probeTarget
+	id          uint16
+	stopChannel chan bool
+	hostif      *rpc.Hostif
+	isRunning   bool
+	wg          *sync.WaitGroup
+	lock        sync.Mutex
+}
+
+// NewICMPProber New ICMPProber module.
+func NewICMPProber(hostif *rpc.Hostif, wg *sync.WaitGroup) *ICMPProber {
+	pr := &ICMPProber{
+		probeTable:  map[string]*probeTarget{},
+		id:          uint16(os.Getpid()),
+		stopChannel: make(chan bool),
+		hostif:      hostif,
+		wg:          wg,
+	}
+	return pr
+}
+
+func createProbeID(subifName string, target net.IP) string {
+	return fmt.Sprintf("%s:%s", subifName, target)
+}
+
+// onLink Report whether addr is on-link of subinterface of VRRP.
+func onLink(addr net.IP, v *VRRP) bool {
+	if addr.To4() == nil && addr.IsLinkLocalUnicast() {
+		return true
+	}
+
+	bits := 32
+	if v.subifIP.To4() == nil {
+		bits = 128
+	}
+	mask := net.CIDRMask(int(v.subifPrefix), bits)
+	network := &net.IPNet{IP: v.subifIP.Mask(mask), Mask: mask}
+	return network.Contains(addr)
+}
+
+// create probe packet, ARP request/NS until MAC address of next hop
+// is resolved.
+func (pr *ICMPProber) createProbe(t *probeTarget) ([]byte, error) {
+	if t.dstMAC == nil {
+		if t.nextHop.To4() != nil {
+			return packets.SerializeARPRequest(t.srcIP, t.nextHop, t.srcMAC)
+		}
+		return packets.SerializeNS(t.srcIP, t.nextHop, t.srcMAC)
+	}
+
+	t.seq++
+	return packets.SerializeICMPEcho(t.srcIP, t.target,
+		t.srcMAC, t.dstMAC, pr.id, t.seq)
+}
+
+func (pr *ICMPProber) setStateNoLock(t *probeTarget, up bool) {
+	if t.up == up {
+		return
+	}
+
+	log.Infof("Reachability target %s(%s): up=%t", t.target, t.subifName, up)
+	t.up = up
+	for _, v := range t.vrrps {
+		v.SetReachabilityState(t.target, up)
+	}
+}
+
+// event.
+func (pr *ICMPProber) timeOutEvent() {
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+
+	ps := []*rpc.Packet{}
+	for _, t := range pr.probeTable {
+		// previous probe is lost.
+		if t.waiting {
+			if t.lossCount < ProbeFallCount {
+				t.lossCount++
+			}
+			if t.lossCount >= ProbeFallCount {
+				// MAC address may be changed, resolve again.
+				t.dstMAC = nil
+				pr.setStateNoLock(t, false)
+			}
+		}
+
+		if buf, err := pr.createProbe(t); err == nil {
+			ps = append(ps, rpc.NewPacket(t.subifName, buf))
+			t.waiting = true
+		} else {
+			log.Errorf("Create probe failed: %v", err)
+		}
+	}
+
+	if len(ps) != 0 {
+		log.Debugf("send probe.")
+		pr.hostif.PacketoutBulk(rpc.NewBulkPackets(ps))
+	}
+}
+
+// ICMP prober loop.
+func (pr *ICMPProber) icmpProberLoop() {
+	defer pr.wg.Done()
+
+	ticker := time.NewTicker(ProbeInterval)
+	for {
+		select {
+		case <-ticker.C:
+			pr.timeOutEvent()
+		case <-pr.stopChannel:
+			log.Infof("Stop icmpProberLoop.")
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// Start Start ICMP prober.
+func (pr *ICMPProber) Start() error {
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+
+	if pr.isRunning == false {
+		pr.wg.Add(1)
+		go pr.icmpProberLoop()
+		pr.isRunning = true
+	}
+
+	return nil
+}
+
+// Stop Stop ICMP prober.
+func (pr *ICMPProber) Stop() {
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+
+	if pr.isRunning == true {
+		pr.stopChannel <- true
+		pr.isRunning = false
+	}
+}
+
+// Resume Resume module.
+func (pr *ICMPProber) Resume() error {
+	// implement if necessary.
+	return nil
+}
+
+// Suspend Suspend module.
+func (pr *ICMPProber) Suspend() error {
+	// implement if necessary.
+	return nil
+}
+
+// Name Module name.
+func (pr *ICMPProber) Name() string {
+	return ICMPProberModuleName
+}
+
+// RecvPackets Recv replies of probe,
+// and return packets other than replies.
+func (pr *ICMPProber) RecvPackets(bps *rpc.BulkPackets) *rpc.BulkPackets {
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+
+	others := []*rpc.Packet{}
+	for _, packet := range bps.Packets {
+		reply, err := packets.DecodeProbeReply(packet.Data)
+		if err != nil {
+			others = append(others, packet)
+			continue
+		}
+
+		if !reply.Echo {
+			// ARP reply/NA: MAC address of next hop is resolved.
+			for _, t := range pr.probeTable {
+				if t.subifName == packet.Subifname && t.nextHop.Equal(reply.SrcIP) {
+					t.dstMAC = reply.SrcMAC
+				}
+			}
+			continue
+		}
+
+		t, ok := pr.probeTable[createProbeID(packet.Subifname, reply.SrcIP)]
+		if !ok {
+			continue
+		}
+
+		if reply.ID == pr.id && reply.Seq == t.seq && t.waiting {
+			t.waiting = false
+			t.lossCount = 0
+			pr.setStateNoLock(t, true)
+		}
+	}
+
+	return rpc.NewBulkPackets(others)
+}
+
+// AddProbeTable Add VRRP to probe of target,
+// and notify current state to VRRP.
+func (pr *ICMPProber) AddProbeTable(target net.IP, v *VRRP) {
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+
+	if (target.To4() != nil) != (v.srcIP.To4() != nil) {
+		log.Errorf("Address family of reachability target %s mismatch: %s",
+			target, v.objID)
+		return
+	}
+
+	nextHop := target
+	if !onLink(target, v) && v.reachabilityGateway != nil {
+		nextHop = v.reachabilityGateway
+	}
+
+	id := createProbeID(v.subifName, target)
+	t, ok := pr.probeTable[id]
+	if !ok {
+		srcIP := v.subifIP
+		if target.IsLinkLocalUnicast() {
+			srcIP = v.srcIP
+		}
+		t = &probeTarget{
+			subifName: v.subifName,
+			target:    target,
+			nextHop:   nextHop,
+			srcIP:     srcIP,
+			srcMAC:    v.vmac,
+			// target is up until probes are lost.
+			up:    true,
+			vrrps: map[string]*VRRP{},
+		}
+		pr.probeTable[id] = t
+	} else if !t.nextHop.Equal(nextHop) {
+		log.Warnf("Reachability target %s(%s) is probed via %s, not %s: %s",
+			target, v.subifName, t.nextHop, nextHop, v.objID)
+	}
+	t.vrrps[v.objID] = v
+	v.SetReachabilityState(target, t.up)
+}
+
+// DeleteProbeTable Delete VRRP from all probes.
+func (pr *ICMPProber) DeleteProbeTable(v *VRRP) {
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+
+	for id, t := range pr.probeTable {
+		delete(t.vrrps, v.objID)
+		if len(t.vrrps) == 0 {
+			delete(pr.probeTable, id)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/google/gopacket"
+	glayers "github.com/google/gopacket/layers"
+	"github.com/lagopus/vrrpd/packets"
+	"github.com/lagopus/vrrpd/rpc"
+	"github.com/stretchr/testify/suite"
+)
+
+type testICMPProberTestSuite struct {
+	suite.Suite
+}
+
+var (
+	proberVMAC   = net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x01, 0x01}
+	proberGWMAC  = net.HardwareAddr{0x52, 0x54, 0x00, 0x11, 0x22, 0x33}
+	proberSubif  = "if0-1"
+	proberSubIP  = net.ParseIP("10.0.0.1").To4()
+	proberGW     = net.ParseIP("10.0.0.254").To4()
+	proberTarget = net.ParseIP("192.168.0.1").To4()
+)
+
+func createProberVRRP() *VRRP {
+	return &VRRP{
+		objID:               "if0-1:ipv4:1",
+		subifName:           proberSubif,
+		subifIP:             proberSubIP,
+		subifPrefix:         24,
+		srcIP:               proberSubIP,
+		vmac:                proberVMAC,
+		reachabilityGateway: proberGW,
+		decrements:          map[string]uint8{},
+	}
+}
+
+func (suite *testICMPProberTestSuite) serialize(l ...gopacket.SerializableLayer) []byte {
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	err := gopacket.SerializeLayers(buf, opts, l...)
+	suite.Empty(err)
+
+	return buf.Bytes()
+}
+
+func (suite *testICMPProberTestSuite) serializeARPReply() []byte {
+	return suite.serialize(
+		&glayers.Ethernet{
+			DstMAC:       proberVMAC,
+			SrcMAC:       proberGWMAC,
+			EthernetType: glayers.EthernetTypeARP,
+		},
+		&glayers.ARP{
+			AddrType:          glayers.LinkTypeEthernet,
+			Protocol:          glayers.EthernetTypeIPv4,
+			HwAddressSize:     6,
+			ProtAddressSize:   net.IPv4len,
+			Operation:         glayers.ARPReply,
+			SourceHwAddress:   proberGWMAC,
+			SourceProtAddress: proberGW,
+			DstHwAddress:      proberVMAC,
+			DstProtAddress:    proberSubIP,
+		})
+}
+
+func (suite *testICMPProberTestSuite) serializeEchoReply(id uint16, seq uint16) []byte {
+	return suite.serialize(
+		&glayers.Ethernet{
+			DstMAC:       proberVMAC,
+			SrcMAC:       proberGWMAC,
+			EthernetType: glayers.EthernetTypeIPv4,
+		},
+		&glayers.IPv4{
+			Version:  4,
+			IHL:      5,
+			TTL:      64,
+			Protocol: glayers.IPProtocolICMPv4,
+			SrcIP:    proberTarget,
+			DstIP:    proberSubIP,
+		},
+		&glayers.ICMPv4{
+			TypeCode: glayers.CreateICMPv4TypeCode(glayers.ICMPv4TypeEchoReply, 0),
+			Id:       id,
+			Seq:      seq,
+		})
+}
+
+func (suite *testICMPProberTestSuite) TestRecvPacketsEchoReply() {
+	pr := NewICMPProber(nil, &sync.WaitGroup{})
+	pr.AddProbeTable(proberTarget, createProberVRRP())
+
+	// off-link target is probed via gateway.
+	t := pr.probeTable[createProbeID(proberSubif, proberTarget)]
+	suite.NotNil(t)
+	suite.True(proberGW.Equal(t.nextHop))
+
+	buf, err := pr.createProbe(t)
+	suite.Empty(err)
+	arp := gopacket.NewPacket(buf, glayers.LayerTypeEthernet,
+		gopacket.Default).Layer(glayers.LayerTypeARP).(*glayers.ARP)
+	suite.Equal(glayers.ARPRequest, int(arp.Operation))
+	suite.True(proberGW.Equal(net.IP(arp.DstProtAddress)))
+
+	// MAC address of gateway is resolved,
+	// and packets other than replies are returned.
+	garp, err := packets.SerializeARP(proberSubIP, proberVMAC)
+	suite.Empty(err)
+	others := pr.RecvPackets(rpc.NewBulkPackets([]*rpc.Packet{
+		rpc.NewPacket(proberSubif, suite.serializeARPReply()),
+		rpc.NewPacket(proberSubif, garp),
+	}))
+	suite.Equal(1, len(others.Packets))
+	suite.Equal(proberGWMAC, t.dstMAC)
+
+	// echo request is sent to target via gateway.
+	buf, err = pr.createProbe(t)
+	suite.Empty(err)
+	packet := gopacket.NewPacket(buf, glayers.LayerTypeEthernet, gopacket.Default)
+	suite.Equal(proberGWMAC, packet.Layer(glayers.LayerTypeEthernet).(*glayers.Ethernet).DstMAC)
+	suite.True(proberTarget.Equal(packet.Layer(glayers.LayerTypeIPv4).(*glayers.IPv4).DstIP))
+
+	// previous probes are lost.
+	t.waiting = true
+	t.lossCount = ProbeFallCount
+	t.up = false
+
+	// echo reply of other sequence is ignored.
+	pr.RecvPackets(rpc.NewBulkPackets([]*rpc.Packet{
+		rpc.NewPacket(proberSubif, suite.serializeEchoReply(pr.id, t.seq+1)),
+	}))
+	suite.Equal(uint8(ProbeFallCount), t.lossCount)
+	suite.False(t.up)
+
+	// echo reply clears loss count.
+	others = pr.RecvPackets(rpc.NewBulkPackets([]*rpc.Packet{
+		rpc.NewPacket(proberSubif, suite.serializeEchoReply(pr.id, t.seq)),
+	}))
+	suite.Empty(others.Packets)
+	suite.Equal(uint8(0), t.lossCount)
+	suite.False(t.waiting)
+	suite.True(t.up)
+}
+
+func (suite *testICMPProberTestSuite) TestAddProbeTableOnLink() {
+	pr := NewICMPProber(nil, &sync.WaitGroup{})
+	target := net.ParseIP("10.0.0.100")
+	pr.AddProbeTable(target, createProberVRRP())
+
+	// on-link target is probed directly.
+	t := pr.probeTable[createProbeID(proberSubif, target)]
+	suite.NotNil(t)
+	suite.True(target.Equal(t.nextHop))
+}
+
+func TestICMPProberTestSuite(t *testing.T) {
+	suite.Run(t, new(testICMPProberTestSuite))
+}
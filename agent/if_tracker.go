@@ -0,0 +1,211 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// IfTrackerModuleName IfTracker module name.
+	IfTrackerModuleName = "IfTrackerModule"
+	// IfTrackInterval Interval of polling operational state.
+	IfTrackInterval = time.Second
+)
+
+// sysfs directory of network interfaces.
+const sysClassNetDir = "/sys/class/net"
+
+type trackIf struct {
+	up     bool
+	exists bool
+	vrrps  map[string]*VRRP
+}
+
+// IfTracker Tracker of operational state of interfaces.
+type IfTracker struct {
+	trackTable  map[string]*trackIf
+	stopChannel chan bool
+	isRunning   bool
+	wg          *sync.WaitGroup
+	lock        sync.Mutex
+}
+
+// NewIfTracker New IfTracker module.
+func NewIfTracker(wg *sync.WaitGroup) *IfTracker {
+	ift := &IfTracker{
+		trackTable:  map[string]*trackIf{},
+		stopChannel: make(chan bool),
+		wg:          wg,
+	}
+	return ift
+}
+
+// isIfUp Report whether operational state of interface is up,
+// and whether interface exists.
+func isIfUp(ifname string) (bool, bool) {
+	buf, err := ioutil.ReadFile(filepath.Join(sysClassNetDir, ifname, "operstate"))
+	if os.IsNotExist(err) {
+		return false, false
+	} else if err != nil {
+		return false, true
+	}
+
+	switch strings.TrimSpace(string(buf)) {
+	case "up":
+		return true, true
+	case "unknown":
+		// e.g. tap/tun device, use administrative state.
+		if iface, err := net.InterfaceByName(ifname); err == nil {
+			return iface.Flags&net.FlagUp != 0, true
+		}
+	}
+
+	return false, true
+}
+
+// update Update state by operational state of interface,
+// and report whether it is changed.
+// Tracked interfaces are validated to have kernel netdev, and
+// interface whose netdev is removed later is skipped as up.
+func (t *trackIf) update(ifname string) bool {
+	up, exists := isIfUp(ifname)
+	if exists != t.exists {
+		if !exists {
+			log.Warnf("Tracked interface %s doesn't exist, skipped", ifname)
+		}
+		t.exists = exists
+	}
+	if !exists {
+		up = true
+	}
+
+	if up == t.up {
+		return false
+	}
+	t.up = up
+	return true
+}
+
+// Interface tracker loop.
+func (ift *IfTracker) ifTrackerLoop() {
+	defer ift.wg.Done()
+
+	ticker := time.NewTicker(IfTrackInterval)
+	for {
+		select {
+		case <-ticker.C:
+			ift.lock.Lock()
+			for ifname, t := range ift.trackTable {
+				if t.update(ifname) {
+					log.Infof("Tracked interface %s: up=%t", ifname, t.up)
+					for _, v := range t.vrrps {
+						v.SetTrackInterfaceState(ifname, t.up)
+					}
+				}
+			}
+			ift.lock.Unlock()
+		case <-ift.stopChannel:
+			log.Infof("Stop IfTrackerLoop.")
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// Start Start interface tracker.
+func (ift *IfTracker) Start() error {
+	ift.lock.Lock()
+	defer ift.lock.Unlock()
+
+	if ift.isRunning == false {
+		ift.wg.Add(1)
+		go ift.ifTrackerLoop()
+		ift.isRunning = true
+	}
+
+	return nil
+}
+
+// Stop Stop interface tracker.
+func (ift *IfTracker) Stop() {
+	ift.lock.Lock()
+	defer ift.lock.Unlock()
+
+	if ift.isRunning == true {
+		ift.stopChannel <- true
+		ift.isRunning = false
+	}
+}
+
+// Resume Resume module.
+func (ift *IfTracker) Resume() error {
+	// implement if necessary.
+	return nil
+}
+
+// Suspend Suspend module.
+func (ift *IfTracker) Suspend() error {
+	// implement if necessary.
+	return nil
+}
+
+// Name Module name.
+func (ift *IfTracker) Name() string {
+	return IfTrackerModuleName
+}
+
+// AddTrackTable Add entry in TrackTable,
+// and notify current operational state to VRRP.
+func (ift *IfTracker) AddTrackTable(ifname string, v *VRRP) {
+	ift.lock.Lock()
+	defer ift.lock.Unlock()
+
+	t, ok := ift.trackTable[ifname]
+	if !ok {
+		t = &trackIf{
+			up:     true,
+			exists: true,
+			vrrps:  map[string]*VRRP{},
+		}
+		t.update(ifname)
+		ift.trackTable[ifname] = t
+	}
+	t.vrrps[v.objID] = v
+	v.SetTrackInterfaceState(ifname, t.up)
+}
+
+// DeleteTrackTable Delete entries of VRRP in TrackTable.
+func (ift *IfTracker) DeleteTrackTable(v *VRRP) {
+	ift.lock.Lock()
+	defer ift.lock.Unlock()
+
+	for ifname, t := range ift.trackTable {
+		delete(t.vrrps, v.objID)
+		if len(t.vrrps) == 0 {
+			delete(ift.trackTable, ifname)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lagopus/vrrpd/rpc"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// MetricsModuleName Metrics module name.
+	MetricsModuleName = "MetricsModule"
+	// MetricsPath Path of metrics.
+	MetricsPath = "/metrics"
+	// metricsPrefix Prefix of metric names.
+	metricsPrefix = "vrrpd_"
+)
+
+// states exported as metrics.
+var metricsStates = []VRRPState{StateInitialize, StateBackup, StateMaster, StateFault,
+	StateError}
+
+// Metrics Prometheus metrics endpoint.
+type Metrics struct {
+	addr      string
+	server    *http.Server
+	datastore *rpc.Datastore
+	hostif    *rpc.Hostif
+	dpagent   *rpc.DPAgent
+	isRunning bool
+	wg        *sync.WaitGroup
+	lock      sync.Mutex
+}
+
+// NewMetrics New Metrics module.
+func NewMetrics(addr string, port int, datastore *rpc.Datastore,
+	hostif *rpc.Hostif, dpagent *rpc.DPAgent, wg *sync.WaitGroup) *Metrics {
+	return &Metrics{
+		addr:      net.JoinHostPort(addr, fmt.Sprintf("%d", port)),
+		datastore: datastore,
+		hostif:    hostif,
+		dpagent:   dpagent,
+		wg:        wg,
+	}
+}
+
+// label Label of metric.
+type label struct {
+	name  string
+	value string
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// metricsWriter Writer of Prometheus text format.
+type metricsWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *metricsWriter) header(name string, typ string, help string) {
+	fmt.Fprintf(&w.buf, "# HELP %s%s %s\n", metricsPrefix, name, help)
+	fmt.Fprintf(&w.buf, "# TYPE %s%s %s\n", metricsPrefix, name, typ)
+}
+
+func (w *metricsWriter) sample(name string, labels []label, value interface{}) {
+	w.buf.WriteString(metricsPrefix + name)
+	if len(labels) > 0 {
+		strs := make([]string, len(labels))
+		for i, l := range labels {
+			strs[i] = fmt.Sprintf(`%s="%s"`, l.name, labelValueReplacer.Replace(l.value))
+		}
+		fmt.Fprintf(&w.buf, "{%s}", strings.Join(strs, ","))
+	}
+	fmt.Fprintf(&w.buf, " %v\n", value)
+}
+
+func vrrpLabels(info *VRRPInfo) []label {
+	return []label{
+		{"subinterface", info.Subifname},
+		{"af", info.AF.String()},
+		{"vrid", fmt.Sprintf("%d", info.Vrid)},
+	}
+}
+
+func withLabel(labels []label, name string, value string) []label {
+	return append(append([]label{}, labels...), label{name, value})
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// writeVRRPMetrics Write metrics of VRRPs.
+func (w *metricsWriter) writeVRRPMetrics(infos []VRRPInfo) {
+	w.header("vrrp_state", "gauge", "State of virtual router(1 for current state).")
+	for i := range infos {
+		for _, s := range metricsStates {
+			w.sample("vrrp_state", withLabel(vrrpLabels(&infos[i]), "state", s.String()),
+				boolToInt(infos[i].State == s))
+		}
+	}
+
+	w.header("vrrp_priority", "gauge", "Effective priority of virtual router.")
+	for i := range infos {
+		w.sample("vrrp_priority", vrrpLabels(&infos[i]), infos[i].Priority)
+	}
+
+	counters := []struct {
+		name  string
+		help  string
+		value func(stats *Statistics) uint64
+	}{
+		{"vrrp_master_transitions_total", "Number of transitions to master.",
+			func(stats *Statistics) uint64 { return stats.MasterTransitions }},
+		{"vrrp_advertisements_received_total", "Number of advertisements received.",
+			func(stats *Statistics) uint64 { return stats.RcvdAdvertisements }},
+		{"vrrp_advertisements_sent_total", "Number of advertisements sent.",
+			func(stats *Statistics) uint64 { return stats.SentAdvertisements }},
+		{"vrrp_priority_zero_received_total", "Number of priority zero packets received.",
+			func(stats *Statistics) uint64 { return stats.RcvdPriZeroPackets }},
+		{"vrrp_priority_zero_sent_total", "Number of priority zero packets sent.",
+			func(stats *Statistics) uint64 { return stats.SentPriZeroPackets }},
+	}
+	for _, c := range counters {
+		w.header(c.name, "counter", c.help)
+		for i := range infos {
+			w.sample(c.name, vrrpLabels(&infos[i]), c.value(&infos[i].Stats))
+		}
+	}
+
+	errors := []struct {
+		reason string
+		value  func(stats *Statistics) uint64
+	}{
+		{"ttl", func(stats *Statistics) uint64 { return stats.IPTTLErrors }},
+		{"checksum", func(stats *Statistics) uint64 { return stats.ChecksumErrors }},
+		{"version", func(stats *Statistics) uint64 { return stats.VersionErrors }},
+		{"type", func(stats *Statistics) uint64 { return stats.RcvdInvalidTypePackets }},
+		{"address_list", func(stats *Statistics) uint64 { return stats.AddressListErrors }},
+		{"packet_length", func(stats *Statistics) uint64 { return stats.PacketLengthErrors }},
+		{"interval", func(stats *Statistics) uint64 { return stats.AdvIntervalErrors }},
+	}
+	w.header("vrrp_packet_errors_total", "counter", "Number of packet errors by reason.")
+	for i := range infos {
+		for _, e := range errors {
+			w.sample("vrrp_packet_errors_total",
+				withLabel(vrrpLabels(&infos[i]), "reason", e.reason),
+				e.value(&infos[i].Stats))
+		}
+	}
+}
+
+// writeGlobalMetrics Write global metrics.
+func (w *metricsWriter) writeGlobalMetrics(stats GlobalStatistics) {
+	w.header("packet_errors_total", "counter",
+		"Number of packet errors not attributed to a virtual router.")
+	w.sample("packet_errors_total", []label{{"reason", "checksum"}}, stats.ChecksumErrors)
+	w.sample("packet_errors_total", []label{{"reason", "version"}}, stats.VersionErrors)
+	w.sample("packet_errors_total", []label{{"reason", "vrid"}}, stats.VrIDErrors)
+	w.sample("packet_errors_total", []label{{"reason", "other"}}, stats.OtherErrors)
+}
+
+// writeRPCMetrics Write metrics of RPC modules.
+func (w *metricsWriter) writeRPCMetrics(m *Metrics) {
+	w.header("hostif_send_queue_depth", "gauge", "Number of packets waiting to be sent to hostif.")
+	w.sample("hostif_send_queue_depth", nil, m.hostif.SendQueueDepth())
+
+	w.header("grpc_connection_state", "gauge", "State of gRPC connection(1 for current state).")
+	type conn struct {
+		module string
+		state  string
+	}
+	conns := []conn{}
+	// datastore is nil if config source is file only.
+	if m.datastore != nil {
+		conns = append(conns, conn{"datastore", m.datastore.ConnState()})
+	}
+	conns = append(conns,
+		conn{"dpa", m.dpagent.ConnState()},
+		conn{"hostif", m.hostif.ConnState()})
+	for _, c := range conns {
+		w.sample("grpc_connection_state",
+			[]label{{"module", c.module}, {"state", c.state}}, 1)
+	}
+
+	w.header("dpa_rpc_duration_seconds", "histogram", "Latency of DPA RPC.")
+	latency := m.dpagent.Latency()
+	methods := []string{}
+	for method := range latency {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		h := latency[method]
+		labels := []label{{"method", method}}
+		for i, b := range h.Buckets {
+			w.sample("dpa_rpc_duration_seconds_bucket",
+				withLabel(labels, "le", fmt.Sprintf("%g", b)), h.Counts[i])
+		}
+		w.sample("dpa_rpc_duration_seconds_bucket", withLabel(labels, "le", "+Inf"), h.Count)
+		w.sample("dpa_rpc_duration_seconds_sum", labels, h.Sum)
+		w.sample("dpa_rpc_duration_seconds_count", labels, h.Count)
+	}
+}
+
+func (m *Metrics) handleMetrics(rw http.ResponseWriter, req *http.Request) {
+	w := &metricsWriter{}
+	w.writeVRRPMetrics(vmgr.VRRPInfos())
+	w.writeGlobalMetrics(vmgr.GlobalStatistics())
+	w.writeRPCMetrics(m)
+
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	if _, err := rw.Write(w.buf.Bytes()); err != nil {
+		log.Warnf("Can't write metrics: %v", err)
+	}
+}
+
+// Start Start metrics endpoint.
+func (m *Metrics) Start() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.isRunning == false {
+		listener, err := net.Listen("tcp", m.addr)
+		if err != nil {
+			return err
+		}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc(MetricsPath, m.handleMetrics)
+		m.server = &http.Server{Handler: mux}
+
+		m.wg.Add(1)
+		go func(server *http.Server) {
+			defer m.wg.Done()
+			if err := server.Serve(listener); err != http.ErrServerClosed {
+				log.Errorf("Metrics server failed: %v", err)
+			}
+			log.Infof("Stop metrics server.")
+		}(m.server)
+
+		m.isRunning = true
+	}
+
+	return nil
+}
+
+// Stop Stop metrics endpoint.
+func (m *Metrics) Stop() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.isRunning == true {
+		_ = m.server.Close()
+		m.isRunning = false
+	}
+}
+
+// Resume Resume module.
+func (m *Metrics) Resume() error {
+	// implement if necessary.
+	return nil
+}
+
+// Suspend Suspend module.
+func (m *Metrics) Suspend() error {
+	// metrics are served while suspended.
+	return nil
+}
+
+// Name Module name.
+func (m *Metrics) Name() string {
+	return MetricsModuleName
+}
@@ -0,0 +1,483 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lagopus/vrrpd/config"
+	"github.com/lagopus/vrrpd/logger"
+	"github.com/lagopus/vrrpd/mgmt"
+	"github.com/lagopus/vrrpd/models"
+	"github.com/lagopus/vrrpd/module"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MgmtServerModuleName Management server module name.
+	MgmtServerModuleName = "MgmtServerModule"
+)
+
+// MgmtServer Management gRPC server.
+// It is served on TCP and/or control socket(UNIX domain socket).
+// RPCs changing state of vrrpd are served only on control socket.
+type MgmtServer struct {
+	addr      string
+	socket    string
+	servers   []*grpc.Server
+	watchers  uint64
+	isRunning bool
+	wg        *sync.WaitGroup
+	lock      sync.Mutex
+}
+
+// NewMgmtServer New MgmtServer module.
+// TCP is not served if port is 0, and control socket is not served
+// if socket is empty.
+func NewMgmtServer(addr string, port int, socket string, wg *sync.WaitGroup) *MgmtServer {
+	m := &MgmtServer{
+		socket: socket,
+		wg:     wg,
+	}
+	if port > 0 {
+		m.addr = net.JoinHostPort(addr, fmt.Sprintf("%d", port))
+	}
+	return m
+}
+
+func toMgmtAF(af models.AddressFamily) mgmt.AddressFamily {
+	if af == models.AddressFamilyIPv6 {
+		return mgmt.AddressFamily_IPV6
+	}
+	return mgmt.AddressFamily_IPV4
+}
+
+// filterVRRPInfos Filter VRRPs by subinterface and VRID.
+func filterVRRPInfos(infos []VRRPInfo, subifname string, vrid uint32) []VRRPInfo {
+	filtered := []VRRPInfo{}
+	for _, info := range infos {
+		if subifname != "" && info.Subifname != subifname {
+			continue
+		}
+		if vrid != 0 && uint32(info.Vrid) != vrid {
+			continue
+		}
+		filtered = append(filtered, info)
+	}
+	return filtered
+}
+
+func toMgmtState(s VRRPState) mgmt.State {
+	switch s {
+	case StateBackup:
+		return mgmt.State_BACKUP
+	case StateMaster:
+		return mgmt.State_MASTER
+	case StateFault:
+		return mgmt.State_FAULT
+	case StateError:
+		return mgmt.State_ERROR
+	}
+	return mgmt.State_INITIALIZE
+}
+
+func toMgmtVRRP(info *VRRPInfo, now time.Time) *mgmt.VRRP {
+	vaddrs := []string{}
+	for _, vaddr := range info.VirtualAddresses {
+		vaddrs = append(vaddrs, vaddr.String())
+	}
+
+	var master, primary string
+	if info.MasterIP != nil {
+		master = info.MasterIP.String()
+	}
+	if info.PrimaryIP != nil {
+		primary = info.PrimaryIP.String()
+	}
+	var nextRetry uint64
+	if info.State == StateError && info.NextRetry.After(now) {
+		nextRetry = uint64(info.NextRetry.Sub(now) / time.Millisecond)
+	}
+
+	return &mgmt.VRRP{
+		Subinterface:                info.Subifname,
+		Af:                          toMgmtAF(info.AF),
+		Vrid:                        uint32(info.Vrid),
+		State:                       toMgmtState(info.State),
+		Priority:                    uint32(info.BasePriority),
+		EffectivePriority:           uint32(info.Priority),
+		Preempt:                     info.Preempt,
+		AdvertisementInterval:       uint32(info.AdvInterval),
+		MasterAdvertisementInterval: uint32(info.MasterAdvInt),
+		MasterDownInterval:          uint32(info.MasterDownInt),
+		SkewTime:                    uint32(info.SkewTime),
+		VirtualAddresses:            vaddrs,
+		VirtualMac:                  info.VirtualMAC.String(),
+		MasterAddress:               master,
+		SinceLastTransition:         uint64(now.Sub(info.LastTransition) / time.Millisecond),
+		Accept:                      info.Accept,
+		PrimaryAddress:              primary,
+		Version:                     info.Version.String(),
+		Error:                       info.LastError,
+		NextRetry:                   nextRetry,
+	}
+}
+
+func toMgmtTrigger(e VRRPEvent) mgmt.Trigger {
+	switch e {
+	case EventStartMaster:
+		return mgmt.Trigger_START_MASTER
+	case EventStartBackup:
+		return mgmt.Trigger_START_BACKUP
+	case EventMasterDown:
+		return mgmt.Trigger_MASTER_DOWN
+	case EventDetectedNewMaster:
+		return mgmt.Trigger_DETECTED_NEW_MASTER
+	case EventPreempt:
+		return mgmt.Trigger_PREEMPT
+	case EventShutdown:
+		return mgmt.Trigger_SHUTDOWN
+	case EventFault:
+		return mgmt.Trigger_FAULT
+	case EventRecover:
+		return mgmt.Trigger_RECOVER
+	}
+	return mgmt.Trigger_START
+}
+
+func toMgmtEvent(n *Notification, dropped uint64) *mgmt.Event {
+	var source string
+	if n.AdvSrcIP != nil {
+		source = n.AdvSrcIP.String()
+	}
+
+	return &mgmt.Event{
+		Subinterface: n.Subifname,
+		Af:           toMgmtAF(n.AF),
+		Vrid:         uint32(n.Vrid),
+		OldState:     toMgmtState(n.OldState),
+		NewState:     toMgmtState(n.NewState),
+		Trigger:      toMgmtTrigger(n.Event),
+		Timestamp:    n.Time.UnixNano(),
+		AdvPriority:  uint32(n.AdvPriority),
+		AdvSource:    source,
+		Dropped:      dropped,
+	}
+}
+
+func toMgmtStatistics(info *VRRPInfo) *mgmt.Statistics {
+	stats := &info.Stats
+	return &mgmt.Statistics{
+		Subinterface:           info.Subifname,
+		Af:                     toMgmtAF(info.AF),
+		Vrid:                   uint32(info.Vrid),
+		MasterTransitions:      stats.MasterTransitions,
+		RcvdAdvertisements:     stats.RcvdAdvertisements,
+		SentAdvertisements:     stats.SentAdvertisements,
+		AdvIntervalErrors:      stats.AdvIntervalErrors,
+		IpTtlErrors:            stats.IPTTLErrors,
+		ChecksumErrors:         stats.ChecksumErrors,
+		VersionErrors:          stats.VersionErrors,
+		RcvdInvalidTypePackets: stats.RcvdInvalidTypePackets,
+		AddressListErrors:      stats.AddressListErrors,
+		RcvdPriZeroPackets:     stats.RcvdPriZeroPackets,
+		SentPriZeroPackets:     stats.SentPriZeroPackets,
+		PacketLengthErrors:     stats.PacketLengthErrors,
+		DiscontinuityTime:      stats.DiscontinuityTime.Unix(),
+	}
+}
+
+// GetVRRPStates Get runtime state of virtual routers.
+func (m *MgmtServer) GetVRRPStates(ctx context.Context,
+	req *mgmt.GetVRRPStatesRequest) (*mgmt.GetVRRPStatesReply, error) {
+	now := time.Now()
+	reply := &mgmt.GetVRRPStatesReply{}
+	infos := filterVRRPInfos(vmgr.VRRPInfos(), req.Subinterface, req.Vrid)
+	for i := range infos {
+		reply.Vrrps = append(reply.Vrrps, toMgmtVRRP(&infos[i], now))
+	}
+
+	return reply, nil
+}
+
+// GetStatistics Get statistics of virtual routers.
+func (m *MgmtServer) GetStatistics(ctx context.Context,
+	req *mgmt.GetStatisticsRequest) (*mgmt.GetStatisticsReply, error) {
+	global := vmgr.GlobalStatistics()
+	reply := &mgmt.GetStatisticsReply{
+		Global: &mgmt.GlobalStatistics{
+			ChecksumErrors: global.ChecksumErrors,
+			VersionErrors:  global.VersionErrors,
+			VridErrors:     global.VrIDErrors,
+			OtherErrors:    global.OtherErrors,
+		},
+	}
+	infos := filterVRRPInfos(vmgr.VRRPInfos(), req.Subinterface, req.Vrid)
+	for i := range infos {
+		reply.Vrrps = append(reply.Vrrps, toMgmtStatistics(&infos[i]))
+	}
+
+	return reply, nil
+}
+
+// SetLogLevel Set log level.
+func (m *MgmtServer) SetLogLevel(ctx context.Context,
+	req *mgmt.SetLogLevelRequest) (*mgmt.SetLogLevelReply, error) {
+	previous, err := logger.SetLevel(req.Level)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	log.Infof("Log level: %s -> %s", previous, req.Level)
+
+	return &mgmt.SetLogLevelReply{Previous: previous}, nil
+}
+
+// ReloadConfig Reload config file, and apply changes.
+func (m *MgmtServer) ReloadConfig(ctx context.Context,
+	req *mgmt.ReloadConfigRequest) (*mgmt.ReloadConfigReply, error) {
+	result, err := ReloadConfig()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+
+	return &mgmt.ReloadConfigReply{
+		Added:           result.Added,
+		Deleted:         result.Deleted,
+		Changed:         result.Changed,
+		NotifyScripts:   result.NotifyScripts,
+		RestartRequired: result.RestartRequired,
+	}, nil
+}
+
+// Confirm Confirm pending commit.
+func (m *MgmtServer) Confirm(ctx context.Context,
+	req *mgmt.ConfirmRequest) (*mgmt.ConfirmReply, error) {
+	if err := config.GetMgr().Confirm(); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+
+	return &mgmt.ConfirmReply{}, nil
+}
+
+// WatchEvents Stream state change events of virtual routers.
+// Events are buffered per stream, and the number of events dropped
+// by overflow of the buffer is set to Dropped of the next event.
+func (m *MgmtServer) WatchEvents(req *mgmt.WatchEventsRequest,
+	stream mgmt.Management_WatchEventsServer) error {
+	name := fmt.Sprintf("%s-%d", MgmtServerModuleName,
+		atomic.AddUint64(&m.watchers, 1))
+	notifications := vmgr.Subscribe(name)
+	defer vmgr.Unsubscribe(name)
+
+	ctx := stream.Context()
+	var dropped uint64
+	for {
+		select {
+		case n, ok := <-notifications:
+			if !ok {
+				return nil
+			}
+			// dropped notifications are counted even if filtered.
+			dropped += n.Dropped
+			if n.Type != NotificationStateChange {
+				continue
+			}
+			if req.Subinterface != "" && n.Subifname != req.Subinterface {
+				continue
+			}
+			if req.Vrid != 0 && uint32(n.Vrid) != req.Vrid {
+				continue
+			}
+			if err := stream.Send(toMgmtEvent(n, dropped)); err != nil {
+				return err
+			}
+			dropped = 0
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// GetModuleStates Get state of modules.
+func (m *MgmtServer) GetModuleStates(ctx context.Context,
+	req *mgmt.GetModuleStatesRequest) (*mgmt.GetModuleStatesReply, error) {
+	return &mgmt.GetModuleStatesReply{
+		State:   module.GetState().String(),
+		Modules: module.GetModuleNames(),
+	}, nil
+}
+
+// GetConfig Get current and candidate config.
+func (m *MgmtServer) GetConfig(ctx context.Context,
+	req *mgmt.GetConfigRequest) (*mgmt.GetConfigReply, error) {
+	cmgr := config.GetMgr()
+
+	current, err := json.Marshal(cmgr.GetCurrentConfig())
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := json.Marshal(cmgr.GetModifiedConfig())
+	if err != nil {
+		return nil, err
+	}
+	pending, remaining := cmgr.PendingConfirm()
+	var lastRollback int64
+	if t := cmgr.LastRollback(); !t.IsZero() {
+		lastRollback = t.UnixNano()
+	}
+
+	return &mgmt.GetConfigReply{
+		Current:          string(current),
+		Candidate:        string(candidate),
+		ConfirmPending:   pending,
+		ConfirmRemaining: uint64(remaining / time.Millisecond),
+		LastRollback:     lastRollback,
+	}, nil
+}
+
+// readOnlyMgmtServer Management gRPC server served on TCP.
+// RPCs changing state of vrrpd are denied.
+type readOnlyMgmtServer struct {
+	*MgmtServer
+}
+
+func errReadOnly(rpc string) error {
+	return status.Errorf(codes.PermissionDenied,
+		"%s is allowed only on control socket", rpc)
+}
+
+// SetLogLevel Deny setting log level.
+func (m *readOnlyMgmtServer) SetLogLevel(ctx context.Context,
+	req *mgmt.SetLogLevelRequest) (*mgmt.SetLogLevelReply, error) {
+	return nil, errReadOnly("SetLogLevel")
+}
+
+// ReloadConfig Deny reloading config file.
+func (m *readOnlyMgmtServer) ReloadConfig(ctx context.Context,
+	req *mgmt.ReloadConfigRequest) (*mgmt.ReloadConfigReply, error) {
+	return nil, errReadOnly("ReloadConfig")
+}
+
+// Confirm Deny confirming pending commit.
+func (m *readOnlyMgmtServer) Confirm(ctx context.Context,
+	req *mgmt.ConfirmRequest) (*mgmt.ConfirmReply, error) {
+	return nil, errReadOnly("Confirm")
+}
+
+// Start Start management server.
+func (m *MgmtServer) Start() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.isRunning == false {
+		listeners := []net.Listener{}
+		closeListeners := func() {
+			for _, l := range listeners {
+				_ = l.Close()
+			}
+		}
+		servers := []*grpc.Server{}
+
+		if m.addr != "" {
+			listener, err := net.Listen("tcp", m.addr)
+			if err != nil {
+				return err
+			}
+			listeners = append(listeners, listener)
+			server := grpc.NewServer()
+			mgmt.RegisterManagementServer(server, &readOnlyMgmtServer{m})
+			servers = append(servers, server)
+		}
+
+		if m.socket != "" {
+			// remove stale socket.
+			if err := os.Remove(m.socket); err != nil && !os.IsNotExist(err) {
+				closeListeners()
+				return err
+			}
+			listener, err := net.Listen("unix", m.socket)
+			if err != nil {
+				closeListeners()
+				return err
+			}
+			listeners = append(listeners, listener)
+			if err = os.Chmod(m.socket, 0600); err != nil {
+				closeListeners()
+				return err
+			}
+			server := grpc.NewServer()
+			mgmt.RegisterManagementServer(server, m)
+			servers = append(servers, server)
+		}
+
+		m.servers = servers
+		for i, listener := range listeners {
+			m.wg.Add(1)
+			go func(server *grpc.Server, listener net.Listener) {
+				defer m.wg.Done()
+				if err := server.Serve(listener); err != nil {
+					log.Errorf("Management server failed: %v", err)
+				}
+				log.Infof("Stop management server(%s).", listener.Addr())
+			}(servers[i], listener)
+		}
+
+		m.isRunning = true
+	}
+
+	return nil
+}
+
+// Stop Stop management server.
+func (m *MgmtServer) Stop() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.isRunning == true {
+		for _, server := range m.servers {
+			server.Stop()
+		}
+		m.isRunning = false
+	}
+}
+
+// Resume Resume module.
+func (m *MgmtServer) Resume() error {
+	// implement if necessary.
+	return nil
+}
+
+// Suspend Suspend module.
+func (m *MgmtServer) Suspend() error {
+	// state is served while suspended.
+	return nil
+}
+
+// Name Module name.
+func (m *MgmtServer) Name() string {
+	return MgmtServerModuleName
+}
@@ -0,0 +1,205 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"net"
+	"time"
+
+	"github.com/lagopus/vrrpd/models"
+	"github.com/lagopus/vrrpd/packets"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// NotificationChannelSize Size of channel of subscriber.
+	NotificationChannelSize = 1000
+)
+
+// NotificationType Type of notification.
+type NotificationType uint8
+
+const (
+	// NotificationStateChange State of VRRP changed.
+	NotificationStateChange NotificationType = iota
+	// NotificationProtoError Protocol error of received packet.
+	NotificationProtoError
+)
+
+func (t NotificationType) String() string {
+	switch t {
+	case NotificationStateChange:
+		return "StateChange"
+	case NotificationProtoError:
+		return "ProtoError"
+	}
+	return "UNKNOWN"
+}
+
+// NewMasterReason Reason of transition to master
+// (RFC 6527 vrrpv3StatisticsNewMasterReason).
+type NewMasterReason uint8
+
+const (
+	// NewMasterReasonNotMaster Not transitioned to master.
+	NewMasterReasonNotMaster NewMasterReason = iota
+	// NewMasterReasonPriority Priority is 255(owner).
+	NewMasterReasonPriority
+	// NewMasterReasonPreempted Preempted master.
+	NewMasterReasonPreempted
+	// NewMasterReasonMasterNoResponse Master down timer expired.
+	NewMasterReasonMasterNoResponse
+)
+
+func (r NewMasterReason) String() string {
+	switch r {
+	case NewMasterReasonNotMaster:
+		return "NotMaster"
+	case NewMasterReasonPriority:
+		return "Priority"
+	case NewMasterReasonPreempted:
+		return "Preempted"
+	case NewMasterReasonMasterNoResponse:
+		return "MasterNoResponse"
+	}
+	return "UNKNOWN"
+}
+
+// ProtoErrReason Reason of protocol error
+// (RFC 6527 vrrpv3StatisticsProtoErrReason).
+type ProtoErrReason uint8
+
+const (
+	// ProtoErrReasonNoError No error.
+	ProtoErrReasonNoError ProtoErrReason = iota
+	// ProtoErrReasonIPTTLError Bad TTL/Hop Limit.
+	ProtoErrReasonIPTTLError
+	// ProtoErrReasonVersionError Bad version.
+	ProtoErrReasonVersionError
+	// ProtoErrReasonChecksumError Bad checksum.
+	ProtoErrReasonChecksumError
+	// ProtoErrReasonVrIDError Unknown VRID.
+	ProtoErrReasonVrIDError
+)
+
+func (r ProtoErrReason) String() string {
+	switch r {
+	case ProtoErrReasonNoError:
+		return "NoError"
+	case ProtoErrReasonIPTTLError:
+		return "IPTTLError"
+	case ProtoErrReasonVersionError:
+		return "VersionError"
+	case ProtoErrReasonChecksumError:
+		return "ChecksumError"
+	case ProtoErrReasonVrIDError:
+		return "VrIDError"
+	}
+	return "UNKNOWN"
+}
+
+// toProtoErrReason Convert reason of decode error, if it is protocol error.
+func toProtoErrReason(reason packets.DecodeErrorReason) (ProtoErrReason, bool) {
+	switch reason {
+	case packets.DecodeErrorTTL:
+		return ProtoErrReasonIPTTLError, true
+	case packets.DecodeErrorVersion:
+		return ProtoErrReasonVersionError, true
+	case packets.DecodeErrorChecksum:
+		return ProtoErrReasonChecksumError, true
+	}
+	return ProtoErrReasonNoError, false
+}
+
+// Notification Notification of VRRP.
+type Notification struct {
+	Type      NotificationType
+	Time      time.Time
+	Subifname string
+	AF        models.AddressFamily
+	Vrid      uint8
+	// StateChange.
+	OldState        VRRPState
+	NewState        VRRPState
+	Event           VRRPEvent
+	MasterIP        net.IP
+	NewMasterReason NewMasterReason
+	// advertisement causing state change(nil if not caused by advertisement).
+	AdvSrcIP    net.IP
+	AdvPriority uint8
+	// ProtoError.
+	ProtoErrReason ProtoErrReason
+	// number of notifications dropped before this one.
+	Dropped uint64
+}
+
+// subscriber of notifications.
+type subscriber struct {
+	ch      chan *Notification
+	dropped uint64
+}
+
+// Subscribe Subscribe notifications.
+// Notifications are dropped if the channel is full, and the number of
+// dropped notifications is set to Dropped of the next notification.
+func (vmgr *VRRPMgr) Subscribe(name string) <-chan *Notification {
+	vmgr.subscribersLock.Lock()
+	defer vmgr.subscribersLock.Unlock()
+
+	if s, ok := vmgr.subscribers[name]; ok {
+		close(s.ch)
+	}
+	s := &subscriber{ch: make(chan *Notification, NotificationChannelSize)}
+	vmgr.subscribers[name] = s
+
+	return s.ch
+}
+
+// Unsubscribe Unsubscribe notifications, and close the channel.
+func (vmgr *VRRPMgr) Unsubscribe(name string) {
+	vmgr.subscribersLock.Lock()
+	defer vmgr.subscribersLock.Unlock()
+
+	if s, ok := vmgr.subscribers[name]; ok {
+		close(s.ch)
+		delete(vmgr.subscribers, name)
+	}
+}
+
+// publish Publish notification to subscribers.
+// It is called with lock of VRRP, so it doesn't block.
+func (vmgr *VRRPMgr) publish(n *Notification) {
+	vmgr.subscribersLock.Lock()
+	defer vmgr.subscribersLock.Unlock()
+
+	for name, s := range vmgr.subscribers {
+		sn := n
+		if s.dropped > 0 {
+			c := *n
+			c.Dropped = s.dropped
+			sn = &c
+		}
+
+		select {
+		case s.ch <- sn:
+			s.dropped = 0
+		default:
+			s.dropped++
+			log.Errorf("Subscriber %s: drop notification: %v", name, n.Type)
+		}
+	}
+}
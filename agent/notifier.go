@@ -0,0 +1,246 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/lagopus/vrrpd/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// NotifierModuleName Notifier module name.
+	NotifierModuleName = "NotifierModule"
+	// notifyQueueSize Size of queue of notify scripts per VRRP.
+	notifyQueueSize = 100
+)
+
+// notifyJob Command of notify script to be run.
+type notifyJob struct {
+	name         string
+	command      string
+	timeout      time.Duration
+	notification *Notification
+}
+
+// Notifier Runner of notify scripts on state transitions.
+// Scripts are run asynchronously, and scripts of the same VRRP
+// are run in order of transitions.
+type Notifier struct {
+	scripts   map[string]*models.NotifyScript
+	queues    map[string]chan *notifyJob
+	isRunning bool
+	wg        *sync.WaitGroup
+	lock      sync.Mutex
+}
+
+// NewNotifier New Notifier module.
+func NewNotifier(nss map[string]*models.NotifyScript, wg *sync.WaitGroup) *Notifier {
+	scripts := map[string]*models.NotifyScript{}
+	for name, ns := range nss {
+		scripts[name] = ns.Copy()
+	}
+
+	return &Notifier{
+		scripts: scripts,
+		queues:  map[string]chan *notifyJob{},
+		wg:      wg,
+	}
+}
+
+// SetScripts Set notify scripts.
+// Queued scripts are run with previous commands.
+func (nf *Notifier) SetScripts(nss map[string]*models.NotifyScript) {
+	nf.lock.Lock()
+	defer nf.lock.Unlock()
+
+	scripts := map[string]*models.NotifyScript{}
+	for name, ns := range nss {
+		scripts[name] = ns.Copy()
+	}
+	nf.scripts = scripts
+}
+
+// notifyCommand Command of notify script for state.
+func notifyCommand(ns *models.NotifyScript, s VRRPState) string {
+	switch s {
+	case StateMaster:
+		return ns.Master
+	case StateBackup:
+		return ns.Backup
+	case StateFault:
+		return ns.Fault
+	case StateInitialize:
+		return ns.Initialize
+	}
+	return ""
+}
+
+// runNotifyScript Run command of notify script, and log exit status.
+// Subinterface, address family, VRID and new state are passed as
+// arguments, and details of transition are passed as environment variables.
+func runNotifyScript(job *notifyJob) {
+	n := job.notification
+	ctx, cancel := context.WithTimeout(context.Background(), job.timeout)
+	defer cancel()
+
+	var master string
+	if n.MasterIP != nil {
+		master = n.MasterIP.String()
+	}
+	vrid := fmt.Sprintf("%d", n.Vrid)
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", job.command+` "$@"`, "sh",
+		n.Subifname, n.AF.String(), vrid, n.NewState.String())
+	cmd.Env = append(os.Environ(),
+		"VRRP_SUBINTERFACE="+n.Subifname,
+		"VRRP_AF="+n.AF.String(),
+		"VRRP_VRID="+vrid,
+		"VRRP_STATE="+n.NewState.String(),
+		"VRRP_OLD_STATE="+n.OldState.String(),
+		"VRRP_EVENT="+n.Event.String(),
+		"VRRP_MASTER="+master,
+	)
+
+	objID := createObjID(n.Subifname, n.AF, n.Vrid)
+	err := cmd.Run()
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		log.Warnf("Notify script %s(%s) of VRRP(%s) timed out after %v",
+			job.name, n.NewState, objID, job.timeout)
+	case err != nil:
+		log.Warnf("Notify script %s(%s) of VRRP(%s) failed: %v",
+			job.name, n.NewState, objID, err)
+	default:
+		log.Infof("Notify script %s(%s) of VRRP(%s) exited with status 0",
+			job.name, n.NewState, objID)
+	}
+}
+
+func (nf *Notifier) runQueue(queue chan *notifyJob) {
+	defer nf.wg.Done()
+	for job := range queue {
+		runNotifyScript(job)
+	}
+}
+
+// Notify Run notify scripts for state change of VRRP.
+// Global notify script is run first, and then the script named name.
+// It is called with lock of VRRP, so it doesn't block.
+func (nf *Notifier) Notify(name string, n *Notification) {
+	nf.lock.Lock()
+	defer nf.lock.Unlock()
+
+	if nf.isRunning == false {
+		return
+	}
+
+	objID := createObjID(n.Subifname, n.AF, n.Vrid)
+	jobs := []*notifyJob{}
+	for _, nsname := range []string{models.NotifyScriptGlobal, name} {
+		if nsname == "" {
+			continue
+		}
+		ns, ok := nf.scripts[nsname]
+		if !ok {
+			if nsname != models.NotifyScriptGlobal {
+				log.Warnf("Notify script %s of VRRP(%s) is not found", nsname, objID)
+			}
+			continue
+		}
+		if command := notifyCommand(ns, n.NewState); command != "" {
+			jobs = append(jobs, &notifyJob{
+				name:         nsname,
+				command:      command,
+				timeout:      time.Duration(ns.Timeout) * time.Second,
+				notification: n,
+			})
+		}
+	}
+
+	queue, ok := nf.queues[objID]
+	if len(jobs) > 0 && !ok {
+		queue = make(chan *notifyJob, notifyQueueSize)
+		nf.queues[objID] = queue
+		nf.wg.Add(1)
+		go nf.runQueue(queue)
+	}
+	for _, job := range jobs {
+		select {
+		case queue <- job:
+		default:
+			log.Warnf("Notify script %s(%s) of VRRP(%s) is dropped: queue is full",
+				job.name, n.NewState, objID)
+		}
+	}
+
+	// VRRP is deleted, queued scripts are run and queue is released.
+	if queue != nil && n.Event == EventShutdown {
+		close(queue)
+		delete(nf.queues, objID)
+	}
+}
+
+// Start Start notifier.
+func (nf *Notifier) Start() error {
+	nf.lock.Lock()
+	defer nf.lock.Unlock()
+
+	if nf.isRunning == false {
+		nf.isRunning = true
+	}
+
+	return nil
+}
+
+// Stop Stop notifier.
+// Queued scripts are still run.
+func (nf *Notifier) Stop() {
+	nf.lock.Lock()
+	defer nf.lock.Unlock()
+
+	if nf.isRunning == true {
+		for objID, queue := range nf.queues {
+			close(queue)
+			delete(nf.queues, objID)
+		}
+		nf.isRunning = false
+	}
+}
+
+// Resume Resume module.
+func (nf *Notifier) Resume() error {
+	// implement if necessary.
+	return nil
+}
+
+// Suspend Suspend module.
+func (nf *Notifier) Suspend() error {
+	// implement if necessary.
+	return nil
+}
+
+// Name Module name.
+func (nf *Notifier) Name() string {
+	return NotifierModuleName
+}
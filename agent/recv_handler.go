@@ -19,6 +19,7 @@ package agent
 import (
 	"sync"
 
+	"github.com/lagopus/vrrpd/module"
 	"github.com/lagopus/vrrpd/rpc"
 	log "github.com/sirupsen/logrus"
 )
@@ -50,10 +51,15 @@ func NewRecvHandler(wg *sync.WaitGroup) *RecvHandler {
 func (h *RecvHandler) handlerLoop() {
 	defer h.wg.Done()
 
+	prober := (module.GetModule(ICMPProberModuleName)).(*ICMPProber)
+
 	for {
 		select {
 		case packets := <-h.handlerChannel:
-			vmgr.RecvVRRPAdv(packets)
+			// replies of probe are handled by ICMPProber.
+			if packets = prober.RecvPackets(packets); len(packets.Packets) != 0 {
+				vmgr.RecvVRRPAdv(packets)
+			}
 		case <-h.stopChannel:
 			log.Infof("Stop handlerLoop.")
 			return
@@ -0,0 +1,55 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"strings"
+
+	"github.com/lagopus/vrrpd/config"
+	"github.com/lagopus/vrrpd/module"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReloadConfig Read config file again, and apply only changes.
+// Notify scripts are set to Notifier, and VRRPs are updated
+// by UpdateHandler.
+func ReloadConfig() (*config.ReloadResult, error) {
+	updateHandler := (module.GetModule(UpdateHandlerModuleName)).(*UpdateHandler)
+	notifier := (module.GetModule(NotifierModuleName)).(*Notifier)
+	apply := func(conf *config.AgentConfig) error {
+		notifier.SetScripts(conf.NotifyScripts)
+		return updateHandler.Apply(conf)
+	}
+
+	result, err := config.GetMgr().ReloadConfig(apply)
+	if err != nil {
+		log.Errorf("[reload] failed: %v", err)
+		return nil, err
+	}
+
+	if len(result.RestartRequired) > 0 {
+		log.Warnf("[reload] %s changed, restart is required to apply",
+			strings.Join(result.RestartRequired, ", "))
+	}
+	if result.IsChanged() {
+		log.Infof("[reload] success: %v", result)
+	} else {
+		log.Infof("[reload] config is not changed")
+	}
+
+	return result, nil
+}
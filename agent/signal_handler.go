@@ -70,8 +70,20 @@ func (sh *SignalHandler) handleExit() {
 	log.Infof("Stop signalHandlerLoop.")
 }
 
+// handleHup Reload config file.
+// It is reloaded in background not to block other signals,
+// and reloads are serialized in ReloadConfig.
 func (sh *SignalHandler) handleHup() {
 	log.Debugf("call handleHup.")
+	go func() {
+		// errors are logged in ReloadConfig.
+		_, _ = ReloadConfig()
+	}()
+}
+
+// handleUsr2 Rotate log file.
+func (sh *SignalHandler) handleUsr2() {
+	log.Debugf("call handleUsr2.")
 	if err := logger.Rotate(); err != nil {
 		log.Errorf("Can't log rotate: %v.", err)
 	}
@@ -82,6 +94,7 @@ func (sh *SignalHandler) signalHandlerLoop() {
 
 	signal.Notify(sh.signalChannel,
 		syscall.SIGHUP,
+		syscall.SIGUSR2,
 		syscall.SIGINT,
 		syscall.SIGTERM,
 		syscall.SIGQUIT)
@@ -92,6 +105,8 @@ func (sh *SignalHandler) signalHandlerLoop() {
 			switch s {
 			case syscall.SIGHUP:
 				sh.handleHup()
+			case syscall.SIGUSR2:
+				sh.handleUsr2()
 			case syscall.SIGINT:
 				sh.handleExit()
 				return
@@ -0,0 +1,481 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// The following was in reference.
+// - RFC 6527 Definitions of Managed Objects for VRRPv3
+
+package agent
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lagopus/vrrpd/agentx"
+	"github.com/lagopus/vrrpd/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// SNMPSubagentModuleName SNMP subagent module name.
+	SNMPSubagentModuleName = "SNMPSubagentModule"
+	// SNMPReconnectInterval Interval of reconnecting to master agent.
+	SNMPReconnectInterval = 10 * time.Second
+	// snmpSubscriberName Name of subscriber of notifications.
+	snmpSubscriberName = "snmp"
+	// snmpIfIndexBase Base of ifIndex assigned to subinterface
+	// not found in kernel.
+	snmpIfIndexBase = 0x40000000
+)
+
+var (
+	vrrpv3MIB = agentx.MustParseOID("1.3.6.1.2.1.207")
+	// vrrpv3Notifications.
+	vrrpv3NewMaster  = vrrpv3MIB.Append(0, 1)
+	vrrpv3ProtoError = vrrpv3MIB.Append(0, 2)
+	// vrrpv3OperationsEntry.
+	vrrpv3OperationsEntry = vrrpv3MIB.Append(1, 1, 1, 1)
+	// vrrpv3AssociatedIpAddrEntry.
+	vrrpv3AssociatedIPAddrEntry = vrrpv3MIB.Append(1, 1, 2, 1)
+	// vrrpv3Statistics.
+	vrrpv3Statistics = vrrpv3MIB.Append(1, 2)
+	// vrrpv3StatisticsEntry.
+	vrrpv3StatisticsEntry = vrrpv3Statistics.Append(5, 1)
+
+	snmpSysUpTime   = agentx.MustParseOID("1.3.6.1.2.1.1.3.0")
+	snmpSnmpTrapOID = agentx.MustParseOID("1.3.6.1.6.3.1.1.4.1.0")
+)
+
+// columns of vrrpv3OperationsEntry.
+const (
+	vrrpv3OperationsMasterIPAddr   = 3
+	vrrpv3OperationsPrimaryIPAddr  = 4
+	vrrpv3OperationsVirtualMacAddr = 5
+	vrrpv3OperationsStatus         = 6
+	vrrpv3OperationsPriority       = 7
+	vrrpv3OperationsAddrCount      = 8
+	vrrpv3OperationsAdvInterval    = 9
+	vrrpv3OperationsPreemptMode    = 10
+	vrrpv3OperationsAcceptMode     = 11
+	vrrpv3OperationsUpTime         = 12
+	vrrpv3OperationsRowStatus      = 13
+)
+
+// columns of vrrpv3AssociatedIpAddrEntry.
+const (
+	vrrpv3AssociatedIPAddrRowStatus = 2
+)
+
+// columns of vrrpv3StatisticsEntry.
+const (
+	vrrpv3StatisticsMasterTransitions      = 1
+	vrrpv3StatisticsNewMasterReason        = 2
+	vrrpv3StatisticsRcvdAdvertisements     = 3
+	vrrpv3StatisticsAdvIntervalErrors      = 4
+	vrrpv3StatisticsIPTTLErrors            = 5
+	vrrpv3StatisticsProtoErrReason         = 6
+	vrrpv3StatisticsRcvdPriZeroPackets     = 7
+	vrrpv3StatisticsSentPriZeroPackets     = 8
+	vrrpv3StatisticsRcvdInvalidTypePackets = 9
+	vrrpv3StatisticsAddressListErrors      = 10
+	vrrpv3StatisticsPacketLengthErrors     = 11
+	vrrpv3StatisticsRowDiscontinuityTime   = 12
+	vrrpv3StatisticsRefreshRate            = 13
+)
+
+// scalars of vrrpv3Statistics.
+const (
+	vrrpv3RouterChecksumErrors              = 1
+	vrrpv3RouterVersionErrors               = 2
+	vrrpv3RouterVrIDErrors                  = 3
+	vrrpv3GlobalStatisticsDiscontinuityTime = 4
+)
+
+// values of textual conventions.
+const (
+	snmpTrue            = 1
+	snmpFalse           = 2
+	snmpRowStatusActive = 1
+	snmpInetAddrIPv4    = 1
+	snmpInetAddrIPv6    = 2
+	snmpStatusInit      = 1
+	snmpStatusBackup    = 2
+	snmpStatusMaster    = 3
+)
+
+// SNMPSubagent AgentX subagent serving VRRPv3-MIB.
+type SNMPSubagent struct {
+	addr        string
+	startTime   time.Time
+	session     *agentx.Session
+	ifIndexes   map[string]uint32
+	nextIfIndex uint32
+	stopChannel chan bool
+	isRunning   bool
+	wg          *sync.WaitGroup
+	lock        sync.Mutex
+	sessionLock sync.Mutex
+	ifIndexLock sync.Mutex
+}
+
+// NewSNMPSubagent New SNMPSubagent module.
+func NewSNMPSubagent(addr string, wg *sync.WaitGroup) *SNMPSubagent {
+	return &SNMPSubagent{
+		addr:        addr,
+		startTime:   time.Now(),
+		ifIndexes:   map[string]uint32{},
+		nextIfIndex: snmpIfIndexBase,
+		stopChannel: make(chan bool),
+		wg:          wg,
+	}
+}
+
+// ifIndex ifIndex of subinterface.
+// Index of kernel interface is used if exists, otherwise
+// index is assigned. Index is kept while running.
+func (s *SNMPSubagent) ifIndex(subifname string) uint32 {
+	s.ifIndexLock.Lock()
+	defer s.ifIndexLock.Unlock()
+
+	if index, ok := s.ifIndexes[subifname]; ok {
+		return index
+	}
+
+	var index uint32
+	if iface, err := net.InterfaceByName(subifname); err == nil {
+		index = uint32(iface.Index)
+	} else {
+		s.nextIfIndex++
+		index = s.nextIfIndex
+	}
+	s.ifIndexes[subifname] = index
+
+	return index
+}
+
+// rowIndex Index of row(ifIndex, VrId, InetAddrType).
+func (s *SNMPSubagent) rowIndex(subifname string, af models.AddressFamily,
+	vrid uint8) agentx.OID {
+	addrType := uint32(snmpInetAddrIPv4)
+	if af == models.AddressFamilyIPv6 {
+		addrType = snmpInetAddrIPv6
+	}
+	return agentx.OID{s.ifIndex(subifname), uint32(vrid), addrType}
+}
+
+// timeTicks Centiseconds since start of subagent.
+func (s *SNMPSubagent) timeTicks(t time.Time) uint32 {
+	if t.IsZero() || t.Before(s.startTime) {
+		return 0
+	}
+	return uint32(t.Sub(s.startTime) / (10 * time.Millisecond))
+}
+
+func inetAddress(af models.AddressFamily, ip net.IP) []byte {
+	if af == models.AddressFamilyIPv6 {
+		ip = ip.To16()
+	} else {
+		ip = ip.To4()
+	}
+	return append([]byte{}, ip...)
+}
+
+func truthValue(b bool) int32 {
+	if b {
+		return snmpTrue
+	}
+	return snmpFalse
+}
+
+func operationsStatus(state VRRPState) int32 {
+	switch state {
+	case StateBackup:
+		return snmpStatusBackup
+	case StateMaster:
+		return snmpStatusMaster
+	}
+	return snmpStatusInit
+}
+
+func integer(name agentx.OID, v int32) agentx.VarBind {
+	return agentx.VarBind{Type: agentx.TypeInteger, Name: name, Value: v}
+}
+
+func octetString(name agentx.OID, v []byte) agentx.VarBind {
+	return agentx.VarBind{Type: agentx.TypeOctetString, Name: name, Value: v}
+}
+
+func counter64(name agentx.OID, v uint64) agentx.VarBind {
+	return agentx.VarBind{Type: agentx.TypeCounter64, Name: name, Value: v}
+}
+
+func timeTicks(name agentx.OID, v uint32) agentx.VarBind {
+	return agentx.VarBind{Type: agentx.TypeTimeTicks, Name: name, Value: v}
+}
+
+// vrrpVarBinds Variables of VRRP in vrrpv3OperationsTable,
+// vrrpv3AssociatedIpAddrTable and vrrpv3StatisticsTable.
+func (s *SNMPSubagent) vrrpVarBinds(info *VRRPInfo) []agentx.VarBind {
+	index := s.rowIndex(info.Subifname, info.AF, info.Vrid)
+	ops := func(column uint32) agentx.OID {
+		return vrrpv3OperationsEntry.Append(column).Append(index...)
+	}
+	stats := func(column uint32) agentx.OID {
+		return vrrpv3StatisticsEntry.Append(column).Append(index...)
+	}
+
+	vbs := []agentx.VarBind{
+		octetString(ops(vrrpv3OperationsMasterIPAddr), inetAddress(info.AF, info.MasterIP)),
+		octetString(ops(vrrpv3OperationsPrimaryIPAddr), inetAddress(info.AF, info.PrimaryIP)),
+		octetString(ops(vrrpv3OperationsVirtualMacAddr), info.VirtualMAC),
+		integer(ops(vrrpv3OperationsStatus), operationsStatus(info.State)),
+		{Type: agentx.TypeGauge32, Name: ops(vrrpv3OperationsPriority),
+			Value: uint32(info.Priority)},
+		integer(ops(vrrpv3OperationsAddrCount), int32(len(info.VirtualAddresses))),
+		integer(ops(vrrpv3OperationsAdvInterval), int32(info.AdvInterval)),
+		integer(ops(vrrpv3OperationsPreemptMode), truthValue(info.Preempt)),
+		integer(ops(vrrpv3OperationsAcceptMode), truthValue(info.Accept)),
+		timeTicks(ops(vrrpv3OperationsUpTime), s.timeTicks(info.UpTime)),
+		integer(ops(vrrpv3OperationsRowStatus), snmpRowStatusActive),
+
+		{Type: agentx.TypeCounter32, Name: stats(vrrpv3StatisticsMasterTransitions),
+			Value: uint32(info.Stats.MasterTransitions)},
+		integer(stats(vrrpv3StatisticsNewMasterReason), int32(info.NewMasterReason)),
+		counter64(stats(vrrpv3StatisticsRcvdAdvertisements), info.Stats.RcvdAdvertisements),
+		counter64(stats(vrrpv3StatisticsAdvIntervalErrors), info.Stats.AdvIntervalErrors),
+		counter64(stats(vrrpv3StatisticsIPTTLErrors), info.Stats.IPTTLErrors),
+		integer(stats(vrrpv3StatisticsProtoErrReason), int32(info.ProtoErrReason)),
+		counter64(stats(vrrpv3StatisticsRcvdPriZeroPackets), info.Stats.RcvdPriZeroPackets),
+		counter64(stats(vrrpv3StatisticsSentPriZeroPackets), info.Stats.SentPriZeroPackets),
+		counter64(stats(vrrpv3StatisticsRcvdInvalidTypePackets),
+			info.Stats.RcvdInvalidTypePackets),
+		counter64(stats(vrrpv3StatisticsAddressListErrors), info.Stats.AddressListErrors),
+		counter64(stats(vrrpv3StatisticsPacketLengthErrors), info.Stats.PacketLengthErrors),
+		timeTicks(stats(vrrpv3StatisticsRowDiscontinuityTime),
+			s.timeTicks(info.Stats.DiscontinuityTime)),
+		// served from live counters.
+		{Type: agentx.TypeGauge32, Name: stats(vrrpv3StatisticsRefreshRate),
+			Value: uint32(0)},
+	}
+
+	for _, vaddr := range info.VirtualAddresses {
+		addr := inetAddress(info.AF, vaddr)
+		name := vrrpv3AssociatedIPAddrEntry.Append(vrrpv3AssociatedIPAddrRowStatus).
+			Append(index...).Append(uint32(len(addr)))
+		for _, b := range addr {
+			name = append(name, uint32(b))
+		}
+		vbs = append(vbs, integer(name, snmpRowStatusActive))
+	}
+
+	return vbs
+}
+
+// Tree Get VRRPv3-MIB view of running VRRPs.
+func (s *SNMPSubagent) Tree() *agentx.Tree {
+	global := vmgr.GlobalStatistics()
+	vbs := []agentx.VarBind{
+		counter64(vrrpv3Statistics.Append(vrrpv3RouterChecksumErrors, 0),
+			global.ChecksumErrors),
+		counter64(vrrpv3Statistics.Append(vrrpv3RouterVersionErrors, 0),
+			global.VersionErrors),
+		counter64(vrrpv3Statistics.Append(vrrpv3RouterVrIDErrors, 0),
+			global.VrIDErrors),
+		timeTicks(vrrpv3Statistics.Append(vrrpv3GlobalStatisticsDiscontinuityTime, 0), 0),
+	}
+
+	infos := vmgr.VRRPInfos()
+	for i := range infos {
+		vbs = append(vbs, s.vrrpVarBinds(&infos[i])...)
+	}
+
+	return agentx.NewTree(vbs)
+}
+
+// notificationVarBinds Variables of notification,
+// returns nil if it is not notified.
+func (s *SNMPSubagent) notificationVarBinds(n *Notification) []agentx.VarBind {
+	index := s.rowIndex(n.Subifname, n.AF, n.Vrid)
+	vbs := []agentx.VarBind{
+		timeTicks(snmpSysUpTime, s.timeTicks(n.Time)),
+	}
+
+	switch n.Type {
+	case NotificationStateChange:
+		if n.NewState != StateMaster {
+			return nil
+		}
+		vbs = append(vbs,
+			agentx.VarBind{Type: agentx.TypeObjectIdentifier, Name: snmpSnmpTrapOID,
+				Value: vrrpv3NewMaster},
+			octetString(vrrpv3OperationsEntry.Append(vrrpv3OperationsMasterIPAddr).
+				Append(index...), inetAddress(n.AF, n.MasterIP)),
+			integer(vrrpv3StatisticsEntry.Append(vrrpv3StatisticsNewMasterReason).
+				Append(index...), int32(n.NewMasterReason)))
+	case NotificationProtoError:
+		vbs = append(vbs,
+			agentx.VarBind{Type: agentx.TypeObjectIdentifier, Name: snmpSnmpTrapOID,
+				Value: vrrpv3ProtoError},
+			integer(vrrpv3StatisticsEntry.Append(vrrpv3StatisticsProtoErrReason).
+				Append(index...), int32(n.ProtoErrReason)))
+	default:
+		return nil
+	}
+
+	return vbs
+}
+
+// notify Send notification to master agent if connected.
+func (s *SNMPSubagent) notify(n *Notification) {
+	vbs := s.notificationVarBinds(n)
+	if vbs == nil {
+		return
+	}
+
+	s.sessionLock.Lock()
+	defer s.sessionLock.Unlock()
+
+	if s.session == nil {
+		log.Debugf("Drop notification(not connected): %v", n.Type)
+		return
+	}
+	if err := s.session.Notify(vbs); err != nil {
+		log.Errorf("AgentX notify failed: %v", err)
+	}
+}
+
+// open Connect to master agent, and register VRRPv3-MIB.
+func (s *SNMPSubagent) open() (*agentx.Session, error) {
+	session, err := agentx.Dial(s.addr, agentx.DefaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = session.Open(vrrpv3MIB, "vrrpd"); err != nil {
+		_ = session.Close(agentx.ReasonOther)
+		return nil, err
+	}
+	if err = session.Register(vrrpv3MIB, agentx.DefaultPriority); err != nil {
+		_ = session.Close(agentx.ReasonOther)
+		return nil, err
+	}
+
+	s.sessionLock.Lock()
+	defer s.sessionLock.Unlock()
+	s.session = session
+
+	return session, nil
+}
+
+// closeSession Close session to master agent.
+func (s *SNMPSubagent) closeSession(reason agentx.CloseReason) {
+	s.sessionLock.Lock()
+	defer s.sessionLock.Unlock()
+
+	if s.session != nil {
+		_ = s.session.Close(reason)
+		s.session = nil
+	}
+}
+
+func (s *SNMPSubagent) loop(notifications <-chan *Notification) {
+	defer s.wg.Done()
+	defer vmgr.Unsubscribe(snmpSubscriberName)
+
+	// nil channel blocks while disconnected.
+	var errChannel chan error
+	retry := time.NewTimer(0)
+	defer retry.Stop()
+
+	for {
+		select {
+		case <-retry.C:
+			session, err := s.open()
+			if err != nil {
+				log.Warnf("AgentX connect to %s failed, retry after %v: %v",
+					s.addr, SNMPReconnectInterval, err)
+				retry.Reset(SNMPReconnectInterval)
+				continue
+			}
+			log.Infof("AgentX session %d opened.", session.ID())
+
+			errChannel = make(chan error, 1)
+			go func(ch chan error) {
+				ch <- session.Serve(s)
+			}(errChannel)
+		case err := <-errChannel:
+			log.Errorf("AgentX session closed, retry after %v: %v",
+				SNMPReconnectInterval, err)
+			s.closeSession(agentx.ReasonOther)
+			errChannel = nil
+			retry.Reset(SNMPReconnectInterval)
+		case n, ok := <-notifications:
+			if ok {
+				s.notify(n)
+			}
+		case <-s.stopChannel:
+			log.Infof("Stop SNMP subagent.")
+			s.closeSession(agentx.ReasonShutdown)
+			if errChannel != nil {
+				<-errChannel
+			}
+			return
+		}
+	}
+}
+
+// Start Start SNMP subagent.
+func (s *SNMPSubagent) Start() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.isRunning == false {
+		s.wg.Add(1)
+		go s.loop(vmgr.Subscribe(snmpSubscriberName))
+
+		s.isRunning = true
+	}
+
+	return nil
+}
+
+// Stop Stop SNMP subagent.
+func (s *SNMPSubagent) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.isRunning == true {
+		s.stopChannel <- true
+		s.isRunning = false
+	}
+}
+
+// Resume Resume module.
+func (s *SNMPSubagent) Resume() error {
+	// implement if necessary.
+	return nil
+}
+
+// Suspend Suspend module.
+func (s *SNMPSubagent) Suspend() error {
+	// MIB is served while suspended.
+	return nil
+}
+
+// Name Module name.
+func (s *SNMPSubagent) Name() string {
+	return SNMPSubagentModuleName
+}
@@ -0,0 +1,80 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"time"
+
+	"github.com/lagopus/vrrpd/packets"
+)
+
+// Statistics Statistics of VRRP(RFC 6527 vrrpv3StatisticsEntry).
+type Statistics struct {
+	MasterTransitions      uint64
+	RcvdAdvertisements     uint64
+	SentAdvertisements     uint64
+	AdvIntervalErrors      uint64
+	IPTTLErrors            uint64
+	ChecksumErrors         uint64
+	VersionErrors          uint64
+	RcvdInvalidTypePackets uint64
+	AddressListErrors      uint64
+	RcvdPriZeroPackets     uint64
+	SentPriZeroPackets     uint64
+	PacketLengthErrors     uint64
+	// time of creating VRRP(vrrpv3StatisticsRowDiscontinuityTime).
+	DiscontinuityTime time.Time
+}
+
+// GlobalStatistics Global statistics of VRRP(RFC 6527 vrrpv3GlobalStatistics).
+type GlobalStatistics struct {
+	ChecksumErrors uint64
+	VersionErrors  uint64
+	VrIDErrors     uint64
+	// errors of packets not decoded as VRRP.
+	OtherErrors uint64
+}
+
+// countDecodeError Count error in decoding VRRP advertisement.
+func (stats *Statistics) countDecodeError(reason packets.DecodeErrorReason) {
+	switch reason {
+	case packets.DecodeErrorTTL:
+		stats.IPTTLErrors++
+	case packets.DecodeErrorChecksum:
+		stats.ChecksumErrors++
+	case packets.DecodeErrorVersion:
+		stats.VersionErrors++
+	case packets.DecodeErrorType:
+		stats.RcvdInvalidTypePackets++
+	case packets.DecodeErrorAddressList:
+		stats.AddressListErrors++
+	case packets.DecodeErrorPacketLength:
+		stats.PacketLengthErrors++
+	}
+}
+
+// countDecodeError Count error in decoding VRRP advertisement.
+func (stats *GlobalStatistics) countDecodeError(reason packets.DecodeErrorReason) {
+	switch reason {
+	case packets.DecodeErrorChecksum:
+		stats.ChecksumErrors++
+	case packets.DecodeErrorVersion:
+		stats.VersionErrors++
+	case packets.DecodeErrorOther:
+		stats.OtherErrors++
+	}
+}
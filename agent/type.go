@@ -30,6 +30,10 @@ const (
 	StateBackup
 	// StateMaster Master.
 	StateMaster
+	// StateFault Fault.
+	StateFault
+	// StateError Failed to apply config, it is retried with backoff.
+	StateError
 )
 
 func (s VRRPState) String() string {
@@ -41,6 +45,10 @@ func (s VRRPState) String() string {
 		str = "Backup"
 	case StateMaster:
 		str = "Master"
+	case StateFault:
+		str = "Fault"
+	case StateError:
+		str = "Error"
 	default:
 		str = "UNKNOWN"
 	}
@@ -65,6 +73,10 @@ const (
 	EventPreempt
 	// EventShutdown Shutdown.
 	EventShutdown
+	// EventFault Fault.
+	EventFault
+	// EventRecover Recover from fault.
+	EventRecover
 )
 
 func (e VRRPEvent) String() string {
@@ -84,6 +96,10 @@ func (e VRRPEvent) String() string {
 		str = "Preempt"
 	case EventShutdown:
 		str = "Shutdown"
+	case EventFault:
+		str = "Fault"
+	case EventRecover:
+		str = "Recover"
 	default:
 		str = "UNKNOWN"
 	}
@@ -96,3 +112,8 @@ const (
 	// MinInterval 1 centiseconds.
 	MinInterval time.Duration = time.Duration(1) * Centisecond
 )
+
+const (
+	// prefix length of IPv6 link-local address.
+	linkLocalPrefix uint32 = 64
+)
@@ -17,8 +17,9 @@
 package agent
 
 import (
-	"os"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/lagopus/vrrpd/config"
 	"github.com/lagopus/vrrpd/models"
@@ -31,11 +32,22 @@ const (
 
 	// UpdateChannelSize Size of UpdateChannel.
 	UpdateChannelSize = 1000
+
+	// RetryInterval Interval of retry for failed VRRP.
+	RetryInterval = time.Second
+
+	// ApplyTimeout Timeout of waiting for result of applying config.
+	ApplyTimeout = time.Minute
 )
 
+type updateRequest struct {
+	conf   *config.AgentConfig
+	result chan error
+}
+
 // UpdateHandler handler
 type UpdateHandler struct {
-	handlerChannel chan *config.AgentConfig
+	handlerChannel chan *updateRequest
 	stopChannel    chan bool
 	isRunning      bool
 	wg             *sync.WaitGroup
@@ -45,36 +57,51 @@ type UpdateHandler struct {
 // NewUpdateHandler New UpdateHandler module.
 func NewUpdateHandler(wg *sync.WaitGroup) *UpdateHandler {
 	h := &UpdateHandler{
-		handlerChannel: make(chan *config.AgentConfig, UpdateChannelSize),
+		handlerChannel: make(chan *updateRequest, UpdateChannelSize),
 		stopChannel:    make(chan bool),
 		wg:             wg,
 	}
 	return h
 }
 
+func (u *UpdateHandler) updateSettings(conf *config.AgentConfig) error {
+	// overwrite same interface
+	subifTable := map[string]*models.Subinterface{}
+	for _, iface := range conf.Interfaces {
+		// ignore tunnel interface
+		if iface.Type != models.IfTypeTunnel {
+			for _, subiface := range iface.Subinterfaces {
+				subifTable[subiface.Name] = subiface
+			}
+		}
+	}
+
+	if err := vmgr.UpdateSettings(subifTable); err != nil {
+		log.Errorf("UpdateSettings failure: %v", err)
+		return err
+	}
+
+	return nil
+}
+
 func (u *UpdateHandler) handlerLoop() {
 	defer u.wg.Done()
 
+	ticker := time.NewTicker(RetryInterval)
 	for {
 		select {
-		case conf := <-u.handlerChannel:
-			// overwrite same interface
-			subifTable := map[string]*models.Subinterface{}
-			for _, iface := range conf.Interfaces {
-				// ignore tunnel interface
-				if iface.Type != models.IfTypeTunnel {
-					for _, subiface := range iface.Subinterfaces {
-						subifTable[subiface.Name] = subiface
-					}
-				}
-			}
-			if err := vmgr.UpdateSettings(subifTable); err != nil {
-				// TODO: graceful shutdown
-				log.Errorf("UpdateSettings failure: %v", err)
-				os.Exit(1)
+		case req := <-u.handlerChannel:
+			err := u.updateSettings(req.conf)
+			if req.result != nil {
+				req.result <- err
 			}
+		case now := <-ticker.C:
+			vmgr.RetryFailed(now)
+		case e := <-vmgr.syncChannel:
+			vmgr.syncGroupState(e)
 		case <-u.stopChannel:
 			log.Infof("Stop handlerLoop.")
+			ticker.Stop()
 			return
 		}
 	}
@@ -82,7 +109,25 @@ func (u *UpdateHandler) handlerLoop() {
 
 // SendHandlerChannel Send event to handlerChannel.
 func (u *UpdateHandler) SendHandlerChannel(conf *config.AgentConfig) {
-	u.handlerChannel <- conf
+	u.handlerChannel <- &updateRequest{conf: conf}
+}
+
+// Apply Apply config, and wait for the result.
+// The result is *ApplyError if some VRRPs failed, and it is partial
+// unless all VRRPs failed.
+func (u *UpdateHandler) Apply(conf *config.AgentConfig) error {
+	req := &updateRequest{
+		conf:   conf,
+		result: make(chan error, 1),
+	}
+	u.handlerChannel <- req
+
+	select {
+	case err := <-req.result:
+		return err
+	case <-time.After(ApplyTimeout):
+		return fmt.Errorf("apply timeout")
+	}
 }
 
 // Start Start hander.
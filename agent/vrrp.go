@@ -34,6 +34,13 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// dataPlaneAgent Agent of DataPlane, which is rpc.DPAgent.
+type dataPlaneAgent interface {
+	GetVifMacaddr(vif string) (net.HardwareAddr, error)
+	ToMaster(name string, phyaddr string, vaddr []string) error
+	ToBackup(name string, phyaddr string, vaddr []string) error
+}
+
 // VRRP structure of VRRPv3.
 type VRRP struct {
 	objID string
@@ -43,32 +50,76 @@ type VRRP struct {
 	skewTime               uint16
 	nextDownTime           time.Time
 	preempt                bool
+	preemptDelay           time.Duration
+	preemptTime            time.Time
+	preemptTimer           *time.Timer
+	preemptAdvPriority     uint8
 	accept                 bool
+	basePriority           uint8
+	priorityDecrement      uint8
+	trackInterfaces        []string
+	decrements             map[string]uint8
+	healthChecks           []string
+	faults                 map[string]bool
+	reachabilityTargets    []net.IP
+	reachabilityGateway    net.IP
+	reachabilityDecrement  uint8
+	syncGroup              string
+	notifyScript           string
+	syncing                bool
+	advInterval            uint16
+	stats                  Statistics
+	newMasterReason        NewMasterReason
+	protoErrReason         ProtoErrReason
+	masterIP               net.IP
+	upTime                 time.Time
+	lastTransition         time.Time
+	event                  VRRPEvent
+	advSrcIP               net.IP
+	advPriority            uint8
 	state                  VRRPState
+	af                     models.AddressFamily
+	version                models.VRRPVersion
+	v3MasterIP             net.IP
 	vaddrs                 []net.IP
 	vmac                   net.HardwareAddr
 	subifName              string
 	subifIP                net.IP
 	subifPrefix            uint32
+	srcIP                  net.IP
 	advPackets             []*rpc.Packet
 	advPriorityZeroPackets []*rpc.Packet
 	garpPackets            []*rpc.Packet
 	advTimer               *AdvTimer
 	mDownTimer             *MDownTimer
+	ifTracker              *IfTracker
+	healthChecker          *HealthChecker
+	icmpProber             *ICMPProber
+	notifier               *Notifier
 	hostif                 *rpc.Hostif
-	dpagent                *rpc.DPAgent
+	dpagent                dataPlaneAgent
 	// performance-oriented (channel is not used).
 	lock sync.Mutex
 }
 
-// create VRRP.
-func newVRRP(imodel *models.Subinterface, vmodel *models.VRRP) (*VRRP, error) {
-	var priority uint8
-	if vmodel.IsMaster(imodel.IP) {
-		priority = 255
-	} else {
-		priority = vmodel.Priority
+func createObjID(subifName string, af models.AddressFamily, vrid uint8) string {
+	return fmt.Sprintf("%s:%s:%d", subifName, af, vrid)
+}
+
+// createPriority Priority is 255 if IP Address Owner.
+func createPriority(subifIP net.IP, vmodel *models.VRRP) uint8 {
+	if vmodel.IsMaster(subifIP) {
+		return 255
 	}
+	return vmodel.Priority
+}
+
+// create VRRP.
+func newVRRP(imodel *models.Subinterface, af models.AddressFamily,
+	vmodel *models.VRRP) (*VRRP, error) {
+	subifIP, subifPrefix := imodel.Address(af)
+
+	priority := createPriority(subifIP, vmodel)
 
 	log.Debugf("vrrp adv priority: %d", priority)
 
@@ -81,17 +132,39 @@ func newVRRP(imodel *models.Subinterface, vmodel *models.VRRP) (*VRRP, error) {
 			MaxAdverInt:  vmodel.Interval,
 			IPAddress:    vmodel.VirtualAddresses, // TODO: sort
 		},
-		preempt:     vmodel.Preempt,
-		vaddrs:      vmodel.VirtualAddresses,
-		subifName:   imodel.Name,
-		subifIP:     imodel.IP,
-		subifPrefix: imodel.Prefix,
-		advTimer:    (module.GetModule(AdvTimerModuleName)).(*AdvTimer),
-		mDownTimer:  (module.GetModule(MDownTimerModuleName)).(*MDownTimer),
-		hostif:      (module.GetModule(rpc.HostifModuleName)).(*rpc.Hostif),
-		dpagent:     (module.GetModule(rpc.DPAgentModuleName)).(*rpc.DPAgent),
-	}
-	v.objID = fmt.Sprintf("%s:%d", imodel.Name, vmodel.Vrid)
+		preempt:               vmodel.Preempt,
+		preemptDelay:          time.Duration(vmodel.PreemptDelay) * time.Second,
+		accept:                vmodel.Accept,
+		basePriority:          priority,
+		priorityDecrement:     vmodel.PriorityDecrement,
+		trackInterfaces:       vmodel.TrackInterfaces,
+		decrements:            map[string]uint8{},
+		healthChecks:          vmodel.HealthChecks,
+		faults:                map[string]bool{},
+		reachabilityTargets:   vmodel.ReachabilityTargets,
+		reachabilityGateway:   vmodel.ReachabilityGateway,
+		reachabilityDecrement: vmodel.ReachabilityDecrement,
+		syncGroup:             vmodel.SyncGroup,
+		notifyScript:          vmodel.NotifyScript,
+		advInterval:           vmodel.Interval,
+		stats:                 Statistics{DiscontinuityTime: time.Now()},
+		af:                    af,
+		version:               vmodel.Version,
+		vaddrs:                vmodel.VirtualAddresses,
+		subifName:             imodel.Name,
+		subifIP:               subifIP,
+		subifPrefix:           subifPrefix,
+		advTimer:              (module.GetModule(AdvTimerModuleName)).(*AdvTimer),
+		mDownTimer:            (module.GetModule(MDownTimerModuleName)).(*MDownTimer),
+		ifTracker:             (module.GetModule(IfTrackerModuleName)).(*IfTracker),
+		healthChecker:         (module.GetModule(HealthCheckerModuleName)).(*HealthChecker),
+		icmpProber:            (module.GetModule(ICMPProberModuleName)).(*ICMPProber),
+		notifier:              (module.GetModule(NotifierModuleName)).(*Notifier),
+		hostif:                (module.GetModule(rpc.HostifModuleName)).(*rpc.Hostif),
+		dpagent:               (module.GetModule(rpc.DPAgentModuleName)).(*rpc.DPAgent),
+	}
+	v.objID = createObjID(imodel.Name, af, vmodel.Vrid)
+	v.lastTransition = time.Now()
 	v.setStateNoLock(StateInitialize)
 	v.resetMasterDownInterval(vmodel.Interval)
 	now := time.Now()
@@ -105,6 +178,19 @@ func newVRRP(imodel *models.Subinterface, vmodel *models.VRRP) (*VRRP, error) {
 		return nil, err
 	}
 
+	if af == models.AddressFamilyIPv6 {
+		// RFC 5798: source is link-local address of interface,
+		// and first address of adv is virtual link-local address.
+		if subifIP.IsLinkLocalUnicast() {
+			v.srcIP = subifIP
+		} else {
+			v.srcIP = packets.LinkLocalAddr(v.vmac)
+		}
+	} else {
+		v.srcIP = subifIP.To4()
+	}
+	v.setVirtualAddressesNoLock(vmodel)
+
 	if err = v.resetPacket(); err != nil {
 		log.Errorf("resetPacket faild: %v", err)
 		return nil, err
@@ -113,6 +199,152 @@ func newVRRP(imodel *models.Subinterface, vmodel *models.VRRP) (*VRRP, error) {
 	return v, nil
 }
 
+// setVirtualAddressesNoLock Set virtual addresses of adv.
+// In IPv6, first address is virtual link-local address.
+func (v *VRRP) setVirtualAddressesNoLock(vmodel *models.VRRP) {
+	vaddrs := vmodel.VirtualAddresses
+	if v.af == models.AddressFamilyIPv6 {
+		vll := vmodel.VirtualLinkLocal
+		if vll == nil {
+			vll = packets.LinkLocalAddr(packets.VirtualMACIPv6(vmodel.Vrid))
+		}
+		vaddrs = append([]net.IP{vll}, vmodel.VirtualAddresses...)
+	}
+	v.vaddrs = vaddrs
+	v.IPAddress = vaddrs
+}
+
+// needRebuild Report whether VRRP must be rebuilt for new settings.
+// Address of subinterface can't be changed in place.
+func (v *VRRP) needRebuild(imodel *models.Subinterface, af models.AddressFamily) bool {
+	subifIP, subifPrefix := imodel.Address(af)
+	return !v.subifIP.Equal(subifIP) || v.subifPrefix != subifPrefix
+}
+
+func equalIPs(a []net.IP, b []net.IP) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// trackingChanged Report whether settings of tracking are changed.
+func (v *VRRP) trackingChanged(vmodel *models.VRRP) bool {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return !equalStrings(v.trackInterfaces, vmodel.TrackInterfaces) ||
+		v.priorityDecrement != vmodel.PriorityDecrement ||
+		!equalStrings(v.healthChecks, vmodel.HealthChecks) ||
+		!equalIPs(v.reachabilityTargets, vmodel.ReachabilityTargets) ||
+		!v.reachabilityGateway.Equal(vmodel.ReachabilityGateway) ||
+		v.reachabilityDecrement != vmodel.ReachabilityDecrement ||
+		v.syncGroup != vmodel.SyncGroup
+}
+
+// updateTracking Update settings of tracking,
+// and reset states of tracked objects.
+func (v *VRRP) updateTracking(vmodel *models.VRRP) {
+	v.stopTracking()
+
+	v.lock.Lock()
+	v.trackInterfaces = vmodel.TrackInterfaces
+	v.priorityDecrement = vmodel.PriorityDecrement
+	v.healthChecks = vmodel.HealthChecks
+	v.reachabilityTargets = vmodel.ReachabilityTargets
+	v.reachabilityGateway = vmodel.ReachabilityGateway
+	v.reachabilityDecrement = vmodel.ReachabilityDecrement
+	if v.syncGroup != "" && v.syncGroup != vmodel.SyncGroup {
+		vmgr.leaveSyncGroup(v, v.syncGroup)
+	}
+	v.syncGroup = vmodel.SyncGroup
+	v.decrements = map[string]uint8{}
+	v.faults = map[string]bool{}
+	v.lock.Unlock()
+
+	v.startTracking()
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.updatePriorityNoLock()
+	if v.getStateNoLock() == StateFault && len(v.faults) == 0 {
+		v.nextStateNoLock(EventRecover)
+	}
+}
+
+// update Update settings in place.
+func (v *VRRP) update(imodel *models.Subinterface, af models.AddressFamily,
+	vmodel *models.VRRP) error {
+	if v.trackingChanged(vmodel) {
+		v.updateTracking(vmodel)
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	subifIP, _ := imodel.Address(af)
+	isMaster := v.getStateNoLock() == StateMaster
+
+	v.preempt = vmodel.Preempt
+	v.notifyScript = vmodel.NotifyScript
+	v.preemptDelay = time.Duration(vmodel.PreemptDelay) * time.Second
+	if v.version != vmodel.Version {
+		v.version = vmodel.Version
+		v.v3MasterIP = nil
+	}
+
+	v.advInterval = vmodel.Interval
+	// Backup uses Max Advertise Interval of Master.
+	if v.getStateNoLock() != StateBackup {
+		v.MaxAdverInt = vmodel.Interval
+	}
+
+	oldVaddrs := v.vaddrs
+	v.setVirtualAddressesNoLock(vmodel)
+	vaddrsChanged := !equalIPs(oldVaddrs, v.vaddrs)
+	if isMaster && vaddrsChanged {
+		// unset old virtual addresses.
+		newVaddrs := v.vaddrs
+		v.vaddrs = oldVaddrs
+		v.toBackup()
+		v.vaddrs = newVaddrs
+	}
+
+	v.accept = vmodel.Accept
+
+	// reset priority, master down interval and packets.
+	v.basePriority = createPriority(subifIP, vmodel)
+	v.updatePriorityNoLock()
+	v.resetMasterDownInterval(v.MaxAdverInt)
+	if err := v.resetPacketNoLock(); err != nil {
+		return err
+	}
+
+	if isMaster && vaddrsChanged {
+		v.toMaster()
+		v.sendGARP()
+	}
+
+	return nil
+}
+
 func (v *VRRP) setState(s VRRPState) {
 	v.lock.Lock()
 	defer v.lock.Unlock()
@@ -120,6 +352,44 @@ func (v *VRRP) setState(s VRRPState) {
 }
 
 func (v *VRRP) setStateNoLock(s VRRPState) {
+	if s == v.state {
+		return
+	}
+
+	// state changed by sync group is not notified.
+	if v.syncGroup != "" && !v.syncing {
+		vmgr.notifySyncGroup(v, s)
+	}
+
+	now := time.Now()
+	switch s {
+	case StateInitialize, StateFault:
+		v.upTime = time.Time{}
+		v.masterIP = nil
+	case StateMaster:
+		v.masterIP = v.srcIP
+	}
+	if v.upTime.IsZero() && (s == StateMaster || s == StateBackup) {
+		v.upTime = now
+	}
+
+	n := &Notification{
+		Type:            NotificationStateChange,
+		Time:            now,
+		Subifname:       v.subifName,
+		AF:              v.af,
+		Vrid:            v.VirtualRtrID,
+		OldState:        v.state,
+		NewState:        s,
+		Event:           v.event,
+		MasterIP:        v.masterIP,
+		NewMasterReason: v.newMasterReason,
+		AdvSrcIP:        v.advSrcIP,
+		AdvPriority:     v.advPriority,
+	}
+	vmgr.publish(n)
+	v.notifier.Notify(v.notifyScript, n)
+	v.lastTransition = now
 	v.state = s
 }
 
@@ -173,28 +443,87 @@ func (v *VRRP) getSkewTimeTimeNoLock() uint16 {
 	return v.skewTime
 }
 
+// preemptDelayExpiredNoLock Report whether preempt delay has expired.
+// The preempt delay starts at the first call, and preempt is checked
+// again by timer, even if no further adv is received.
+func (v *VRRP) preemptDelayExpiredNoLock(now time.Time, advPriority uint8) bool {
+	if v.preemptDelay == 0 {
+		return true
+	}
+
+	// checked again with priority of the latest adv.
+	v.preemptAdvPriority = advPriority
+	if v.preemptTime.IsZero() {
+		v.preemptTime = now.Add(v.preemptDelay)
+		v.preemptTimer = time.AfterFunc(time.Until(v.preemptTime), v.preemptDelayExpired)
+		log.Infof("Wait for preempt delay: until %v", v.preemptTime)
+		return false
+	}
+
+	return !now.Before(v.preemptTime)
+}
+
+// preemptDelayExpired Preempt by timer of preempt delay.
+func (v *VRRP) preemptDelayExpired() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	// canceled, or restarted after the timer.
+	if v.preemptTime.IsZero() || time.Now().Before(v.preemptTime) {
+		return
+	}
+
+	if v.getStateNoLock() == StateBackup && v.preempt &&
+		v.preemptAdvPriority < v.Priority {
+		log.Debugf("Event = %v: adv.Priority = %v, Priority = %v",
+			EventPreempt, v.preemptAdvPriority, v.Priority)
+		v.nextStateNoLock(EventPreempt)
+	}
+	v.cancelPreemptDelayNoLock()
+}
+
+func (v *VRRP) cancelPreemptDelayNoLock() {
+	if !v.preemptTime.IsZero() {
+		log.Infof("Cancel preempt delay.")
+		v.preemptTime = time.Time{}
+		v.preemptTimer.Stop()
+	}
+}
+
 func (v *VRRP) sendVRRPAdvPriorityZero() {
 	log.Debugf("send VRRPPriorityZero.")
+	v.stats.SentPriZeroPackets++
 	bps := rpc.NewBulkPackets(v.advPriorityZeroPackets)
 	v.hostif.PacketoutBulk(bps)
 }
 
+// sendGARP send GARP(IPv4) or unsolicited NA(IPv6).
 func (v *VRRP) sendGARP() {
-	log.Debugf("send GARP.")
+	log.Debugf("send GARP/NA.")
 	bps := rpc.NewBulkPackets(v.garpPackets)
 	v.hostif.PacketoutBulk(bps)
 }
 
-func (v *VRRP) toMaster() {
-	log.Debugf("set virtual addresses.")
-
+func (v *VRRP) createAddrs() (string, []string) {
 	addrs := []string{}
 	for _, vaddr := range v.vaddrs {
-		addr := fmt.Sprintf("%s/%d", vaddr.String(), v.subifPrefix)
+		prefix := v.subifPrefix
+		if v.af == models.AddressFamilyIPv6 && vaddr.IsLinkLocalUnicast() {
+			prefix = linkLocalPrefix
+		}
+		addr := fmt.Sprintf("%s/%d", vaddr.String(), prefix)
 		addrs = append(addrs, addr)
 	}
 	phyaddr := fmt.Sprintf("%s/%d", v.subifIP.String(), v.subifPrefix)
 
+	return phyaddr, addrs
+}
+
+func (v *VRRP) toMaster() {
+	log.Debugf("set virtual addresses.")
+
+	phyaddr, addrs := v.createAddrs()
+
 	if err := v.dpagent.ToMaster(v.subifName, phyaddr, addrs); err != nil {
 		// ignore.
 		log.Errorf("%v", err)
@@ -204,12 +533,7 @@ func (v *VRRP) toMaster() {
 func (v *VRRP) toBackup() {
 	log.Debugf("unset virtual addresses.")
 
-	addrs := []string{}
-	for _, vaddr := range v.vaddrs {
-		addr := fmt.Sprintf("%s/%d", vaddr.String(), v.subifPrefix)
-		addrs = append(addrs, addr)
-	}
-	phyaddr := fmt.Sprintf("%s/%d", v.subifIP.String(), v.subifPrefix)
+	phyaddr, addrs := v.createAddrs()
 
 	if err := v.dpagent.ToBackup(v.subifName, phyaddr, addrs); err != nil {
 		// ignore.
@@ -218,6 +542,11 @@ func (v *VRRP) toBackup() {
 }
 
 func (v *VRRP) createGARP() ([]*rpc.Packet, error) {
+	// ARP is not used in IPv6.
+	if v.af == models.AddressFamilyIPv6 {
+		return v.createUnsolicitedNA()
+	}
+
 	ps := []*rpc.Packet{}
 	for _, ip := range v.IPAddress {
 		// virtual mac address
@@ -233,28 +562,62 @@ func (v *VRRP) createGARP() ([]*rpc.Packet, error) {
 	return ps, nil
 }
 
-func (v *VRRP) createVRRPAdv() ([]*rpc.Packet, error) {
+func (v *VRRP) createUnsolicitedNA() ([]*rpc.Packet, error) {
 	ps := []*rpc.Packet{}
-	if buf, err := packets.SerializeVRRPAdv(v.subifIP, &v.VRRPv3Adv); err == nil {
-		p := rpc.NewPacket(v.subifName, buf)
-		ps = append(ps, p)
-	} else {
-		return nil, err
+	for _, ip := range v.IPAddress {
+		// physical mac address
+		if buf, err := packets.SerializeUnsolicitedNA(ip, v.vmac); err == nil {
+			p := rpc.NewPacket(v.subifName, buf)
+			ps = append(ps, p)
+		} else {
+			return nil, err
+		}
+	}
+	return ps, nil
+}
+
+// serializeVRRPAdv serialize VRRPv3 and/or VRRPv2 adv by version.
+func (v *VRRP) serializeVRRPAdv(adv *layers.VRRPv3Adv) ([]*rpc.Packet, error) {
+	ps := []*rpc.Packet{}
+	// VRRPv3
+	if v.version != models.VRRPVersion2 {
+		if buf, err := packets.SerializeVRRPAdv(v.srcIP, adv); err == nil {
+			p := rpc.NewPacket(v.subifName, buf)
+			ps = append(ps, p)
+		} else {
+			return nil, err
+		}
+	}
+	// VRRPv2
+	if v.version != models.VRRPVersion3 {
+		if buf, err := packets.SerializeVRRPv2Adv(v.srcIP, adv.ToVRRPv2Adv()); err == nil {
+			p := rpc.NewPacket(v.subifName, buf)
+			ps = append(ps, p)
+		} else {
+			return nil, err
+		}
 	}
 	return ps, nil
 }
 
+func (v *VRRP) createVRRPAdv() ([]*rpc.Packet, error) {
+	return v.serializeVRRPAdv(&v.VRRPv3Adv)
+}
+
 func (v *VRRP) createVRRPAdvPriorityZero() ([]*rpc.Packet, error) {
-	cv := *v
-	cv.Priority = 0
+	adv := v.VRRPv3Adv
+	adv.Priority = 0
 
-	return cv.createVRRPAdv()
+	return v.serializeVRRPAdv(&adv)
 }
 
 func (v *VRRP) resetPacket() error {
 	v.lock.Lock()
 	defer v.lock.Unlock()
+	return v.resetPacketNoLock()
+}
 
+func (v *VRRP) resetPacketNoLock() error {
 	var err error
 	if v.advPackets, err = v.createVRRPAdv(); err != nil {
 		return err
@@ -281,6 +644,178 @@ func (v *VRRP) resetMasterDownInterval(interval uint16) {
 	}
 }
 
+// setPriorityDecrementNoLock Set decrement of priority by tracked object.
+// Decrement 0 means the tracked object is healthy.
+func (v *VRRP) setPriorityDecrementNoLock(key string, decrement uint8) {
+	if decrement == 0 {
+		delete(v.decrements, key)
+	} else {
+		v.decrements[key] = decrement
+	}
+	v.updatePriorityNoLock()
+}
+
+// updatePriorityNoLock Recompute effective priority,
+// and reset adv packets and master down interval.
+func (v *VRRP) updatePriorityNoLock() {
+	priority := v.basePriority
+
+	decrement := 0
+	for _, d := range v.decrements {
+		decrement += int(d)
+	}
+	if decrement > 0 {
+		// effective priority is in range of backup(1-254).
+		p := int(v.basePriority) - decrement
+		if p < 1 {
+			p = 1
+		} else if p > 254 {
+			p = 254
+		}
+		priority = uint8(p)
+	}
+
+	if priority == v.Priority {
+		return
+	}
+
+	log.Infof("%s: change priority: %d -> %d", v.objID, v.Priority, priority)
+	v.Priority = priority
+	v.resetMasterDownInterval(v.MaxAdverInt)
+	if err := v.resetPacketNoLock(); err != nil {
+		log.Errorf("resetPacket faild: %v", err)
+	}
+
+	if v.getStateNoLock() == StateMaster {
+		// send adv immediately, backup preempts if necessary.
+		v.nextMasterAdvTime = time.Now()
+	}
+}
+
+// SetTrackInterfaceState Set operational state of tracked interface.
+func (v *VRRP) SetTrackInterfaceState(ifname string, up bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	var decrement uint8
+	if !up {
+		decrement = v.priorityDecrement
+	}
+	v.setPriorityDecrementNoLock("interface:"+ifname, decrement)
+}
+
+// setFaultNoLock Set fault by tracked object.
+func (v *VRRP) setFaultNoLock(key string, fault bool) {
+	if fault {
+		v.faults[key] = true
+	} else {
+		delete(v.faults, key)
+	}
+
+	switch s := v.getStateNoLock(); {
+	case s == StateInitialize:
+		// checked in doInitializeTasks().
+	case s == StateFault && len(v.faults) == 0:
+		v.nextStateNoLock(EventRecover)
+	case s != StateFault && len(v.faults) > 0:
+		v.nextStateNoLock(EventFault)
+	}
+}
+
+// SetHealthCheckState Set result of health check.
+// If weight is 0, VRRP is in fault while health check is down.
+func (v *VRRP) SetHealthCheckState(name string, weight uint8, up bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	key := "script:" + name
+	if weight == 0 {
+		v.setFaultNoLock(key, !up)
+		return
+	}
+
+	var decrement uint8
+	if !up {
+		decrement = weight
+	}
+	v.setPriorityDecrementNoLock(key, decrement)
+}
+
+// SetReachabilityState Set reachability of target.
+func (v *VRRP) SetReachabilityState(target net.IP, up bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	var decrement uint8
+	if !up {
+		decrement = v.reachabilityDecrement
+	}
+	v.setPriorityDecrementNoLock("reachability:"+target.String(), decrement)
+}
+
+// SyncState Follow the state of other member in sync group.
+func (v *VRRP) SyncState(s VRRPState) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.syncing = true
+	defer func() { v.syncing = false }()
+
+	key := "sync-group:" + v.syncGroup
+	switch s {
+	case StateMaster:
+		v.setFaultNoLock(key, false)
+		if v.getStateNoLock() == StateBackup {
+			v.nextStateNoLock(EventPreempt)
+		}
+	case StateBackup:
+		v.setFaultNoLock(key, false)
+		if v.getStateNoLock() == StateMaster {
+			v.advTimer.DeleteMasterTable(v)
+			v.setNextDownTimeNoLock(time.Now(), v.masterDownInterval)
+			v.nextStateNoLock(EventDetectedNewMaster)
+		}
+	case StateInitialize, StateFault:
+		v.setFaultNoLock(key, true)
+	}
+}
+
+// SyncGroup Get sync group.
+func (v *VRRP) SyncGroup() string {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.syncGroup
+}
+
+// ClearSyncFault Clear fault caused by other member of sync group.
+func (v *VRRP) ClearSyncFault() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.syncing = true
+	defer func() { v.syncing = false }()
+
+	v.setFaultNoLock("sync-group:"+v.syncGroup, false)
+}
+
+func (v *VRRP) startTracking() {
+	for _, ifname := range v.trackInterfaces {
+		v.ifTracker.AddTrackTable(ifname, v)
+	}
+	for _, name := range v.healthChecks {
+		v.healthChecker.AddCheckTable(name, v)
+	}
+	for _, target := range v.reachabilityTargets {
+		v.icmpProber.AddProbeTable(target, v)
+	}
+}
+
+func (v *VRRP) stopTracking() {
+	v.ifTracker.DeleteTrackTable(v)
+	v.healthChecker.DeleteCheckTable(v)
+	v.icmpProber.DeleteProbeTable(v)
+}
+
 func (v *VRRP) getVRRPAdvExpired(now time.Time) ([]*rpc.Packet, bool) {
 	v.lock.Lock()
 	defer v.lock.Unlock()
@@ -289,6 +824,7 @@ func (v *VRRP) getVRRPAdvExpired(now time.Time) ([]*rpc.Packet, bool) {
 		return nil, false
 	}
 	v.setNextMasterAdvTimeNoLock(now)
+	v.stats.SentAdvertisements++
 
 	return v.advPackets, true
 }
@@ -306,9 +842,30 @@ func (v *VRRP) masterDownTimeExpired(now time.Time, funcDeleteBackupTable func()
 	}
 }
 
+// acceptVersionNoLock Report whether version of adv is acceptable.
+func (v *VRRP) acceptVersionNoLock(version uint8, advSrcIP net.IP) bool {
+	switch v.version {
+	case models.VRRPVersion2:
+		return version == layers.VRRPv2Version
+	case models.VRRPVersion3:
+		return version == layers.VRRPv3Version
+	case models.VRRPVersion3Compat:
+		if version == layers.VRRPv3Version {
+			v.v3MasterIP = advSrcIP
+			return true
+		}
+		// RFC 5798 section 8.4:
+		// ignore VRRPv2 adv from the master that also sends VRRPv3 adv.
+		return version == layers.VRRPv2Version &&
+			!advSrcIP.Equal(v.v3MasterIP)
+	default:
+		return false
+	}
+}
+
 func (v *VRRP) containsInterfaceIPs(ips []net.IP) bool {
 	for _, ip := range ips {
-		if bytes.Equal(v.subifIP, ip) {
+		if v.subifIP.Equal(ip) || v.srcIP.Equal(ip) {
 			return true
 		}
 	}
@@ -316,9 +873,111 @@ func (v *VRRP) containsInterfaceIPs(ips []net.IP) bool {
 	return false
 }
 
+// countDecodeError Count error in decoding VRRP advertisement.
+func (v *VRRP) countDecodeError(reason packets.DecodeErrorReason) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.stats.countDecodeError(reason)
+	if r, ok := toProtoErrReason(reason); ok {
+		v.setProtoErrorNoLock(r)
+	}
+}
+
+// setProtoErrorNoLock Set reason of last protocol error, and notify it.
+func (v *VRRP) setProtoErrorNoLock(reason ProtoErrReason) {
+	v.protoErrReason = reason
+	vmgr.publish(&Notification{
+		Type:           NotificationProtoError,
+		Time:           time.Now(),
+		Subifname:      v.subifName,
+		AF:             v.af,
+		Vrid:           v.VirtualRtrID,
+		ProtoErrReason: reason,
+	})
+}
+
+// Statistics Get statistics.
+func (v *VRRP) Statistics() Statistics {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.stats
+}
+
+// VRRPInfo Runtime information of VRRP.
+// VirtualMAC is MAC address used for virtual addresses(physical MAC address),
+// and UpTime is time of transition from Initialize/Fault(zero in Initialize/Fault).
+type VRRPInfo struct {
+	Subifname        string
+	AF               models.AddressFamily
+	Vrid             uint8
+	Version          models.VRRPVersion
+	State            VRRPState
+	Priority         uint8
+	BasePriority     uint8
+	AdvInterval      uint16
+	MasterAdvInt     uint16
+	MasterDownInt    uint16
+	SkewTime         uint16
+	Preempt          bool
+	Accept           bool
+	PrimaryIP        net.IP
+	MasterIP         net.IP
+	VirtualAddresses []net.IP
+	VirtualMAC       net.HardwareAddr
+	UpTime           time.Time
+	LastTransition   time.Time
+	NewMasterReason  NewMasterReason
+	ProtoErrReason   ProtoErrReason
+	Stats            Statistics
+	// last error and time of next retry in StateError.
+	LastError string
+	NextRetry time.Time
+}
+
+// Info Get runtime information.
+func (v *VRRP) Info() VRRPInfo {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	vaddrs := make([]net.IP, len(v.vaddrs))
+	copy(vaddrs, v.vaddrs)
+
+	return VRRPInfo{
+		Subifname:        v.subifName,
+		AF:               v.af,
+		Vrid:             v.VirtualRtrID,
+		Version:          v.version,
+		State:            v.getStateNoLock(),
+		Priority:         v.Priority,
+		BasePriority:     v.basePriority,
+		AdvInterval:      v.advInterval,
+		MasterAdvInt:     v.MaxAdverInt,
+		MasterDownInt:    v.masterDownInterval,
+		SkewTime:         v.skewTime,
+		Preempt:          v.preempt,
+		Accept:           v.accept,
+		PrimaryIP:        v.srcIP,
+		MasterIP:         v.masterIP,
+		VirtualAddresses: vaddrs,
+		VirtualMAC:       v.vmac,
+		UpTime:           v.upTime,
+		LastTransition:   v.lastTransition,
+		NewMasterReason:  v.newMasterReason,
+		ProtoErrReason:   v.protoErrReason,
+		Stats:            v.stats,
+	}
+}
+
 // Initialize
 
 func (v *VRRP) doInitializeTasks() {
+	if len(v.faults) > 0 {
+		v.nextStateNoLock(EventFault)
+		return
+	}
+
 	// virtualIP == local router IP.
 	if v.containsInterfaceIPs(v.IPAddress) {
 		v.nextStateNoLock(EventStartMaster)
@@ -337,7 +996,7 @@ func (v *VRRP) becomeInitialize() {
 	log.Info("Become Initialize.")
 
 	switch s := v.getStateNoLock(); s {
-	case StateInitialize:
+	case StateInitialize, StateFault:
 		// nothing
 	case StateBackup:
 		v.mDownTimer.DeleteBackupTable(v)
@@ -349,21 +1008,55 @@ func (v *VRRP) becomeInitialize() {
 		log.Errorf("Bad state %v.", s)
 	}
 
+	v.cancelPreemptDelayNoLock()
 	v.setStateNoLock(StateInitialize)
 }
 
+// Fault.
+
+func (v *VRRP) becomeFault() {
+	log.Info("Become Fault.")
+
+	switch s := v.getStateNoLock(); s {
+	case StateInitialize:
+		// nothing
+	case StateBackup:
+		v.mDownTimer.DeleteBackupTable(v)
+	case StateMaster:
+		v.advTimer.DeleteMasterTable(v)
+		v.sendVRRPAdvPriorityZero()
+		v.toBackup()
+	default:
+		log.Errorf("Bad state %v.", s)
+	}
+
+	v.cancelPreemptDelayNoLock()
+	v.setStateNoLock(StateFault)
+}
+
+func (v *VRRP) recoverFault() {
+	log.Info("Recover from Fault.")
+
+	// StateInitialize is transient, not notified to sync group.
+	v.state = StateInitialize
+	v.doInitializeTasks()
+}
+
 // Master.
 
-func (v *VRRP) becomeMaster() {
+func (v *VRRP) becomeMaster(reason NewMasterReason) {
 	log.Info("Become Master.")
 
 	// to master
 	v.toMaster()
-	// send GARP
+	// send GARP/unsolicited NA
 	v.sendGARP()
 	// not called DeleteBackupTable() (called in mDownTimer).
 	v.advTimer.AddMasterTable(v)
+	v.cancelPreemptDelayNoLock()
+	v.newMasterReason = reason
 	v.setStateNoLock(StateMaster)
+	v.stats.MasterTransitions++
 }
 
 // Backup.
@@ -399,17 +1092,27 @@ func (v *VRRP) becomeBackup() {
 //    +---------------+    [EventMasterDown]     +---------------+
 //                         [EventPreempt]
 //
+//    [EventFault] in StateInitialize, StateMaster and StateBackup
+//    goes to StateFault, and [EventRecover] in StateFault goes to
+//    StateInitialize and restarts. [EventShutdown] in StateFault
+//    goes to StateInitialize.
+//
 
 func (v *VRRP) nextStateNoLock(e VRRPEvent) {
+	// event triggering state change.
+	v.event = e
+
 	switch s := v.getStateNoLock(); s {
 	case StateInitialize:
 		switch e {
 		case EventStart:
 			v.doInitializeTasks()
 		case EventStartMaster:
-			v.becomeMaster()
+			v.becomeMaster(NewMasterReasonPriority)
 		case EventStartBackup:
 			v.becomeBackup()
+		case EventFault:
+			v.becomeFault()
 		default:
 			log.Errorf("Bad event %v in StateInitialize", e)
 		}
@@ -419,20 +1122,33 @@ func (v *VRRP) nextStateNoLock(e VRRPEvent) {
 			v.becomeBackup()
 		case EventShutdown:
 			v.becomeInitialize()
+		case EventFault:
+			v.becomeFault()
 		default:
 			log.Errorf("Bad event %v in StateMaster", e)
 		}
 	case StateBackup:
 		switch e {
 		case EventMasterDown:
-			v.becomeMaster()
+			v.becomeMaster(NewMasterReasonMasterNoResponse)
 		case EventPreempt:
-			v.becomeMaster()
+			v.becomeMaster(NewMasterReasonPreempted)
 		case EventShutdown:
 			v.becomeInitialize()
+		case EventFault:
+			v.becomeFault()
 		default:
 			log.Errorf("Bad event %v in StateBackup", e)
 		}
+	case StateFault:
+		switch e {
+		case EventRecover:
+			v.recoverFault()
+		case EventShutdown:
+			v.becomeInitialize()
+		default:
+			log.Errorf("Bad event %v in StateFault", e)
+		}
 	default:
 		log.Errorf("Bad state %v", s)
 	}
@@ -452,26 +1168,56 @@ func (v *VRRP) NextStateForRecv(vrrpAdv *layers.VRRPv3Adv,
 	v.lock.Lock()
 	defer v.lock.Unlock()
 
+	// advertisement triggering state change.
+	v.advSrcIP, v.advPriority = advSrcIP, vrrpAdv.Priority
+	defer func() { v.advSrcIP, v.advPriority = nil, 0 }()
+
+	v.stats.RcvdAdvertisements++
+	if vrrpAdv.Priority == 0 {
+		v.stats.RcvdPriZeroPackets++
+	}
+
 	// check virtualIP in adv == local router IP.
 	if v.containsInterfaceIPs(vrrpAdv.IPAddress) {
 		log.Debugf("Discard adv: %v", vrrpAdv)
 		return
 	}
 
+	// check version of adv.
+	if !v.acceptVersionNoLock(vrrpAdv.Version, advSrcIP) {
+		log.Debugf("Discard adv(version %d): %v", vrrpAdv.Version, vrrpAdv)
+		v.stats.VersionErrors++
+		v.setProtoErrorNoLock(ProtoErrReasonVersionError)
+		return
+	}
+
+	if vrrpAdv.MaxAdverInt != v.advInterval {
+		v.stats.AdvIntervalErrors++
+	}
+
 	switch s := v.getStateNoLock(); s {
-	case StateInitialize:
+	case StateInitialize, StateFault:
 		// do nothing.
 	case StateBackup:
+		v.masterIP = advSrcIP
 		switch {
 		case v.Priority == 0:
 			v.setNextDownTimeNoLock(now, v.getSkewTimeTimeNoLock())
 		case v.preempt == true &&
 			vrrpAdv.Priority < v.Priority:
-			log.Debugf("Event = %v: adv.Priority = %v, Priority = %v",
-				EventPreempt, vrrpAdv.Priority, v.Priority)
-			v.nextStateNoLock(EventPreempt)
+			if v.preemptDelayExpiredNoLock(now, vrrpAdv.Priority) {
+				log.Debugf("Event = %v: adv.Priority = %v, Priority = %v",
+					EventPreempt, vrrpAdv.Priority, v.Priority)
+				v.nextStateNoLock(EventPreempt)
+			} else {
+				// wait for preempt delay.
+				v.MaxAdverInt = vrrpAdv.MaxAdverInt
+				v.resetMasterDownInterval(v.MaxAdverInt)
+				v.setNextDownTimeNoLock(now, v.masterDownInterval)
+			}
 		case v.preempt == false ||
 			vrrpAdv.Priority >= v.Priority:
+			v.cancelPreemptDelayNoLock()
 			v.MaxAdverInt = vrrpAdv.MaxAdverInt
 			v.resetMasterDownInterval(v.MaxAdverInt)
 			v.setNextDownTimeNoLock(now, v.masterDownInterval)
@@ -484,16 +1230,17 @@ func (v *VRRP) NextStateForRecv(vrrpAdv *layers.VRRPv3Adv,
 			v.nextMasterAdvTime = now
 		case vrrpAdv.Priority > v.Priority ||
 			(vrrpAdv.Priority == v.Priority &&
-				bytes.Compare(advSrcIP, v.subifIP) > 0):
+				bytes.Compare(advSrcIP, v.srcIP) > 0):
 			log.Debugf("Event = %v: adv.Priority = %v, Priority = %v"+
-				"adv.srcIP = %v, subifIP = %v",
+				"adv.srcIP = %v, srcIP = %v",
 				EventDetectedNewMaster,
 				vrrpAdv.Priority, v.Priority,
-				vrrpAdv.Priority, v.subifIP)
+				advSrcIP, v.srcIP)
 			v.advTimer.DeleteMasterTable(v)
 			v.MaxAdverInt = vrrpAdv.MaxAdverInt
 			v.resetMasterDownInterval(v.MaxAdverInt)
 			v.setNextDownTimeNoLock(now, v.masterDownInterval)
+			v.masterIP = advSrcIP
 			v.nextStateNoLock(EventDetectedNewMaster)
 		default:
 			log.Debugf("Discard adv: %v", vrrpAdv)
@@ -21,6 +21,8 @@ package agent
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,21 +33,158 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	// SyncChannelSize Size of SyncChannel.
+	SyncChannelSize = 1000
+	// RetryInitialBackoff Initial backoff of retry for failed VRRP.
+	RetryInitialBackoff = time.Second
+	// RetryMaxBackoff Max backoff of retry for failed VRRP.
+	RetryMaxBackoff = time.Minute
+)
+
+// ApplyError Failures of VRRPs in applying settings.
+type ApplyError struct {
+	Failures map[string]error
+	// number of VRRPs applied successfully.
+	Applied int
+}
+
+func (e *ApplyError) Error() string {
+	objIDs := []string{}
+	for objID := range e.Failures {
+		objIDs = append(objIDs, objID)
+	}
+	sort.Strings(objIDs)
+
+	strs := []string{}
+	for _, objID := range objIDs {
+		strs = append(strs, fmt.Sprintf("%s: %v", objID, e.Failures[objID]))
+	}
+
+	return fmt.Sprintf("apply failed: %s", strings.Join(strs, ", "))
+}
+
+// Partial Report whether some VRRPs are applied, and failures are
+// retried with backoff. It is not partial if all VRRPs failed.
+func (e *ApplyError) Partial() bool {
+	return e.Applied > 0
+}
+
+// failed VRRP, retried with backoff.
+type failedVRRP struct {
+	setting   *vrrpSetting
+	err       error
+	backoff   time.Duration
+	nextRetry time.Time
+}
+
+// info Get information of failed VRRP from settings.
+func (f *failedVRRP) info() VRRPInfo {
+	subifIP, _ := f.setting.subifModel.Address(f.setting.af)
+	vmodel := f.setting.vrrpModel.Copy()
+	priority := createPriority(subifIP, vmodel)
+
+	return VRRPInfo{
+		Subifname:        f.setting.subifModel.Name,
+		AF:               f.setting.af,
+		Vrid:             vmodel.Vrid,
+		Version:          vmodel.Version,
+		State:            StateError,
+		Priority:         priority,
+		BasePriority:     priority,
+		AdvInterval:      vmodel.Interval,
+		Preempt:          vmodel.Preempt,
+		Accept:           vmodel.Accept,
+		PrimaryIP:        subifIP,
+		VirtualAddresses: vmodel.VirtualAddresses,
+		LastTransition:   f.nextRetry.Add(-f.backoff),
+		LastError:        f.err.Error(),
+		NextRetry:        f.nextRetry,
+	}
+}
+
+// state change of member in sync group.
+type syncEvent struct {
+	v     *VRRP
+	state VRRPState
+	// member left group, it is deleted or its group is changed.
+	left  bool
+	group string
+}
+
 // VRRPMgr VRRP manager.
 type VRRPMgr struct {
-	vrrpTable map[string]*VRRP
-	lock      sync.RWMutex
+	vrrpTable       map[string]*VRRP
+	failedTable     map[string]*failedVRRP
+	syncChannel     chan *syncEvent
+	subscribers     map[string]*subscriber
+	stats           GlobalStatistics
+	lock            sync.RWMutex
+	statsLock       sync.Mutex
+	subscribersLock sync.Mutex
 }
 
 var vmgr = newVRRPMgr()
 
 func newVRRPMgr() *VRRPMgr {
 	vm := &VRRPMgr{
-		vrrpTable: map[string]*VRRP{},
+		vrrpTable:   map[string]*VRRP{},
+		failedTable: map[string]*failedVRRP{},
+		syncChannel: make(chan *syncEvent, SyncChannelSize),
+		subscribers: map[string]*subscriber{},
 	}
 	return vm
 }
 
+// notifySyncGroup Notify state change of member in sync group.
+// It is called with lock of VRRP, so the event is handled asynchronously.
+func (vmgr *VRRPMgr) notifySyncGroup(v *VRRP, s VRRPState) {
+	select {
+	case vmgr.syncChannel <- &syncEvent{v: v, state: s}:
+	default:
+		log.Errorf("Sync group %s: drop event: %s -> %v", v.syncGroup, v.objID, s)
+	}
+}
+
+// leaveSyncGroup Notify that member left sync group.
+// It is called with lock of VRRPMgr, so the event is handled asynchronously.
+func (vmgr *VRRPMgr) leaveSyncGroup(v *VRRP, group string) {
+	select {
+	case vmgr.syncChannel <- &syncEvent{v: v, left: true, group: group}:
+	default:
+		log.Errorf("Sync group %s: drop event: %s left", group, v.objID)
+	}
+}
+
+// syncGroupState Make other members of sync group follow the state.
+// If member left, fault caused by it is cleared in other members.
+func (vmgr *VRRPMgr) syncGroupState(e *syncEvent) {
+	vmgr.lock.RLock()
+	defer vmgr.lock.RUnlock()
+
+	if e.left {
+		log.Infof("Sync group %s: %s left", e.group, e.v.objID)
+		for _, v := range vmgr.vrrpTable {
+			if v != e.v && v.syncGroup == e.group {
+				v.ClearSyncFault()
+			}
+		}
+		return
+	}
+
+	// ignore deleted VRRP.
+	if v, ok := vmgr.vrrpTable[e.v.objID]; !ok || v != e.v {
+		return
+	}
+
+	log.Infof("Sync group %s: %s -> %v", e.v.syncGroup, e.v.objID, e.state)
+	for _, v := range vmgr.vrrpTable {
+		if v != e.v && v.syncGroup == e.v.syncGroup {
+			v.SyncState(e.state)
+		}
+	}
+}
+
 // RecvVRRPAdv Recv VRRP Advertisement.
 func (vmgr *VRRPMgr) RecvVRRPAdv(bps *rpc.BulkPackets) {
 	vmgr.lock.RLock()
@@ -53,62 +192,220 @@ func (vmgr *VRRPMgr) RecvVRRPAdv(bps *rpc.BulkPackets) {
 
 	for _, packet := range bps.Packets {
 		now := time.Now()
-		if _, ip, vrrpAdv, err := packets.DecodeVRRPAdv(packet.Data); err == nil {
-			objID := fmt.Sprintf("%s:%d", packet.Subifname, vrrpAdv.VirtualRtrID)
+		if srcIP, vrrpAdv, err := packets.DecodeVRRPAdvPacket(packet.Data); err == nil {
+			objID := createObjID(packet.Subifname,
+				models.ToAddressFamily(srcIP), vrrpAdv.VirtualRtrID)
 			if v, ok := vmgr.vrrpTable[objID]; ok {
-				v.NextStateForRecv(vrrpAdv, ip.SrcIP, now)
+				v.NextStateForRecv(vrrpAdv, srcIP, now)
 			} else {
 				log.Errorf("Unknown vrrp: %s", objID)
+				vmgr.countGlobal(func(stats *GlobalStatistics) {
+					stats.VrIDErrors++
+				})
 				continue
 			}
 		} else {
-			log.Errorf("Bad packet.")
+			log.Errorf("Bad packet: %v", err)
+			reason := packets.DecodeErrorOther
+			if e, ok := err.(*packets.DecodeError); ok {
+				reason = e.Reason
+				objID := createObjID(packet.Subifname,
+					models.ToAddressFamily(e.SrcIP), e.Vrid)
+				if v, ok := vmgr.vrrpTable[objID]; ok {
+					v.countDecodeError(reason)
+				}
+			}
+			vmgr.countGlobal(func(stats *GlobalStatistics) {
+				stats.countDecodeError(reason)
+			})
 			continue
 		}
 	}
 }
 
+func (vmgr *VRRPMgr) countGlobal(count func(stats *GlobalStatistics)) {
+	vmgr.statsLock.Lock()
+	defer vmgr.statsLock.Unlock()
+
+	count(&vmgr.stats)
+}
+
+// VRRPInfos Get runtime information of VRRPs sorted by subinterface,
+// address family and VRID.
+func (vmgr *VRRPMgr) VRRPInfos() []VRRPInfo {
+	vmgr.lock.RLock()
+	defer vmgr.lock.RUnlock()
+
+	infos := []VRRPInfo{}
+	for _, v := range vmgr.vrrpTable {
+		infos = append(infos, v.Info())
+	}
+	for _, f := range vmgr.failedTable {
+		infos = append(infos, f.info())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		a, b := infos[i], infos[j]
+		if a.Subifname != b.Subifname {
+			return a.Subifname < b.Subifname
+		}
+		if a.AF != b.AF {
+			return a.AF < b.AF
+		}
+		return a.Vrid < b.Vrid
+	})
+
+	return infos
+}
+
+// GlobalStatistics Get global statistics.
+func (vmgr *VRRPMgr) GlobalStatistics() GlobalStatistics {
+	vmgr.statsLock.Lock()
+	defer vmgr.statsLock.Unlock()
+
+	return vmgr.stats
+}
+
+// vrrp settings.
+type vrrpSetting struct {
+	subifModel *models.Subinterface
+	af         models.AddressFamily
+	vrrpModel  *models.VRRP
+}
+
+// createVRRPNoLock Create and start VRRP.
+func (vmgr *VRRPMgr) createVRRPNoLock(s *vrrpSetting) error {
+	v, err := newVRRP(s.subifModel, s.af, s.vrrpModel)
+	if err != nil {
+		return err
+	}
+
+	v.startTracking()
+	v.NextState(EventStart)
+	vmgr.vrrpTable[v.objID] = v
+	log.Debugf("Create VRRP: %v", v)
+
+	return nil
+}
+
+// deleteVRRPNoLock Shutdown and delete VRRP.
+func (vmgr *VRRPMgr) deleteVRRPNoLock(v *VRRP) {
+	v.stopTracking()
+	v.NextState(EventShutdown)
+	delete(vmgr.vrrpTable, v.objID)
+	if group := v.SyncGroup(); group != "" {
+		vmgr.leaveSyncGroup(v, group)
+	}
+	log.Debugf("Delete VRRP: %v", v)
+}
+
+// addFailedNoLock Add failed VRRP, it is retried after backoff.
+func (vmgr *VRRPMgr) addFailedNoLock(objID string, s *vrrpSetting,
+	err error, backoff time.Duration, now time.Time) {
+	log.Errorf("Apply VRRP(%s) failed, retry after %v: %v", objID, backoff, err)
+	vmgr.failedTable[objID] = &failedVRRP{
+		setting:   s,
+		err:       err,
+		backoff:   backoff,
+		nextRetry: now.Add(backoff),
+	}
+}
+
 // UpdateSettings Update settings.
+// Only changed VRRPs are updated, and unchanged VRRPs keep their state.
+// Failed VRRPs don't affect others, they are retried by RetryFailed().
 func (vmgr *VRRPMgr) UpdateSettings(subifTable map[string]*models.Subinterface) error {
 	vmgr.lock.Lock()
 	defer vmgr.lock.Unlock()
 
 	log.Debugf("Update settings")
 
-	// delete all vrrp
+	settings := map[string]*vrrpSetting{}
+	for _, subifModel := range subifTable {
+		if subifModel.IsValid() {
+			for _, af := range models.AddressFamilies {
+				for _, vrrpModel := range subifModel.VRRPTable(af) {
+					objID := createObjID(subifModel.Name, af, vrrpModel.Vrid)
+					settings[objID] = &vrrpSetting{
+						subifModel: subifModel,
+						af:         af,
+						vrrpModel:  vrrpModel,
+					}
+				}
+			}
+		} else {
+			log.Errorf("Create VRRP failed: %s", subifModel)
+		}
+	}
+
+	// delete removed vrrp
 	for _, v := range vmgr.vrrpTable {
-		v.NextState(EventShutdown)
-		delete(vmgr.vrrpTable, v.objID)
-		log.Debugf("Delete VRRP: %v", v)
+		if s, ok := settings[v.objID]; ok && !v.needRebuild(s.subifModel, s.af) {
+			continue
+		}
+		vmgr.deleteVRRPNoLock(v)
 	}
 
+	// failed vrrp is applied again by new settings.
+	vmgr.failedTable = map[string]*failedVRRP{}
+
 	if module.GetState() == module.StateSuspended {
 		if err := module.ResumeModules(); err != nil {
 			return err
 		}
 	}
 
-	for _, subifModel := range subifTable {
-		if subifModel.IsValid() {
-			for _, vrrpModel := range subifModel.VRRPs {
-				if v, err := newVRRP(subifModel, vrrpModel); err == nil {
-					v.NextState(EventStart)
-					vmgr.vrrpTable[v.objID] = v
-					log.Debugf("Create VRRP: %v", v)
-				} else {
-					return err
-				}
+	now := time.Now()
+	failures := map[string]error{}
+	for objID, s := range settings {
+		if v, ok := vmgr.vrrpTable[objID]; ok {
+			if err := v.update(s.subifModel, s.af, s.vrrpModel); err != nil {
+				vmgr.deleteVRRPNoLock(v)
+				vmgr.addFailedNoLock(objID, s, err, RetryInitialBackoff, now)
+				failures[objID] = err
+				continue
 			}
-		} else {
-			log.Errorf("Create VRRP failed: %s", subifModel)
+			log.Debugf("Update VRRP: %v", v)
+		} else if err := vmgr.createVRRPNoLock(s); err != nil {
+			vmgr.addFailedNoLock(objID, s, err, RetryInitialBackoff, now)
+			failures[objID] = err
 		}
 	}
 
-	if len(vmgr.vrrpTable) == 0 {
+	if len(vmgr.vrrpTable) == 0 && len(vmgr.failedTable) == 0 {
 		if err := module.SuspendModules(); err != nil {
 			return err
 		}
 	}
 
+	if len(failures) != 0 {
+		return &ApplyError{
+			Failures: failures,
+			Applied:  len(settings) - len(failures),
+		}
+	}
+
 	return nil
 }
+
+// RetryFailed Retry failed VRRPs whose backoff has expired.
+func (vmgr *VRRPMgr) RetryFailed(now time.Time) {
+	vmgr.lock.Lock()
+	defer vmgr.lock.Unlock()
+
+	for objID, f := range vmgr.failedTable {
+		if now.Before(f.nextRetry) {
+			continue
+		}
+
+		delete(vmgr.failedTable, objID)
+		if err := vmgr.createVRRPNoLock(f.setting); err != nil {
+			backoff := f.backoff * 2
+			if backoff > RetryMaxBackoff {
+				backoff = RetryMaxBackoff
+			}
+			vmgr.addFailedNoLock(objID, f.setting, err, backoff, now)
+			continue
+		}
+		log.Infof("Retry VRRP(%s) succeeded.", objID)
+	}
+}
@@ -0,0 +1,44 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testVRRPMgrTestSuite struct {
+	suite.Suite
+}
+
+func (suite *testVRRPMgrTestSuite) TestApplyErrorPartial() {
+	failures := map[string]error{"if0-1:ipv4:1": errors.New("failed")}
+
+	// some VRRPs are applied.
+	err := &ApplyError{Failures: failures, Applied: 1}
+	suite.True(err.Partial())
+
+	// all VRRPs failed.
+	err = &ApplyError{Failures: failures}
+	suite.False(err.Partial())
+}
+
+func TestVRRPMgrTestSuite(t *testing.T) {
+	suite.Run(t, new(testVRRPMgrTestSuite))
+}
@@ -0,0 +1,143 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lagopus/vrrpd/models"
+	"github.com/lagopus/vrrpd/packets/layers"
+	"github.com/lagopus/vrrpd/rpc"
+	"github.com/stretchr/testify/suite"
+)
+
+type testVRRPTestSuite struct {
+	suite.Suite
+}
+
+// DataPlane agent recording transitions to master.
+type testDPAgent struct {
+	masterChannel chan string
+}
+
+func (d *testDPAgent) GetVifMacaddr(vif string) (net.HardwareAddr, error) {
+	return net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x01, 0x01}, nil
+}
+
+func (d *testDPAgent) ToMaster(name string, phyaddr string, vaddr []string) error {
+	d.masterChannel <- name
+	return nil
+}
+
+func (d *testDPAgent) ToBackup(name string, phyaddr string, vaddr []string) error {
+	return nil
+}
+
+func createBackupVRRP(dpa *testDPAgent, delay time.Duration) *VRRP {
+	wg := &sync.WaitGroup{}
+	hostif := rpc.NewHostif("127.0.0.1", 0, nil, wg)
+	subifIP := net.ParseIP("10.0.0.1").To4()
+
+	v := &VRRP{
+		objID:        "if0-1:ipv4:1",
+		preempt:      true,
+		preemptDelay: delay,
+		decrements:   map[string]uint8{},
+		faults:       map[string]bool{},
+		state:        StateBackup,
+		af:           models.AddressFamilyIPv4,
+		version:      models.VRRPVersion3,
+		vaddrs:       []net.IP{net.ParseIP("10.0.0.254").To4()},
+		subifName:    "if0-1",
+		subifIP:      subifIP,
+		subifPrefix:  24,
+		srcIP:        subifIP,
+		advTimer:     NewAdvTimer(hostif, wg),
+		mDownTimer:   NewMDownTimer(wg),
+		notifier:     NewNotifier(map[string]*models.NotifyScript{}, wg),
+		hostif:       hostif,
+		dpagent:      dpa,
+	}
+	v.VirtualRtrID = 1
+	v.Priority = 200
+	v.MaxAdverInt = 100
+
+	return v
+}
+
+func createAdv(priority uint8) *layers.VRRPv3Adv {
+	return &layers.VRRPv3Adv{
+		Version:      layers.VRRPv3Version,
+		VirtualRtrID: 1,
+		Priority:     priority,
+		MaxAdverInt:  100,
+		IPAddress:    []net.IP{net.ParseIP("10.0.0.254").To4()},
+	}
+}
+
+func (suite *testVRRPTestSuite) TestPreemptDelayExpired() {
+	dpa := &testDPAgent{masterChannel: make(chan string, 1)}
+	v := createBackupVRRP(dpa, 100*time.Millisecond)
+
+	// adv of lower priority starts preempt delay.
+	v.NextStateForRecv(createAdv(100), net.ParseIP("10.0.0.2").To4(), time.Now())
+	v.lock.Lock()
+	suite.Equal(StateBackup, v.state)
+	v.lock.Unlock()
+
+	// preempt without further adv.
+	select {
+	case name := <-dpa.masterChannel:
+		suite.Equal("if0-1", name)
+	case <-time.After(time.Second):
+		suite.Fail("not preempted")
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	suite.Equal(StateMaster, v.state)
+	suite.Equal(NewMasterReasonPreempted, v.newMasterReason)
+	suite.True(v.preemptTime.IsZero())
+}
+
+func (suite *testVRRPTestSuite) TestPreemptDelayCanceled() {
+	dpa := &testDPAgent{masterChannel: make(chan string, 1)}
+	v := createBackupVRRP(dpa, 100*time.Millisecond)
+
+	v.NextStateForRecv(createAdv(100), net.ParseIP("10.0.0.2").To4(), time.Now())
+
+	// adv of higher priority cancels preempt delay.
+	v.NextStateForRecv(createAdv(250), net.ParseIP("10.0.0.2").To4(), time.Now())
+
+	select {
+	case <-dpa.masterChannel:
+		suite.Fail("preempted")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	suite.Equal(StateBackup, v.state)
+	suite.True(v.preemptTime.IsZero())
+}
+
+func TestVRRPTestSuite(t *testing.T) {
+	suite.Run(t, new(testVRRPTestSuite))
+}
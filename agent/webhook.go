@@ -0,0 +1,312 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agent
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lagopus/vrrpd/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// WebhookModuleName Webhook module name.
+	WebhookModuleName = "WebhookModule"
+	// WebhookEventHeader Header of event type.
+	WebhookEventHeader = "X-Vrrpd-Event"
+	// WebhookSignatureHeader Header of HMAC-SHA256 signature of body
+	// ("sha256=" followed by hex digest), set if secret is configured.
+	WebhookSignatureHeader = "X-Vrrpd-Signature"
+	// WebhookRetryInterval Initial interval of retries, doubled for each retry.
+	WebhookRetryInterval = 1 * time.Second
+	// WebhookMaxRetryInterval Max interval of retries.
+	WebhookMaxRetryInterval = 60 * time.Second
+	// webhookSubscriberName Name of subscriber of notifications.
+	webhookSubscriberName = "webhook"
+)
+
+// webhookPayload JSON body of webhook.
+type webhookPayload struct {
+	Event        string    `json:"event"`
+	Time         time.Time `json:"time"`
+	Subinterface string    `json:"subinterface"`
+	AF           string    `json:"af"`
+	Vrid         uint8     `json:"vrid"`
+	// state-change.
+	OldState        string `json:"old_state,omitempty"`
+	NewState        string `json:"new_state,omitempty"`
+	Trigger         string `json:"trigger,omitempty"`
+	MasterAddress   string `json:"master_address,omitempty"`
+	NewMasterReason string `json:"new_master_reason,omitempty"`
+	AdvSource       string `json:"adv_source,omitempty"`
+	AdvPriority     uint8  `json:"adv_priority,omitempty"`
+	// proto-error.
+	ProtoErrReason string `json:"proto_error_reason,omitempty"`
+	// number of notifications dropped before this one.
+	Dropped uint64 `json:"dropped,omitempty"`
+}
+
+func newWebhookPayload(n *Notification) *webhookPayload {
+	p := &webhookPayload{
+		Time:         n.Time,
+		Subinterface: n.Subifname,
+		AF:           n.AF.String(),
+		Vrid:         n.Vrid,
+		Dropped:      n.Dropped,
+	}
+
+	switch n.Type {
+	case NotificationStateChange:
+		p.Event = models.WebhookEventStateChange
+		p.OldState = n.OldState.String()
+		p.NewState = n.NewState.String()
+		p.Trigger = n.Event.String()
+		if n.MasterIP != nil {
+			p.MasterAddress = n.MasterIP.String()
+		}
+		if n.NewState == StateMaster {
+			p.NewMasterReason = n.NewMasterReason.String()
+		}
+		if n.AdvSrcIP != nil {
+			p.AdvSource = n.AdvSrcIP.String()
+			p.AdvPriority = n.AdvPriority
+		}
+	case NotificationProtoError:
+		p.Event = models.WebhookEventProtoError
+		p.ProtoErrReason = n.ProtoErrReason.String()
+	}
+
+	return p
+}
+
+// webhookSignature HMAC-SHA256 signature of body.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookDelivery Body to be delivered.
+type webhookDelivery struct {
+	event string
+	body  []byte
+}
+
+type webhookTarget struct {
+	*models.Webhook
+	client *http.Client
+	queue  chan *webhookDelivery
+}
+
+// post Post body to target, and report whether it may be retried if failed.
+func (t *webhookTarget) post(d *webhookDelivery) (bool, error) {
+	req, err := http.NewRequest("POST", t.URL, bytes.NewReader(d.body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, d.event)
+	if t.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, webhookSignature(t.Secret, d.body))
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode >= 500 ||
+		resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests:
+		return true, fmt.Errorf("status %s", resp.Status)
+	}
+	return false, fmt.Errorf("status %s", resp.Status)
+}
+
+// Webhook Sender of webhooks of notifications.
+// Each target has a bounded queue, and deliveries are retried with
+// exponential backoff.
+type Webhook struct {
+	targets     []*webhookTarget
+	stopChannel chan bool
+	isRunning   bool
+	wg          *sync.WaitGroup
+	lock        sync.Mutex
+}
+
+// NewWebhook New Webhook module.
+func NewWebhook(whs map[string]*models.Webhook, wg *sync.WaitGroup) *Webhook {
+	targets := []*webhookTarget{}
+	for _, wh := range whs {
+		targets = append(targets, &webhookTarget{
+			Webhook: wh.Copy(),
+			client:  &http.Client{Timeout: time.Duration(wh.Timeout) * time.Second},
+			queue:   make(chan *webhookDelivery, wh.QueueSize),
+		})
+	}
+
+	return &Webhook{
+		targets:     targets,
+		stopChannel: make(chan bool),
+		wg:          wg,
+	}
+}
+
+// enqueue Enqueue notification to targets subscribing it.
+func (w *Webhook) enqueue(n *Notification) {
+	p := newWebhookPayload(n)
+	body, err := json.Marshal(p)
+	if err != nil {
+		log.Errorf("Webhook payload marshal failed: %v", err)
+		return
+	}
+
+	for _, t := range w.targets {
+		if !t.Subscribes(p.Event) {
+			continue
+		}
+		select {
+		case t.queue <- &webhookDelivery{event: p.Event, body: body}:
+		default:
+			log.Warnf("Webhook %s: %s of VRRP(%s) is dropped: queue is full",
+				t.Name, p.Event, createObjID(n.Subifname, n.AF, n.Vrid))
+		}
+	}
+}
+
+// dispatchLoop Dispatch notifications to targets.
+func (w *Webhook) dispatchLoop(notifications <-chan *Notification,
+	stopChannel chan bool) {
+	defer w.wg.Done()
+	defer vmgr.Unsubscribe(webhookSubscriberName)
+
+	for {
+		select {
+		case n, ok := <-notifications:
+			if ok {
+				w.enqueue(n)
+			}
+		case <-stopChannel:
+			log.Infof("Stop webhook dispatcher.")
+			return
+		}
+	}
+}
+
+// deliverLoop Deliver queued bodies to target.
+func (w *Webhook) deliverLoop(t *webhookTarget, stopChannel chan bool) {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case d := <-t.queue:
+			interval := WebhookRetryInterval
+			for retries := uint8(0); ; retries++ {
+				retry, err := t.post(d)
+				if err == nil {
+					log.Debugf("Webhook %s: %s delivered.", t.Name, d.event)
+					break
+				}
+				if !retry || retries >= t.Retries {
+					log.Errorf("Webhook %s: delivery of %s failed: %v",
+						t.Name, d.event, err)
+					break
+				}
+
+				log.Warnf("Webhook %s: delivery of %s failed, retry after %v: %v",
+					t.Name, d.event, interval, err)
+				select {
+				case <-time.After(interval):
+				case <-stopChannel:
+					log.Infof("Stop webhook(%s).", t.Name)
+					return
+				}
+				if interval *= 2; interval > WebhookMaxRetryInterval {
+					interval = WebhookMaxRetryInterval
+				}
+			}
+		case <-stopChannel:
+			log.Infof("Stop webhook(%s).", t.Name)
+			return
+		}
+	}
+}
+
+// Start Start webhook delivery.
+func (w *Webhook) Start() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.isRunning == false {
+		for _, t := range w.targets {
+			w.wg.Add(1)
+			go w.deliverLoop(t, w.stopChannel)
+		}
+		w.wg.Add(1)
+		go w.dispatchLoop(vmgr.Subscribe(webhookSubscriberName), w.stopChannel)
+
+		w.isRunning = true
+	}
+
+	return nil
+}
+
+// Stop Stop webhook delivery.
+// Queued bodies are delivered after restart.
+func (w *Webhook) Stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.isRunning == true {
+		// stop all loops.
+		close(w.stopChannel)
+		w.stopChannel = make(chan bool)
+		w.isRunning = false
+	}
+}
+
+// Resume Resume module.
+func (w *Webhook) Resume() error {
+	// implement if necessary.
+	return nil
+}
+
+// Suspend Suspend module.
+func (w *Webhook) Suspend() error {
+	// notifications are delivered while suspended.
+	return nil
+}
+
+// Name Module name.
+func (w *Webhook) Name() string {
+	return WebhookModuleName
+}
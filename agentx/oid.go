@@ -0,0 +1,104 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agentx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OID Object identifier.
+type OID []uint32
+
+// ParseOID Parse dotted OID string(e.g. "1.3.6.1.2.1").
+func ParseOID(s string) (OID, error) {
+	s = strings.TrimPrefix(s, ".")
+	if s == "" {
+		return OID{}, nil
+	}
+
+	strs := strings.Split(s, ".")
+	oid := make(OID, len(strs))
+	for i, str := range strs {
+		subid, err := strconv.ParseUint(str, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("bad OID %s: %v", s, err)
+		}
+		oid[i] = uint32(subid)
+	}
+
+	return oid, nil
+}
+
+// MustParseOID Parse dotted OID string, panics if it is invalid.
+func MustParseOID(s string) OID {
+	oid, err := ParseOID(s)
+	if err != nil {
+		panic(err)
+	}
+	return oid
+}
+
+func (o OID) String() string {
+	strs := make([]string, len(o))
+	for i, subid := range o {
+		strs[i] = strconv.FormatUint(uint64(subid), 10)
+	}
+	return strings.Join(strs, ".")
+}
+
+// Append Append sub-identifiers to copy of OID.
+func (o OID) Append(subids ...uint32) OID {
+	oid := make(OID, 0, len(o)+len(subids))
+	oid = append(oid, o...)
+	return append(oid, subids...)
+}
+
+// HasPrefix Reports whether OID begins with prefix.
+func (o OID) HasPrefix(prefix OID) bool {
+	if len(o) < len(prefix) {
+		return false
+	}
+	for i, subid := range prefix {
+		if o[i] != subid {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare Compare OIDs lexicographically.
+// The result is -1 if a < b, 0 if a == b and +1 if a > b.
+func Compare(a OID, b OID) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,654 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// The following was in reference.
+// - RFC 2741 Agent Extensibility (AgentX) Protocol Version 1
+
+package agentx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+const (
+	// Version Version of AgentX protocol.
+	Version = 1
+	// HeaderSize Size of PDU header.
+	HeaderSize = 20
+	// MaxPayloadSize Max size of payload accepted.
+	MaxPayloadSize = 1 << 20
+)
+
+// PDUType Type of PDU.
+type PDUType uint8
+
+const (
+	// PDUOpen agentx-Open-PDU.
+	PDUOpen PDUType = iota + 1
+	// PDUClose agentx-Close-PDU.
+	PDUClose
+	// PDURegister agentx-Register-PDU.
+	PDURegister
+	// PDUUnregister agentx-Unregister-PDU.
+	PDUUnregister
+	// PDUGet agentx-Get-PDU.
+	PDUGet
+	// PDUGetNext agentx-GetNext-PDU.
+	PDUGetNext
+	// PDUGetBulk agentx-GetBulk-PDU.
+	PDUGetBulk
+	// PDUTestSet agentx-TestSet-PDU.
+	PDUTestSet
+	// PDUCommitSet agentx-CommitSet-PDU.
+	PDUCommitSet
+	// PDUUndoSet agentx-UndoSet-PDU.
+	PDUUndoSet
+	// PDUCleanupSet agentx-CleanupSet-PDU.
+	PDUCleanupSet
+	// PDUNotify agentx-Notify-PDU.
+	PDUNotify
+	// PDUPing agentx-Ping-PDU.
+	PDUPing
+	// PDUIndexAllocate agentx-IndexAllocate-PDU.
+	PDUIndexAllocate
+	// PDUIndexDeallocate agentx-IndexDeallocate-PDU.
+	PDUIndexDeallocate
+	// PDUAddAgentCaps agentx-AddAgentCaps-PDU.
+	PDUAddAgentCaps
+	// PDURemoveAgentCaps agentx-RemoveAgentCaps-PDU.
+	PDURemoveAgentCaps
+	// PDUResponse agentx-Response-PDU.
+	PDUResponse
+)
+
+func (t PDUType) String() string {
+	strs := []string{"Open", "Close", "Register", "Unregister", "Get",
+		"GetNext", "GetBulk", "TestSet", "CommitSet", "UndoSet", "CleanupSet",
+		"Notify", "Ping", "IndexAllocate", "IndexDeallocate", "AddAgentCaps",
+		"RemoveAgentCaps", "Response"}
+	if t >= PDUOpen && t <= PDUResponse {
+		return strs[t-PDUOpen]
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint8(t))
+}
+
+// Flags of PDU header.
+const (
+	// FlagInstanceRegistration INSTANCE_REGISTRATION.
+	FlagInstanceRegistration uint8 = 1 << iota
+	// FlagNewIndex NEW_INDEX.
+	FlagNewIndex
+	// FlagAnyIndex ANY_INDEX.
+	FlagAnyIndex
+	// FlagNonDefaultContext NON_DEFAULT_CONTEXT.
+	FlagNonDefaultContext
+	// FlagNetworkByteOrder NETWORK_BYTE_ORDER.
+	FlagNetworkByteOrder
+)
+
+// CloseReason Reason of agentx-Close-PDU.
+type CloseReason uint8
+
+const (
+	// ReasonOther reasonOther.
+	ReasonOther CloseReason = iota + 1
+	// ReasonParseError reasonParseError.
+	ReasonParseError
+	// ReasonProtocolError reasonProtocolError.
+	ReasonProtocolError
+	// ReasonTimeouts reasonTimeouts.
+	ReasonTimeouts
+	// ReasonShutdown reasonShutdown.
+	ReasonShutdown
+	// ReasonByManager reasonByManager.
+	ReasonByManager
+)
+
+// ResponseError Error of agentx-Response-PDU.
+type ResponseError uint16
+
+const (
+	// NoAgentXError noAgentXError.
+	NoAgentXError ResponseError = 0
+	// GenErr genErr.
+	GenErr ResponseError = 5
+	// NotWritable notWritable.
+	NotWritable ResponseError = 17
+	// OpenFailed openFailed.
+	OpenFailed ResponseError = 256
+	// NotOpen notOpen.
+	NotOpen ResponseError = 257
+	// IndexWrongType indexWrongType.
+	IndexWrongType ResponseError = 258
+	// IndexAlreadyAllocated indexAlreadyAllocated.
+	IndexAlreadyAllocated ResponseError = 259
+	// IndexNoneAvailable indexNoneAvailable.
+	IndexNoneAvailable ResponseError = 260
+	// IndexNotAllocated indexNotAllocated.
+	IndexNotAllocated ResponseError = 261
+	// UnsupportedContext unsupportedContext.
+	UnsupportedContext ResponseError = 262
+	// DuplicateRegistration duplicateRegistration.
+	DuplicateRegistration ResponseError = 263
+	// UnknownRegistration unknownRegistration.
+	UnknownRegistration ResponseError = 264
+	// UnknownAgentCaps unknownAgentCaps.
+	UnknownAgentCaps ResponseError = 265
+	// ParseError parseError.
+	ParseError ResponseError = 266
+	// RequestDenied requestDenied.
+	RequestDenied ResponseError = 267
+	// ProcessingError processingError.
+	ProcessingError ResponseError = 268
+)
+
+func (e ResponseError) Error() string {
+	return fmt.Sprintf("agentx error %d", uint16(e))
+}
+
+// VarBindType Type of VarBind.
+type VarBindType uint16
+
+const (
+	// TypeInteger Integer.
+	TypeInteger VarBindType = 2
+	// TypeOctetString Octet String.
+	TypeOctetString VarBindType = 4
+	// TypeNull Null.
+	TypeNull VarBindType = 5
+	// TypeObjectIdentifier Object Identifier.
+	TypeObjectIdentifier VarBindType = 6
+	// TypeIPAddress IpAddress.
+	TypeIPAddress VarBindType = 64
+	// TypeCounter32 Counter32.
+	TypeCounter32 VarBindType = 65
+	// TypeGauge32 Gauge32.
+	TypeGauge32 VarBindType = 66
+	// TypeTimeTicks TimeTicks.
+	TypeTimeTicks VarBindType = 67
+	// TypeOpaque Opaque.
+	TypeOpaque VarBindType = 68
+	// TypeCounter64 Counter64.
+	TypeCounter64 VarBindType = 70
+	// TypeNoSuchObject noSuchObject.
+	TypeNoSuchObject VarBindType = 128
+	// TypeNoSuchInstance noSuchInstance.
+	TypeNoSuchInstance VarBindType = 129
+	// TypeEndOfMibView endOfMibView.
+	TypeEndOfMibView VarBindType = 130
+)
+
+// VarBind Variable binding.
+// Value is int32(Integer), []byte(Octet String, Opaque), OID,
+// net.IP(IpAddress), uint32(Counter32, Gauge32, TimeTicks),
+// uint64(Counter64) or nil.
+type VarBind struct {
+	Type  VarBindType
+	Name  OID
+	Value interface{}
+}
+
+// SearchRange Range of OIDs in Get/GetNext/GetBulk.
+type SearchRange struct {
+	Start   OID
+	Include bool
+	End     OID
+}
+
+// Header Header of PDU.
+type Header struct {
+	Version       uint8
+	Type          PDUType
+	Flags         uint8
+	SessionID     uint32
+	TransactionID uint32
+	PacketID      uint32
+	PayloadLength uint32
+}
+
+// PDU AgentX PDU.
+// Only the fields used by the type of PDU are encoded.
+type PDU struct {
+	Header
+	Context string
+	// Open, Register.
+	Timeout uint8
+	// Register.
+	Priority uint8
+	// Open.
+	ID    OID
+	Descr string
+	// Register, Unregister.
+	Subtree OID
+	// Close.
+	Reason CloseReason
+	// GetBulk.
+	NonRepeaters   uint16
+	MaxRepetitions uint16
+	// Get, GetNext, GetBulk.
+	SearchRanges []SearchRange
+	// Response.
+	SysUpTime uint32
+	Error     ResponseError
+	Index     uint16
+	// TestSet, Notify, Response.
+	VarBinds []VarBind
+}
+
+// encoder Encoder of PDU, always in network byte order.
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func (e *encoder) uint8(v uint8) {
+	e.buf.WriteByte(v)
+}
+
+func (e *encoder) uint16(v uint16) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) uint32(v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) uint64(v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) octetString(v []byte) {
+	e.uint32(uint32(len(v)))
+	e.buf.Write(v)
+	// padding to 4-byte alignment.
+	if pad := len(v) % 4; pad != 0 {
+		e.buf.Write(make([]byte, 4-pad))
+	}
+}
+
+func (e *encoder) oid(oid OID, include bool) {
+	e.uint8(uint8(len(oid)))
+	// prefix is not used.
+	e.uint8(0)
+	if include {
+		e.uint8(1)
+	} else {
+		e.uint8(0)
+	}
+	e.uint8(0)
+	for _, subid := range oid {
+		e.uint32(subid)
+	}
+}
+
+func (e *encoder) varBind(vb *VarBind) error {
+	e.uint16(uint16(vb.Type))
+	e.uint16(0)
+	e.oid(vb.Name, false)
+
+	var ok bool
+	switch vb.Type {
+	case TypeInteger:
+		var v int32
+		if v, ok = vb.Value.(int32); ok {
+			e.uint32(uint32(v))
+		}
+	case TypeCounter32, TypeGauge32, TypeTimeTicks:
+		var v uint32
+		if v, ok = vb.Value.(uint32); ok {
+			e.uint32(v)
+		}
+	case TypeCounter64:
+		var v uint64
+		if v, ok = vb.Value.(uint64); ok {
+			e.uint64(v)
+		}
+	case TypeOctetString, TypeOpaque:
+		var v []byte
+		if v, ok = vb.Value.([]byte); ok {
+			e.octetString(v)
+		}
+	case TypeIPAddress:
+		var v net.IP
+		if v, ok = vb.Value.(net.IP); ok {
+			if v = v.To4(); v != nil {
+				e.octetString(v)
+			} else {
+				ok = false
+			}
+		}
+	case TypeObjectIdentifier:
+		var v OID
+		if v, ok = vb.Value.(OID); ok {
+			e.oid(v, false)
+		}
+	case TypeNull, TypeNoSuchObject, TypeNoSuchInstance, TypeEndOfMibView:
+		ok = true
+	}
+	if !ok {
+		return fmt.Errorf("bad value of %s(type %d): %v", vb.Name, vb.Type, vb.Value)
+	}
+
+	return nil
+}
+
+// MarshalBinary Encode PDU in network byte order.
+// PayloadLength and NETWORK_BYTE_ORDER flag in header are set by encoding.
+func (p *PDU) MarshalBinary() ([]byte, error) {
+	e := &encoder{}
+
+	flags := p.Flags | FlagNetworkByteOrder
+	if p.Context != "" {
+		flags |= FlagNonDefaultContext
+		switch p.Type {
+		case PDURegister, PDUUnregister, PDUGet, PDUGetNext, PDUGetBulk,
+			PDUTestSet, PDUNotify, PDUPing, PDUIndexAllocate,
+			PDUIndexDeallocate, PDUAddAgentCaps, PDURemoveAgentCaps:
+			e.octetString([]byte(p.Context))
+		default:
+			return nil, fmt.Errorf("context is not allowed in %s", p.Type)
+		}
+	}
+
+	switch p.Type {
+	case PDUOpen:
+		e.uint8(p.Timeout)
+		e.uint8(0)
+		e.uint16(0)
+		e.oid(p.ID, false)
+		e.octetString([]byte(p.Descr))
+	case PDUClose:
+		e.uint8(uint8(p.Reason))
+		e.uint8(0)
+		e.uint16(0)
+	case PDURegister, PDUUnregister:
+		if p.Type == PDURegister {
+			e.uint8(p.Timeout)
+		} else {
+			e.uint8(0)
+		}
+		e.uint8(p.Priority)
+		// range_subid is not used.
+		e.uint8(0)
+		e.uint8(0)
+		e.oid(p.Subtree, false)
+	case PDUGet, PDUGetNext, PDUGetBulk:
+		if p.Type == PDUGetBulk {
+			e.uint16(p.NonRepeaters)
+			e.uint16(p.MaxRepetitions)
+		}
+		for _, sr := range p.SearchRanges {
+			e.oid(sr.Start, sr.Include)
+			e.oid(sr.End, false)
+		}
+	case PDUResponse:
+		e.uint32(p.SysUpTime)
+		e.uint16(uint16(p.Error))
+		e.uint16(p.Index)
+	}
+
+	switch p.Type {
+	case PDUTestSet, PDUNotify, PDUResponse:
+		for i := range p.VarBinds {
+			if err := e.varBind(&p.VarBinds[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	payload := e.buf.Bytes()
+	h := &encoder{}
+	h.uint8(Version)
+	h.uint8(uint8(p.Type))
+	h.uint8(flags)
+	h.uint8(0)
+	h.uint32(p.SessionID)
+	h.uint32(p.TransactionID)
+	h.uint32(p.PacketID)
+	h.uint32(uint32(len(payload)))
+	h.buf.Write(payload)
+
+	return h.buf.Bytes(), nil
+}
+
+var errShortPayload = errors.New("short payload")
+
+// decoder Decoder of PDU in byte order of header.
+type decoder struct {
+	order binary.ByteOrder
+	data  []byte
+	err   error
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.data) < n {
+		d.err = errShortPayload
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *decoder) uint8() uint8 {
+	if b := d.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (d *decoder) uint16() uint16 {
+	if b := d.next(2); b != nil {
+		return d.order.Uint16(b)
+	}
+	return 0
+}
+
+func (d *decoder) uint32() uint32 {
+	if b := d.next(4); b != nil {
+		return d.order.Uint32(b)
+	}
+	return 0
+}
+
+func (d *decoder) uint64() uint64 {
+	if b := d.next(8); b != nil {
+		return d.order.Uint64(b)
+	}
+	return 0
+}
+
+func (d *decoder) octetString() []byte {
+	n := int(d.uint32())
+	if d.err != nil {
+		return nil
+	}
+	if n > len(d.data) {
+		d.err = errShortPayload
+		return nil
+	}
+	v := append([]byte{}, d.next(n)...)
+	if pad := n % 4; pad != 0 {
+		d.next(4 - pad)
+	}
+	return v
+}
+
+// oid Decode OID, prefix is expanded to 1.3.6.1.<prefix>.
+func (d *decoder) oid() (OID, bool) {
+	n := int(d.uint8())
+	prefix := d.uint8()
+	include := d.uint8() != 0
+	d.uint8()
+
+	oid := OID{}
+	if prefix != 0 {
+		oid = append(oid, 1, 3, 6, 1, uint32(prefix))
+	}
+	for i := 0; i < n && d.err == nil; i++ {
+		oid = append(oid, d.uint32())
+	}
+	return oid, include
+}
+
+func (d *decoder) varBind() VarBind {
+	vb := VarBind{Type: VarBindType(d.uint16())}
+	d.uint16()
+	vb.Name, _ = d.oid()
+
+	switch vb.Type {
+	case TypeInteger:
+		vb.Value = int32(d.uint32())
+	case TypeCounter32, TypeGauge32, TypeTimeTicks:
+		vb.Value = d.uint32()
+	case TypeCounter64:
+		vb.Value = d.uint64()
+	case TypeOctetString, TypeOpaque:
+		vb.Value = d.octetString()
+	case TypeIPAddress:
+		vb.Value = net.IP(d.octetString())
+	case TypeObjectIdentifier:
+		vb.Value, _ = d.oid()
+	case TypeNull, TypeNoSuchObject, TypeNoSuchInstance, TypeEndOfMibView:
+		// no value.
+	default:
+		if d.err == nil {
+			d.err = fmt.Errorf("unknown type of varbind: %d", vb.Type)
+		}
+	}
+
+	return vb
+}
+
+// decodeHeader Decode header of PDU.
+func decodeHeader(b []byte) (*Header, error) {
+	if len(b) < HeaderSize {
+		return nil, errShortPayload
+	}
+
+	h := &Header{
+		Version: b[0],
+		Type:    PDUType(b[1]),
+		Flags:   b[2],
+	}
+	if h.Version != Version {
+		return nil, fmt.Errorf("unsupported version: %d", h.Version)
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if h.Flags&FlagNetworkByteOrder != 0 {
+		order = binary.BigEndian
+	}
+	h.SessionID = order.Uint32(b[4:8])
+	h.TransactionID = order.Uint32(b[8:12])
+	h.PacketID = order.Uint32(b[12:16])
+	h.PayloadLength = order.Uint32(b[16:20])
+
+	return h, nil
+}
+
+// decodePayload Decode payload of PDU.
+func decodePayload(h *Header, payload []byte) (*PDU, error) {
+	p := &PDU{Header: *h}
+	d := &decoder{order: binary.LittleEndian, data: payload}
+	if h.Flags&FlagNetworkByteOrder != 0 {
+		d.order = binary.BigEndian
+	}
+
+	if h.Flags&FlagNonDefaultContext != 0 {
+		p.Context = string(d.octetString())
+	}
+
+	switch p.Type {
+	case PDUOpen:
+		p.Timeout = d.uint8()
+		d.next(3)
+		p.ID, _ = d.oid()
+		p.Descr = string(d.octetString())
+	case PDUClose:
+		p.Reason = CloseReason(d.uint8())
+		d.next(3)
+	case PDURegister, PDUUnregister:
+		p.Timeout = d.uint8()
+		p.Priority = d.uint8()
+		d.next(2)
+		p.Subtree, _ = d.oid()
+	case PDUGet, PDUGetNext, PDUGetBulk:
+		if p.Type == PDUGetBulk {
+			p.NonRepeaters = d.uint16()
+			p.MaxRepetitions = d.uint16()
+		}
+		for len(d.data) > 0 && d.err == nil {
+			sr := SearchRange{}
+			sr.Start, sr.Include = d.oid()
+			sr.End, _ = d.oid()
+			p.SearchRanges = append(p.SearchRanges, sr)
+		}
+	case PDUResponse:
+		p.SysUpTime = d.uint32()
+		p.Error = ResponseError(d.uint16())
+		p.Index = d.uint16()
+	}
+
+	switch p.Type {
+	case PDUTestSet, PDUNotify, PDUResponse:
+		for len(d.data) > 0 && d.err == nil {
+			p.VarBinds = append(p.VarBinds, d.varBind())
+		}
+	}
+
+	if d.err != nil {
+		return nil, fmt.Errorf("bad %s PDU: %v", p.Type, d.err)
+	}
+
+	return p, nil
+}
+
+// ReadPDU Read PDU.
+func ReadPDU(r io.Reader) (*PDU, error) {
+	b := make([]byte, HeaderSize)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+
+	h, err := decodeHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	if h.PayloadLength > MaxPayloadSize {
+		return nil, fmt.Errorf("too large payload: %d", h.PayloadLength)
+	}
+
+	payload := make([]byte, h.PayloadLength)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+
+	return decodePayload(h, payload)
+}
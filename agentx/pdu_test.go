@@ -0,0 +1,122 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agentx
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testPDUTestSuite struct {
+	suite.Suite
+}
+
+func (suite *testPDUTestSuite) TestOID() {
+	oid, err := ParseOID(".1.3.6.1.2.1.207")
+	suite.NoError(err)
+	suite.Equal(OID{1, 3, 6, 1, 2, 1, 207}, oid)
+	suite.Equal("1.3.6.1.2.1.207", oid.String())
+
+	_, err = ParseOID("1.3.x")
+	suite.Error(err)
+
+	suite.True(oid.Append(1, 2).HasPrefix(oid))
+	suite.False(oid.HasPrefix(oid.Append(1)))
+	suite.Equal(OID{1, 3, 6, 1, 2, 1, 207}, oid)
+
+	suite.Equal(0, Compare(oid, oid.Append()))
+	suite.Equal(-1, Compare(oid, oid.Append(0)))
+	suite.Equal(1, Compare(OID{1, 4}, oid))
+}
+
+func (suite *testPDUTestSuite) TestMarshalAndRead() {
+	src := &PDU{
+		Header: Header{
+			Type:          PDUResponse,
+			SessionID:     1,
+			TransactionID: 2,
+			PacketID:      3,
+		},
+		SysUpTime: 100,
+		VarBinds: []VarBind{
+			{Type: TypeInteger, Name: OID{1, 3, 1}, Value: int32(-1)},
+			{Type: TypeOctetString, Name: OID{1, 3, 2}, Value: []byte{1, 2, 3, 4, 5}},
+			{Type: TypeObjectIdentifier, Name: OID{1, 3, 3}, Value: OID{1, 3, 6}},
+			{Type: TypeIPAddress, Name: OID{1, 3, 4}, Value: net.IP{10, 0, 0, 1}},
+			{Type: TypeCounter32, Name: OID{1, 3, 5}, Value: uint32(5)},
+			{Type: TypeCounter64, Name: OID{1, 3, 6}, Value: uint64(1 << 40)},
+			{Type: TypeEndOfMibView, Name: OID{1, 3, 7}},
+		},
+	}
+
+	b, err := src.MarshalBinary()
+	suite.NoError(err)
+	suite.Equal(0, (len(b)-HeaderSize)%4)
+
+	dst, err := ReadPDU(bytes.NewReader(b))
+	suite.NoError(err)
+	suite.Equal(PDUResponse, dst.Type)
+	suite.Equal(FlagNetworkByteOrder, dst.Flags)
+	suite.Equal(uint32(1), dst.SessionID)
+	suite.Equal(uint32(2), dst.TransactionID)
+	suite.Equal(uint32(3), dst.PacketID)
+	suite.Equal(uint32(len(b)-HeaderSize), dst.PayloadLength)
+	suite.Equal(uint32(100), dst.SysUpTime)
+	suite.Equal(src.VarBinds, dst.VarBinds)
+
+	// bad value.
+	src.VarBinds = []VarBind{{Type: TypeInteger, Name: OID{1}, Value: "1"}}
+	_, err = src.MarshalBinary()
+	suite.Error(err)
+}
+
+func (suite *testPDUTestSuite) TestReadGetNext() {
+	// little endian, prefix and context.
+	b := []byte{
+		1, 6, FlagNonDefaultContext, 0,
+		1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0, 32, 0, 0, 0,
+		// context "ctx"
+		3, 0, 0, 0, 'c', 't', 'x', 0,
+		// start: 1.3.6.1.2.1.207(prefix 2), include
+		2, 2, 1, 0, 1, 0, 0, 0, 207, 0, 0, 0,
+		// end: 1.3.6.1.2.1.208(prefix 2)
+		2, 2, 0, 0, 1, 0, 0, 0, 208, 0, 0, 0,
+	}
+
+	p, err := ReadPDU(bytes.NewReader(b))
+	suite.NoError(err)
+	suite.Equal(PDUGetNext, p.Type)
+	suite.Equal(uint32(1), p.SessionID)
+	suite.Equal("ctx", p.Context)
+	suite.Equal([]SearchRange{{
+		Start:   MustParseOID("1.3.6.1.2.1.207"),
+		Include: true,
+		End:     MustParseOID("1.3.6.1.2.1.208"),
+	}}, p.SearchRanges)
+
+	// short payload.
+	b[16] = 36
+	_, err = ReadPDU(bytes.NewReader(append(b, 0, 0, 0, 1)))
+	suite.Error(err)
+}
+
+func TestPDUTestSuite(t *testing.T) {
+	suite.Run(t, new(testPDUTestSuite))
+}
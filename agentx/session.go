@@ -0,0 +1,234 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agentx
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultAddress Default address of master agent.
+	DefaultAddress = "/var/agentx/master"
+	// DefaultPriority Default priority of registration.
+	DefaultPriority = 127
+	// DefaultTimeout Default timeout of session.
+	DefaultTimeout = 5 * time.Second
+)
+
+// Handler Handler of requests from master agent.
+type Handler interface {
+	// Tree Get MIB view to answer a request.
+	Tree() *Tree
+}
+
+// Session AgentX session to master agent.
+type Session struct {
+	conn     net.Conn
+	id       uint32
+	packetID uint32
+	timeout  time.Duration
+	lock     sync.Mutex
+}
+
+// ParseAddress Parse address of master agent in the form of
+// "tcp:<host>:<port>", "unix:<path>" or "<path>".
+func ParseAddress(addr string) (string, string) {
+	switch {
+	case strings.HasPrefix(addr, "tcp:"):
+		return "tcp", strings.TrimPrefix(addr, "tcp:")
+	case strings.HasPrefix(addr, "unix:"):
+		return "unix", strings.TrimPrefix(addr, "unix:")
+	}
+	return "unix", addr
+}
+
+// Dial Connect to master agent.
+func Dial(addr string, timeout time.Duration) (*Session, error) {
+	network, address := ParseAddress(addr)
+	conn, err := net.DialTimeout(network, address, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		conn:    conn,
+		timeout: timeout,
+	}, nil
+}
+
+// ID Session ID.
+func (s *Session) ID() uint32 {
+	return atomic.LoadUint32(&s.id)
+}
+
+func (s *Session) write(p *PDU) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	p.SessionID = s.ID()
+	b, err := p.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	if err = s.conn.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+	_, err = s.conn.Write(b)
+	return err
+}
+
+// request Send PDU and wait for response.
+// It is used before Serve() reads PDUs.
+func (s *Session) request(p *PDU) (*PDU, error) {
+	p.PacketID = atomic.AddUint32(&s.packetID, 1)
+	if err := s.write(p); err != nil {
+		return nil, err
+	}
+
+	if err := s.conn.SetReadDeadline(time.Now().Add(s.timeout)); err != nil {
+		return nil, err
+	}
+	for {
+		res, err := ReadPDU(s.conn)
+		if err != nil {
+			return nil, err
+		}
+		if res.Type != PDUResponse || res.PacketID != p.PacketID {
+			continue
+		}
+		if res.Error != NoAgentXError {
+			return nil, fmt.Errorf("%s failed: %v", p.Type, res.Error)
+		}
+		return res, nil
+	}
+}
+
+// Open Open session.
+func (s *Session) Open(id OID, descr string) error {
+	res, err := s.request(&PDU{
+		Header:  Header{Type: PDUOpen},
+		Timeout: uint8(s.timeout / time.Second),
+		ID:      id,
+		Descr:   descr,
+	})
+	if err != nil {
+		return err
+	}
+
+	atomic.StoreUint32(&s.id, res.SessionID)
+	return nil
+}
+
+// Register Register subtree.
+func (s *Session) Register(subtree OID, priority uint8) error {
+	_, err := s.request(&PDU{
+		Header:   Header{Type: PDURegister},
+		Priority: priority,
+		Subtree:  subtree,
+	})
+	return err
+}
+
+// Notify Send notification.
+// Response is discarded by Serve().
+func (s *Session) Notify(varBinds []VarBind) error {
+	return s.write(&PDU{
+		Header:   Header{Type: PDUNotify, PacketID: atomic.AddUint32(&s.packetID, 1)},
+		VarBinds: varBinds,
+	})
+}
+
+// handleRequest Handle request from master agent.
+// It returns nil if no response is sent.
+func handleRequest(p *PDU, h Handler) *PDU {
+	res := &PDU{
+		Header: Header{
+			Type:          PDUResponse,
+			TransactionID: p.TransactionID,
+			PacketID:      p.PacketID,
+		},
+	}
+
+	switch p.Type {
+	case PDUGet:
+		tree := h.Tree()
+		for _, sr := range p.SearchRanges {
+			res.VarBinds = append(res.VarBinds, tree.Get(sr.Start))
+		}
+	case PDUGetNext:
+		tree := h.Tree()
+		for _, sr := range p.SearchRanges {
+			res.VarBinds = append(res.VarBinds, tree.GetNext(sr))
+		}
+	case PDUGetBulk:
+		res.VarBinds = h.Tree().GetBulk(int(p.NonRepeaters),
+			int(p.MaxRepetitions), p.SearchRanges)
+	case PDUTestSet:
+		// read-only.
+		res.Error = NotWritable
+		res.Index = 1
+	case PDUCommitSet, PDUUndoSet:
+		res.Error = GenErr
+	case PDUCleanupSet, PDUResponse:
+		return nil
+	default:
+		res.Error = ProcessingError
+	}
+
+	return res
+}
+
+// Serve Serve requests from master agent until session is closed.
+func (s *Session) Serve(h Handler) error {
+	if err := s.conn.SetReadDeadline(time.Time{}); err != nil {
+		return err
+	}
+
+	for {
+		p, err := ReadPDU(s.conn)
+		if err != nil {
+			return err
+		}
+
+		if p.Type == PDUClose {
+			return fmt.Errorf("closed by master agent(reason %d)", p.Reason)
+		}
+
+		if res := handleRequest(p, h); res != nil {
+			if err = s.write(res); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close Close session.
+func (s *Session) Close(reason CloseReason) error {
+	if s.ID() != 0 {
+		_ = s.write(&PDU{
+			Header: Header{Type: PDUClose, PacketID: atomic.AddUint32(&s.packetID, 1)},
+			Reason: reason,
+		})
+	}
+	return s.conn.Close()
+}
@@ -0,0 +1,90 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agentx
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testSessionTestSuite struct {
+	suite.Suite
+}
+
+type testHandler struct {
+	tree *Tree
+}
+
+func (h *testHandler) Tree() *Tree {
+	return h.tree
+}
+
+func (suite *testSessionTestSuite) TestServe() {
+	client, server := net.Pipe()
+	s := &Session{conn: client, timeout: DefaultTimeout}
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- s.Serve(&testHandler{tree: newTestTree()})
+	}()
+
+	write := func(p *PDU) {
+		b, err := p.MarshalBinary()
+		suite.NoError(err)
+		_, err = server.Write(b)
+		suite.NoError(err)
+	}
+
+	write(&PDU{
+		Header:       Header{Type: PDUGet, TransactionID: 1, PacketID: 2},
+		SearchRanges: []SearchRange{{Start: OID{1, 3, 2, 2}}},
+	})
+	res, err := ReadPDU(server)
+	suite.NoError(err)
+	suite.Equal(PDUResponse, res.Type)
+	suite.Equal(uint32(2), res.PacketID)
+	suite.Equal([]VarBind{{Type: TypeInteger, Name: OID{1, 3, 2, 2}, Value: int32(22)}},
+		res.VarBinds)
+
+	write(&PDU{Header: Header{Type: PDUTestSet, PacketID: 3}})
+	res, err = ReadPDU(server)
+	suite.NoError(err)
+	suite.Equal(NotWritable, res.Error)
+
+	write(&PDU{Header: Header{Type: PDUClose}, Reason: ReasonShutdown})
+	suite.Error(<-errCh)
+}
+
+func (suite *testSessionTestSuite) TestParseAddress() {
+	network, address := ParseAddress("tcp:127.0.0.1:705")
+	suite.Equal("tcp", network)
+	suite.Equal("127.0.0.1:705", address)
+
+	network, address = ParseAddress("unix:/var/agentx/master")
+	suite.Equal("unix", network)
+	suite.Equal("/var/agentx/master", address)
+
+	network, address = ParseAddress(DefaultAddress)
+	suite.Equal("unix", network)
+	suite.Equal(DefaultAddress, address)
+}
+
+func TestSessionTestSuite(t *testing.T) {
+	suite.Run(t, new(testSessionTestSuite))
+}
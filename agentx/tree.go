@@ -0,0 +1,92 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agentx
+
+import (
+	"sort"
+)
+
+// Tree Read-only MIB view, sorted by OID.
+type Tree struct {
+	varBinds []VarBind
+}
+
+// NewTree New Tree instance.
+func NewTree(varBinds []VarBind) *Tree {
+	vbs := make([]VarBind, len(varBinds))
+	copy(vbs, varBinds)
+	sort.Slice(vbs, func(i, j int) bool {
+		return Compare(vbs[i].Name, vbs[j].Name) < 0
+	})
+	return &Tree{varBinds: vbs}
+}
+
+// Get Get variable of name.
+func (t *Tree) Get(name OID) VarBind {
+	i := sort.Search(len(t.varBinds), func(i int) bool {
+		return Compare(t.varBinds[i].Name, name) >= 0
+	})
+	if i < len(t.varBinds) && Compare(t.varBinds[i].Name, name) == 0 {
+		return t.varBinds[i]
+	}
+	return VarBind{Type: TypeNoSuchObject, Name: name}
+}
+
+// GetNext Get first variable in search range.
+func (t *Tree) GetNext(sr SearchRange) VarBind {
+	i := sort.Search(len(t.varBinds), func(i int) bool {
+		c := Compare(t.varBinds[i].Name, sr.Start)
+		return c > 0 || (c == 0 && sr.Include)
+	})
+	if i < len(t.varBinds) &&
+		(len(sr.End) == 0 || Compare(t.varBinds[i].Name, sr.End) < 0) {
+		return t.varBinds[i]
+	}
+	return VarBind{Type: TypeEndOfMibView, Name: sr.Start}
+}
+
+// GetBulk Get variables as RFC 2741 7.2.3.3.
+func (t *Tree) GetBulk(nonRepeaters int, maxRepetitions int, srs []SearchRange) []VarBind {
+	if nonRepeaters > len(srs) {
+		nonRepeaters = len(srs)
+	}
+
+	vbs := []VarBind{}
+	for _, sr := range srs[:nonRepeaters] {
+		vbs = append(vbs, t.GetNext(sr))
+	}
+
+	repeaters := make([]SearchRange, len(srs)-nonRepeaters)
+	copy(repeaters, srs[nonRepeaters:])
+	for r := 0; r < maxRepetitions && len(repeaters) > 0; r++ {
+		end := true
+		for i := range repeaters {
+			vb := t.GetNext(repeaters[i])
+			vbs = append(vbs, vb)
+			if vb.Type != TypeEndOfMibView {
+				repeaters[i].Start = vb.Name
+				repeaters[i].Include = false
+				end = false
+			}
+		}
+		if end {
+			break
+		}
+	}
+
+	return vbs
+}
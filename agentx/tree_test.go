@@ -0,0 +1,82 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package agentx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testTreeTestSuite struct {
+	suite.Suite
+}
+
+func newTestTree() *Tree {
+	return NewTree([]VarBind{
+		{Type: TypeInteger, Name: OID{1, 3, 2, 1}, Value: int32(21)},
+		{Type: TypeInteger, Name: OID{1, 3, 1, 2}, Value: int32(12)},
+		{Type: TypeInteger, Name: OID{1, 3, 1, 1}, Value: int32(11)},
+		{Type: TypeInteger, Name: OID{1, 3, 2, 2}, Value: int32(22)},
+	})
+}
+
+func (suite *testTreeTestSuite) TestGet() {
+	tree := newTestTree()
+	suite.Equal(int32(12), tree.Get(OID{1, 3, 1, 2}).Value)
+	suite.Equal(TypeNoSuchObject, tree.Get(OID{1, 3, 1}).Type)
+	suite.Equal(TypeNoSuchObject, tree.Get(OID{1, 3, 3}).Type)
+}
+
+func (suite *testTreeTestSuite) TestGetNext() {
+	tree := newTestTree()
+	suite.Equal(int32(11), tree.GetNext(SearchRange{Start: OID{1, 3}}).Value)
+	suite.Equal(int32(12), tree.GetNext(SearchRange{Start: OID{1, 3, 1, 1}}).Value)
+	suite.Equal(int32(11),
+		tree.GetNext(SearchRange{Start: OID{1, 3, 1, 1}, Include: true}).Value)
+
+	vb := tree.GetNext(SearchRange{Start: OID{1, 3, 1, 2}, End: OID{1, 3, 2}})
+	suite.Equal(TypeEndOfMibView, vb.Type)
+	suite.Equal(OID{1, 3, 1, 2}, vb.Name)
+
+	suite.Equal(TypeEndOfMibView, tree.GetNext(SearchRange{Start: OID{1, 3, 2, 2}}).Type)
+}
+
+func (suite *testTreeTestSuite) TestGetBulk() {
+	tree := newTestTree()
+	vbs := tree.GetBulk(1, 3, []SearchRange{
+		{Start: OID{1, 3, 2}},
+		{Start: OID{1, 3, 1}},
+		{Start: OID{1, 3, 2, 1}},
+	})
+
+	values := []interface{}{}
+	for _, vb := range vbs {
+		values = append(values, vb.Value)
+	}
+	suite.Equal([]interface{}{
+		int32(21),
+		int32(11), int32(22),
+		int32(12), nil,
+		int32(21), nil,
+	}, values)
+	suite.Equal(TypeEndOfMibView, vbs[4].Type)
+}
+
+func TestTreeTestSuite(t *testing.T) {
+	suite.Run(t, new(testTreeTestSuite))
+}
@@ -19,55 +19,196 @@ package config
 import (
 	"fmt"
 	"net"
+	"sort"
 	"sync"
 
 	"github.com/lagopus/vrrpd/models"
 )
 
+// DefaultControlSocket Default path of control socket.
+const DefaultControlSocket = "/var/run/vrrpd.sock"
+
 // AgentConfig agent config.
 type AgentConfig struct {
-	DsAddr     net.IP
-	DsPort     uint16
-	DpaAddr    net.IP
-	DpaPort    uint16
-	HostifAddr net.IP
-	HostifPort uint16
-	Interfaces map[string]*models.Interface
-	lock       sync.RWMutex
+	DsAddr        net.IP
+	DsPort        uint16
+	DpaAddr       net.IP
+	DpaPort       uint16
+	HostifAddr    net.IP
+	HostifPort    uint16
+	MetricsAddr   net.IP
+	MetricsPort   uint16
+	AgentXAddr    string
+	MgmtAddr      net.IP
+	MgmtPort      uint16
+	CtrlSocket    string
+	Interfaces    map[string]*models.Interface
+	HealthChecks  map[string]*models.HealthCheck
+	NotifyScripts map[string]*models.NotifyScript
+	Webhooks      map[string]*models.Webhook
+	lock          sync.RWMutex
 }
 
 func newAgentConfig() *AgentConfig {
 	return &AgentConfig{
-		DsAddr:     net.ParseIP("127.0.0.1"),
-		DsPort:     2650,
-		DpaAddr:    net.ParseIP("127.0.0.1"),
-		DpaPort:    30010,
-		HostifAddr: net.ParseIP("127.0.0.1"),
-		HostifPort: 30020,
-		Interfaces: map[string]*models.Interface{},
+		DsAddr:        net.ParseIP("127.0.0.1"),
+		DsPort:        2650,
+		DpaAddr:       net.ParseIP("127.0.0.1"),
+		DpaPort:       30010,
+		HostifAddr:    net.ParseIP("127.0.0.1"),
+		HostifPort:    30020,
+		MetricsAddr:   nil,
+		MetricsPort:   0,
+		AgentXAddr:    "",
+		MgmtAddr:      nil,
+		MgmtPort:      0,
+		CtrlSocket:    DefaultControlSocket,
+		Interfaces:    map[string]*models.Interface{},
+		HealthChecks:  map[string]*models.HealthCheck{},
+		NotifyScripts: map[string]*models.NotifyScript{},
+		Webhooks:      map[string]*models.Webhook{},
 	}
 }
 
 // IsValid Reports whether AgentConfig represents a valid value.
 func (agentConfig *AgentConfig) IsValid() bool {
+	return len(agentConfig.Validate()) == 0
+}
+
+// Validate Validate AgentConfig, and returns errors.
+func (agentConfig *AgentConfig) Validate() models.ValidationErrors {
 	agentConfig.lock.RLock()
 	defer agentConfig.lock.RUnlock()
 
-	if agentConfig.DsAddr != nil && agentConfig.DsPort > 0 &&
-		agentConfig.DpaAddr != nil && agentConfig.DpaPort > 0 &&
-		agentConfig.HostifAddr != nil && agentConfig.HostifPort > 0 &&
-		len(agentConfig.Interfaces) >= 0 {
-		for _, iface := range agentConfig.Interfaces {
-			if iface.IsValid() == false {
-				return false
-			}
+	errs := models.ValidationErrors{}
+	required := func(field string, ok bool) {
+		if !ok {
+			errs = append(errs, &models.ValidationError{
+				Field:  field,
+				Reason: "is required",
+			})
 		}
-		return true
 	}
+	required("datastore.addr", agentConfig.DsAddr != nil)
+	required("datastore.port", agentConfig.DsPort > 0)
+	required("dpa.addr", agentConfig.DpaAddr != nil)
+	required("dpa.port", agentConfig.DpaPort > 0)
+	required("hostif.addr", agentConfig.HostifAddr != nil)
+	required("hostif.port", agentConfig.HostifPort > 0)
+	if agentConfig.MetricsPort > 0 {
+		required("metrics.addr", agentConfig.MetricsAddr != nil)
+	}
+	if agentConfig.MgmtPort > 0 {
+		required("management.addr", agentConfig.MgmtAddr != nil)
+	}
+
+	ifnames := []string{}
+	for ifname := range agentConfig.Interfaces {
+		ifnames = append(ifnames, ifname)
+	}
+	sort.Strings(ifnames)
+	for _, ifname := range ifnames {
+		errs = append(errs, agentConfig.Interfaces[ifname].Validate()...)
+		errs = append(errs, agentConfig.validateReferencesNoLock(
+			agentConfig.Interfaces[ifname])...)
+	}
+
+	hcnames := []string{}
+	for hcname := range agentConfig.HealthChecks {
+		hcnames = append(hcnames, hcname)
+	}
+	sort.Strings(hcnames)
+	for _, hcname := range hcnames {
+		errs = append(errs, agentConfig.HealthChecks[hcname].Validate()...)
+	}
+
+	nsnames := []string{}
+	for nsname := range agentConfig.NotifyScripts {
+		nsnames = append(nsnames, nsname)
+	}
+	sort.Strings(nsnames)
+	for _, nsname := range nsnames {
+		errs = append(errs, agentConfig.NotifyScripts[nsname].Validate()...)
+	}
+
+	whnames := []string{}
+	for whname := range agentConfig.Webhooks {
+		whnames = append(whnames, whname)
+	}
+	sort.Strings(whnames)
+	for _, whname := range whnames {
+		errs = append(errs, agentConfig.Webhooks[whname].Validate()...)
+	}
+
+	return errs
+}
 
+// isDataPlaneIfNoLock Report whether name is interface or subinterface
+// of DataPlane.
+func (agentConfig *AgentConfig) isDataPlaneIfNoLock(name string) bool {
+	for ifname, iface := range agentConfig.Interfaces {
+		if ifname == name {
+			return true
+		}
+		if _, ok := iface.Subinterfaces[name]; ok {
+			return true
+		}
+	}
 	return false
 }
 
+// validateReferencesNoLock Validate health checks, notify scripts and
+// tracked interfaces referred by VRRPs of interface.
+func (agentConfig *AgentConfig) validateReferencesNoLock(
+	iface *models.Interface) models.ValidationErrors {
+	errs := models.ValidationErrors{}
+	// ignore tunnel interface
+	if iface.Type == models.IfTypeTunnel {
+		return errs
+	}
+
+	subifnames := []string{}
+	for subifname := range iface.Subinterfaces {
+		subifnames = append(subifnames, subifname)
+	}
+	sort.Strings(subifnames)
+	for _, subifname := range subifnames {
+		subif := iface.Subinterfaces[subifname]
+		for _, af := range models.AddressFamilies {
+			table := subif.VRRPTable(af)
+			vrids := []int{}
+			for vrid := range table {
+				vrids = append(vrids, int(vrid))
+			}
+			sort.Ints(vrids)
+			for _, vrid := range vrids {
+				vrrp := table[uint8(vrid)]
+				vrrpErrs := vrrp.ValidateReferences(
+					agentConfig.HealthChecks, agentConfig.NotifyScripts)
+				// DataPlane interface is DPDK port without kernel netdev.
+				for _, tif := range vrrp.Copy().TrackInterfaces {
+					if agentConfig.isDataPlaneIfNoLock(tif) {
+						vrrpErrs = append(vrrpErrs, &models.ValidationError{
+							Vrid:  uint8(vrid),
+							Field: "track-interface",
+							Reason: fmt.Sprintf("%s is DataPlane interface, and can't be tracked",
+								tif),
+						})
+					}
+				}
+				for _, e := range vrrpErrs {
+					e.Interface = iface.Name
+					e.Subinterface = subifname
+					e.AF = af
+				}
+				errs = append(errs, vrrpErrs...)
+			}
+		}
+	}
+
+	return errs
+}
+
 // Copy Copy Agent config.
 func (agentConfig *AgentConfig) Copy() *AgentConfig {
 	agentConfig.lock.RLock()
@@ -78,14 +219,38 @@ func (agentConfig *AgentConfig) Copy() *AgentConfig {
 		ifaces[iface.Name] = iface.Copy()
 	}
 
+	hcs := map[string]*models.HealthCheck{}
+	for _, hc := range agentConfig.HealthChecks {
+		hcs[hc.Name] = hc.Copy()
+	}
+
+	nss := map[string]*models.NotifyScript{}
+	for _, ns := range agentConfig.NotifyScripts {
+		nss[ns.Name] = ns.Copy()
+	}
+
+	whs := map[string]*models.Webhook{}
+	for _, wh := range agentConfig.Webhooks {
+		whs[wh.Name] = wh.Copy()
+	}
+
 	return &AgentConfig{
-		DsAddr:     agentConfig.DsAddr,
-		DsPort:     agentConfig.DsPort,
-		DpaAddr:    agentConfig.DpaAddr,
-		DpaPort:    agentConfig.DpaPort,
-		HostifAddr: agentConfig.HostifAddr,
-		HostifPort: agentConfig.HostifPort,
-		Interfaces: ifaces,
+		DsAddr:        agentConfig.DsAddr,
+		DsPort:        agentConfig.DsPort,
+		DpaAddr:       agentConfig.DpaAddr,
+		DpaPort:       agentConfig.DpaPort,
+		HostifAddr:    agentConfig.HostifAddr,
+		HostifPort:    agentConfig.HostifPort,
+		MetricsAddr:   agentConfig.MetricsAddr,
+		MetricsPort:   agentConfig.MetricsPort,
+		AgentXAddr:    agentConfig.AgentXAddr,
+		MgmtAddr:      agentConfig.MgmtAddr,
+		MgmtPort:      agentConfig.MgmtPort,
+		CtrlSocket:    agentConfig.CtrlSocket,
+		Interfaces:    ifaces,
+		HealthChecks:  hcs,
+		NotifyScripts: nss,
+		Webhooks:      whs,
 	}
 }
 
@@ -94,12 +259,18 @@ func (agentConfig *AgentConfig) AddInterface(ifname string) {
 	agentConfig.lock.Lock()
 	defer agentConfig.lock.Unlock()
 
-	_, ret := agentConfig.Interfaces[ifname]
+	agentConfig.addInterfaceNoLock(ifname)
+}
+
+// addInterfaceNoLock Add interface if it doesn't exist, and returns it.
+func (agentConfig *AgentConfig) addInterfaceNoLock(ifname string) *models.Interface {
+	iface, ret := agentConfig.Interfaces[ifname]
 	if ret == false {
-		iface := models.NewInterface()
+		iface = models.NewInterface()
 		iface.Name = ifname
 		agentConfig.Interfaces[ifname] = iface
 	}
+	return iface
 }
 
 // DeleteInterface Delete interface.
@@ -146,8 +317,7 @@ func (agentConfig *AgentConfig) AddSubinterface(ifname string, subifname string)
 	if ret {
 		iface.AddSubinterface(subifname)
 	} else {
-		agentConfig.AddInterface(ifname)
-		agentConfig.Interfaces[ifname].AddSubinterface(subifname)
+		agentConfig.addInterfaceNoLock(ifname).AddSubinterface(subifname)
 	}
 }
 
@@ -171,8 +341,7 @@ func (agentConfig *AgentConfig) SetSubifIndex(ifname string, subifname string, i
 	if ret {
 		iface.SetSubifIndex(subifname, index)
 	} else {
-		agentConfig.AddInterface(ifname)
-		agentConfig.Interfaces[ifname].SetSubifIndex(subifname, index)
+		agentConfig.addInterfaceNoLock(ifname).SetSubifIndex(subifname, index)
 	}
 }
 
@@ -196,8 +365,7 @@ func (agentConfig *AgentConfig) SetSubifIP(ifname string, subifname string, ip n
 	if ret {
 		iface.SetSubifIP(subifname, ip)
 	} else {
-		agentConfig.AddInterface(ifname)
-		agentConfig.Interfaces[ifname].SetSubifIP(subifname, ip)
+		agentConfig.addInterfaceNoLock(ifname).SetSubifIP(subifname, ip)
 	}
 }
 
@@ -221,8 +389,7 @@ func (agentConfig *AgentConfig) SetSubifPrefix(ifname string, subifname string,
 	if ret {
 		iface.SetSubifPrefix(subifname, prefix)
 	} else {
-		agentConfig.AddInterface(ifname)
-		agentConfig.Interfaces[ifname].SetSubifPrefix(subifname, prefix)
+		agentConfig.addInterfaceNoLock(ifname).SetSubifPrefix(subifname, prefix)
 	}
 }
 
@@ -237,136 +404,512 @@ func (agentConfig *AgentConfig) DeleteSubifPrefix(ifname string, subifname strin
 	}
 }
 
+// SetSubifIPv6 Set subinterface IPv6.
+func (agentConfig *AgentConfig) SetSubifIPv6(ifname string, subifname string, ip net.IP) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetSubifIPv6(subifname, ip)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).SetSubifIPv6(subifname, ip)
+	}
+}
+
+// DeleteSubifIPv6 Delete subinterface IPv6.
+func (agentConfig *AgentConfig) DeleteSubifIPv6(ifname string, subifname string) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.DeleteSubifIPv6(subifname)
+	}
+}
+
+// SetSubifIPv6Prefix Set subinterface IPv6 prefix.
+func (agentConfig *AgentConfig) SetSubifIPv6Prefix(ifname string, subifname string, prefix uint32) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetSubifIPv6Prefix(subifname, prefix)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).SetSubifIPv6Prefix(subifname, prefix)
+	}
+}
+
+// DeleteSubifIPv6Prefix Delete subinterface IPv6 prefix.
+func (agentConfig *AgentConfig) DeleteSubifIPv6Prefix(ifname string, subifname string) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.DeleteSubifIPv6Prefix(subifname)
+	}
+}
+
 // AddVrrp Add VRRP.
-func (agentConfig *AgentConfig) AddVrrp(ifname string, subifname string, vrid uint8) {
+func (agentConfig *AgentConfig) AddVrrp(ifname string, subifname string, af models.AddressFamily, vrid uint8) {
 	agentConfig.lock.Lock()
 	defer agentConfig.lock.Unlock()
 
 	iface, ret := agentConfig.Interfaces[ifname]
 	if ret {
-		iface.AddVrrp(subifname, vrid)
+		iface.AddVrrp(subifname, af, vrid)
 	} else {
-		agentConfig.AddInterface(ifname)
-		agentConfig.Interfaces[ifname].AddVrrp(subifname, vrid)
+		agentConfig.addInterfaceNoLock(ifname).AddVrrp(subifname, af, vrid)
+	}
+}
+
+// UpdateVrrp Update VRRP by fn, and add interface, subinterface and VRRP
+// if they don't exist.
+func (agentConfig *AgentConfig) UpdateVrrp(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, fn func(vrrp *models.VRRP)) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	agentConfig.addInterfaceNoLock(ifname).UpdateVrrp(subifname, af, vrid, fn)
+}
+
+// UpdateExistingVrrp Update VRRP by fn, only if it exists.
+func (agentConfig *AgentConfig) UpdateExistingVrrp(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, fn func(vrrp *models.VRRP)) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	if iface, ok := agentConfig.Interfaces[ifname]; ok {
+		iface.UpdateExistingVrrp(subifname, af, vrid, fn)
 	}
 }
 
 // DeleteVrrp Delete VRRP.
-func (agentConfig *AgentConfig) DeleteVrrp(ifname string, subifname string, vrid uint8) {
+func (agentConfig *AgentConfig) DeleteVrrp(ifname string, subifname string, af models.AddressFamily, vrid uint8) {
 	agentConfig.lock.Lock()
 	defer agentConfig.lock.Unlock()
 
 	iface, ret := agentConfig.Interfaces[ifname]
 	if ret {
-		iface.DeleteVrrp(subifname, vrid)
+		iface.DeleteVrrp(subifname, af, vrid)
 	}
 }
 
 // SetVrrpPriority Set priority.
 func (agentConfig *AgentConfig) SetVrrpPriority(ifname string, subifname string,
-	vrid uint8, priority uint8) {
+	af models.AddressFamily, vrid uint8, priority uint8) {
 	agentConfig.lock.Lock()
 	defer agentConfig.lock.Unlock()
 
 	iface, ret := agentConfig.Interfaces[ifname]
 	if ret {
-		iface.SetVrrpPriority(subifname, vrid, priority)
+		iface.SetVrrpPriority(subifname, af, vrid, priority)
 	} else {
-		agentConfig.AddInterface(ifname)
-		agentConfig.Interfaces[ifname].SetVrrpPriority(subifname, vrid, priority)
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpPriority(subifname, af, vrid, priority)
 	}
 }
 
 // SetDefaultVrrpPriority SetDefault priority.
 func (agentConfig *AgentConfig) SetDefaultVrrpPriority(ifname string, subifname string,
-	vrid uint8) {
+	af models.AddressFamily, vrid uint8) {
 	agentConfig.lock.Lock()
 	defer agentConfig.lock.Unlock()
 
 	iface, ret := agentConfig.Interfaces[ifname]
 	if ret {
-		iface.SetDefaultVrrpPriority(subifname, vrid)
+		iface.SetDefaultVrrpPriority(subifname, af, vrid)
 	}
 }
 
 // SetVrrpPreempt Set preempt.
 func (agentConfig *AgentConfig) SetVrrpPreempt(ifname string, subifname string,
-	vrid uint8, preempt bool) {
+	af models.AddressFamily, vrid uint8, preempt bool) {
 	agentConfig.lock.Lock()
 	defer agentConfig.lock.Unlock()
 
 	iface, ret := agentConfig.Interfaces[ifname]
 	if ret {
-		iface.SetVrrpPreempt(subifname, vrid, preempt)
+		iface.SetVrrpPreempt(subifname, af, vrid, preempt)
 	} else {
-		agentConfig.AddInterface(ifname)
-		agentConfig.Interfaces[ifname].SetVrrpPreempt(subifname, vrid, preempt)
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpPreempt(subifname, af, vrid, preempt)
 	}
 }
 
 // SetDefaultVrrpPreempt SetDefault preempt.
 func (agentConfig *AgentConfig) SetDefaultVrrpPreempt(ifname string, subifname string,
-	vrid uint8) {
+	af models.AddressFamily, vrid uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetDefaultVrrpPreempt(subifname, af, vrid)
+	}
+}
+
+// SetVrrpPreemptDelay Set preempt delay.
+func (agentConfig *AgentConfig) SetVrrpPreemptDelay(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, delay uint16) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetVrrpPreemptDelay(subifname, af, vrid, delay)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpPreemptDelay(subifname, af, vrid, delay)
+	}
+}
+
+// SetDefaultVrrpPreemptDelay SetDefault preempt delay.
+func (agentConfig *AgentConfig) SetDefaultVrrpPreemptDelay(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetDefaultVrrpPreemptDelay(subifname, af, vrid)
+	}
+}
+
+// SetVrrpPriorityDecrement Set priority decrement.
+func (agentConfig *AgentConfig) SetVrrpPriorityDecrement(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, decrement uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetVrrpPriorityDecrement(subifname, af, vrid, decrement)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpPriorityDecrement(subifname, af, vrid, decrement)
+	}
+}
+
+// SetDefaultVrrpPriorityDecrement SetDefault priority decrement.
+func (agentConfig *AgentConfig) SetDefaultVrrpPriorityDecrement(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetDefaultVrrpPriorityDecrement(subifname, af, vrid)
+	}
+}
+
+// AddVrrpTrackInterface Add VRRP tracked interface.
+func (agentConfig *AgentConfig) AddVrrpTrackInterface(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, trackIfname string) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.AddVrrpTrackInterface(subifname, af, vrid, trackIfname)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).AddVrrpTrackInterface(subifname, af, vrid, trackIfname)
+	}
+}
+
+// DeleteVrrpTrackInterface Delete VRRP tracked interface.
+func (agentConfig *AgentConfig) DeleteVrrpTrackInterface(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, trackIfname string) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.DeleteVrrpTrackInterface(subifname, af, vrid, trackIfname)
+	}
+}
+
+// AddVrrpHealthCheck Add VRRP health check.
+func (agentConfig *AgentConfig) AddVrrpHealthCheck(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, hcname string) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.AddVrrpHealthCheck(subifname, af, vrid, hcname)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).AddVrrpHealthCheck(subifname, af, vrid, hcname)
+	}
+}
+
+// DeleteVrrpHealthCheck Delete VRRP health check.
+func (agentConfig *AgentConfig) DeleteVrrpHealthCheck(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, hcname string) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.DeleteVrrpHealthCheck(subifname, af, vrid, hcname)
+	}
+}
+
+// SetVrrpReachabilityDecrement Set priority decrement of reachability.
+func (agentConfig *AgentConfig) SetVrrpReachabilityDecrement(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, decrement uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetVrrpReachabilityDecrement(subifname, af, vrid, decrement)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpReachabilityDecrement(subifname, af, vrid, decrement)
+	}
+}
+
+// SetDefaultVrrpReachabilityDecrement SetDefault priority decrement of reachability.
+func (agentConfig *AgentConfig) SetDefaultVrrpReachabilityDecrement(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetDefaultVrrpReachabilityDecrement(subifname, af, vrid)
+	}
+}
+
+// AddVrrpReachabilityTarget Add VRRP ReachabilityTarget.
+func (agentConfig *AgentConfig) AddVrrpReachabilityTarget(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, addr net.IP) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.AddVrrpReachabilityTarget(subifname, af, vrid, addr)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).AddVrrpReachabilityTarget(subifname, af, vrid, addr)
+	}
+}
+
+// DeleteVrrpReachabilityTarget Delete VRRP ReachabilityTarget.
+func (agentConfig *AgentConfig) DeleteVrrpReachabilityTarget(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, addr net.IP) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.DeleteVrrpReachabilityTarget(subifname, af, vrid, addr)
+	}
+}
+
+// SetVrrpSyncGroup Set sync group.
+func (agentConfig *AgentConfig) SetVrrpSyncGroup(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, group string) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetVrrpSyncGroup(subifname, af, vrid, group)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpSyncGroup(subifname, af, vrid, group)
+	}
+}
+
+// DeleteVrrpSyncGroup Delete sync group.
+func (agentConfig *AgentConfig) DeleteVrrpSyncGroup(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.DeleteVrrpSyncGroup(subifname, af, vrid)
+	}
+}
+
+// SetVrrpNotifyScript Set notify script.
+func (agentConfig *AgentConfig) SetVrrpNotifyScript(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, name string) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetVrrpNotifyScript(subifname, af, vrid, name)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpNotifyScript(subifname, af, vrid, name)
+	}
+}
+
+// DeleteVrrpNotifyScript Delete notify script.
+func (agentConfig *AgentConfig) DeleteVrrpNotifyScript(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.DeleteVrrpNotifyScript(subifname, af, vrid)
+	}
+}
+
+// SetVrrpAccept Set accept.
+func (agentConfig *AgentConfig) SetVrrpAccept(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, accept bool) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetVrrpAccept(subifname, af, vrid, accept)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpAccept(subifname, af, vrid, accept)
+	}
+}
+
+// SetDefaultVrrpAccept SetDefault accept.
+func (agentConfig *AgentConfig) SetDefaultVrrpAccept(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetDefaultVrrpAccept(subifname, af, vrid)
+	}
+}
+
+// SetVrrpAllowOutOfPrefix Set allow out of prefix.
+func (agentConfig *AgentConfig) SetVrrpAllowOutOfPrefix(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, allowOutOfPrefix bool) {
 	agentConfig.lock.Lock()
 	defer agentConfig.lock.Unlock()
 
 	iface, ret := agentConfig.Interfaces[ifname]
 	if ret {
-		iface.SetDefaultVrrpPreempt(subifname, vrid)
+		iface.SetVrrpAllowOutOfPrefix(subifname, af, vrid, allowOutOfPrefix)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpAllowOutOfPrefix(subifname, af, vrid, allowOutOfPrefix)
+	}
+}
+
+// SetDefaultVrrpAllowOutOfPrefix SetDefault allow out of prefix.
+func (agentConfig *AgentConfig) SetDefaultVrrpAllowOutOfPrefix(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetDefaultVrrpAllowOutOfPrefix(subifname, af, vrid)
 	}
 }
 
 // SetVrrpInterval Set interval.
 func (agentConfig *AgentConfig) SetVrrpInterval(ifname string, subifname string,
-	vrid uint8, interval uint16) {
+	af models.AddressFamily, vrid uint8, interval uint16) {
 	agentConfig.lock.Lock()
 	defer agentConfig.lock.Unlock()
 
 	iface, ret := agentConfig.Interfaces[ifname]
 	if ret {
-		iface.SetVrrpInterval(subifname, vrid, interval)
+		iface.SetVrrpInterval(subifname, af, vrid, interval)
 	} else {
-		agentConfig.AddInterface(ifname)
-		agentConfig.Interfaces[ifname].SetVrrpInterval(subifname, vrid, interval)
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpInterval(subifname, af, vrid, interval)
 	}
 }
 
 // SetDefaultVrrpInterval SetDefault interval.
 func (agentConfig *AgentConfig) SetDefaultVrrpInterval(ifname string, subifname string,
-	vrid uint8) {
+	af models.AddressFamily, vrid uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetDefaultVrrpInterval(subifname, af, vrid)
+	}
+}
+
+// SetVrrpVersion Set version.
+func (agentConfig *AgentConfig) SetVrrpVersion(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, version string) {
 	agentConfig.lock.Lock()
 	defer agentConfig.lock.Unlock()
 
 	iface, ret := agentConfig.Interfaces[ifname]
 	if ret {
-		iface.SetDefaultVrrpInterval(subifname, vrid)
+		iface.SetVrrpVersion(subifname, af, vrid, version)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpVersion(subifname, af, vrid, version)
+	}
+}
+
+// SetDefaultVrrpVersion SetDefault version.
+func (agentConfig *AgentConfig) SetDefaultVrrpVersion(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.SetDefaultVrrpVersion(subifname, af, vrid)
 	}
 }
 
 // AddVrrpVirtualAddress Add VRRP VirtualAddress.
 func (agentConfig *AgentConfig) AddVrrpVirtualAddress(ifname string, subifname string,
-	vrid uint8, addr net.IP) {
+	af models.AddressFamily, vrid uint8, addr net.IP) {
 	agentConfig.lock.Lock()
 	defer agentConfig.lock.Unlock()
 
 	iface, ret := agentConfig.Interfaces[ifname]
 	if ret {
-		iface.AddVrrpVirtualAddress(subifname, vrid, addr)
+		iface.AddVrrpVirtualAddress(subifname, af, vrid, addr)
 	} else {
-		agentConfig.AddInterface(ifname)
-		agentConfig.Interfaces[ifname].AddVrrpVirtualAddress(subifname, vrid, addr)
+		agentConfig.addInterfaceNoLock(ifname).AddVrrpVirtualAddress(subifname, af, vrid, addr)
 	}
 }
 
 // DeleteVrrpVirtualAddress Delete VRRP VirtualAddress.
 func (agentConfig *AgentConfig) DeleteVrrpVirtualAddress(ifname string, subifname string,
+	af models.AddressFamily, vrid uint8, addr net.IP) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.DeleteVrrpVirtualAddress(subifname, af, vrid, addr)
+	}
+}
+
+// SetVrrpVirtualLinkLocal Set VRRP virtual link-local address(IPv6).
+func (agentConfig *AgentConfig) SetVrrpVirtualLinkLocal(ifname string, subifname string,
 	vrid uint8, addr net.IP) {
 	agentConfig.lock.Lock()
 	defer agentConfig.lock.Unlock()
 
 	iface, ret := agentConfig.Interfaces[ifname]
 	if ret {
-		iface.DeleteVrrpVirtualAddress(subifname, vrid, addr)
+		iface.SetVrrpVirtualLinkLocal(subifname, vrid, addr)
+	} else {
+		agentConfig.addInterfaceNoLock(ifname).SetVrrpVirtualLinkLocal(subifname, vrid, addr)
+	}
+}
+
+// DeleteVrrpVirtualLinkLocal Delete VRRP virtual link-local address(IPv6).
+func (agentConfig *AgentConfig) DeleteVrrpVirtualLinkLocal(ifname string, subifname string,
+	vrid uint8) {
+	agentConfig.lock.Lock()
+	defer agentConfig.lock.Unlock()
+
+	iface, ret := agentConfig.Interfaces[ifname]
+	if ret {
+		iface.DeleteVrrpVirtualLinkLocal(subifname, vrid)
 	}
 }
 
@@ -382,9 +925,32 @@ func (agentConfig *AgentConfig) String() string {
 	str = fmt.Sprintf("%s, DpaPort: %d", str, agentConfig.DpaPort)
 	str = fmt.Sprintf("%s, HostifAddr: %s", str, agentConfig.HostifAddr.String())
 	str = fmt.Sprintf("%s, HostifPort: %d", str, agentConfig.HostifPort)
+	if agentConfig.MetricsPort > 0 {
+		str = fmt.Sprintf("%s, MetricsAddr: %s", str, agentConfig.MetricsAddr.String())
+		str = fmt.Sprintf("%s, MetricsPort: %d", str, agentConfig.MetricsPort)
+	}
+	if agentConfig.AgentXAddr != "" {
+		str = fmt.Sprintf("%s, AgentXAddr: %s", str, agentConfig.AgentXAddr)
+	}
+	if agentConfig.MgmtPort > 0 {
+		str = fmt.Sprintf("%s, MgmtAddr: %s", str, agentConfig.MgmtAddr.String())
+		str = fmt.Sprintf("%s, MgmtPort: %d", str, agentConfig.MgmtPort)
+	}
+	if agentConfig.CtrlSocket != "" {
+		str = fmt.Sprintf("%s, CtrlSocket: %s", str, agentConfig.CtrlSocket)
+	}
 	for _, iface := range agentConfig.Interfaces {
 		str = fmt.Sprintf("%s, Instances(%s): {%s}", str, iface.Name, iface.String())
 	}
+	for _, hc := range agentConfig.HealthChecks {
+		str = fmt.Sprintf("%s, HealthChecks(%s): {%s}", str, hc.Name, hc.String())
+	}
+	for _, ns := range agentConfig.NotifyScripts {
+		str = fmt.Sprintf("%s, NotifyScripts(%s): {%s}", str, ns.Name, ns.String())
+	}
+	for _, wh := range agentConfig.Webhooks {
+		str = fmt.Sprintf("%s, Webhooks(%s): {%s}", str, wh.Name, wh.String())
+	}
 
 	return str
 }
@@ -32,10 +32,11 @@ type testAgentConfigTestSuite struct {
 func createInterface1(suite *testAgentConfigTestSuite) *models.Interface {
 	vrrp1 := models.NewVRRP()
 	vrrp1.Vrid = 1
-	vrrp1.Priority = 255
+	vrrp1.Priority = 254
 	vrrp1.Interval = 100
 	vrrp1.Preempt = true
 	vrrp1.VirtualAddresses = []net.IP{net.ParseIP("192.168.0.1"), net.ParseIP("10.0.0.1")}
+	vrrp1.AllowOutOfPrefix = true
 
 	vrrp2 := models.NewVRRP()
 	vrrp2.Vrid = 2
@@ -43,6 +44,7 @@ func createInterface1(suite *testAgentConfigTestSuite) *models.Interface {
 	vrrp2.Interval = 500
 	vrrp2.Preempt = false
 	vrrp2.VirtualAddresses = []net.IP{net.ParseIP("10.0.0.10"), net.ParseIP("192.168.0.10")}
+	vrrp2.AllowOutOfPrefix = true
 
 	subiface := models.NewSubinterface()
 	subiface.Name = "subinterface01"
@@ -72,13 +74,15 @@ func createInterface2(suite *testAgentConfigTestSuite) *models.Interface {
 	vrrp1.Interval = 1000
 	vrrp1.Preempt = true
 	vrrp1.VirtualAddresses = []net.IP{net.ParseIP("192.168.0.1"), net.ParseIP("10.0.0.1")}
+	vrrp1.AllowOutOfPrefix = true
 
 	vrrp2 := models.NewVRRP()
 	vrrp2.Vrid = 200
-	vrrp2.Priority = 255
-	vrrp2.Interval = 5000
+	vrrp2.Priority = 254
+	vrrp2.Interval = 4000
 	vrrp2.Preempt = false
 	vrrp2.VirtualAddresses = []net.IP{net.ParseIP("10.0.0.10"), net.ParseIP("192.168.0.10")}
+	vrrp2.AllowOutOfPrefix = true
 
 	subiface := models.NewSubinterface()
 	subiface.Name = "subinterface02"
@@ -140,12 +144,71 @@ func (suite *testAgentConfigTestSuite) TestAgentConfigIsValid() {
 	suite.True(agentConfig.IsValid())
 }
 
+func (suite *testAgentConfigTestSuite) TestAgentConfigValidateReferences() {
+	agentConfig := newAgentConfig()
+	iface1 := createInterface1(suite)
+	agentConfig.Interfaces[iface1.Name] = iface1
+	agentConfig.AddVrrpHealthCheck(iface1.Name, "subinterface01",
+		models.AddressFamilyIPv4, 1, "check1")
+	agentConfig.SetVrrpNotifyScript(iface1.Name, "subinterface01",
+		models.AddressFamilyIPv4, 1, "script1")
+
+	errs := agentConfig.Validate()
+	suite.Equal(2, len(errs))
+	suite.Equal("interface iface01 subinterface subinterface01 ipv4 vrrp 1 health-check",
+		errs[0].Path())
+	suite.Equal("interface iface01 subinterface subinterface01 ipv4 vrrp 1 notify-script",
+		errs[1].Path())
+
+	hc := models.NewHealthCheck()
+	hc.Name = "check1"
+	hc.Command = "/bin/true"
+	agentConfig.HealthChecks[hc.Name] = hc
+	ns := models.NewNotifyScript()
+	ns.Name = "script1"
+	ns.Master = "/bin/true"
+	agentConfig.NotifyScripts[ns.Name] = ns
+	suite.Empty(agentConfig.Validate())
+}
+
+func (suite *testAgentConfigTestSuite) TestAgentConfigValidateTrackInterface() {
+	agentConfig := newAgentConfig()
+	iface1 := createInterface1(suite)
+	iface2 := createInterface2(suite)
+	agentConfig.Interfaces[iface1.Name] = iface1
+	agentConfig.Interfaces[iface2.Name] = iface2
+	agentConfig.AddVrrpTrackInterface(iface1.Name, "subinterface01",
+		models.AddressFamilyIPv4, 1, "lo")
+	suite.Empty(agentConfig.Validate())
+
+	// DataPlane interface.
+	agentConfig.AddVrrpTrackInterface(iface1.Name, "subinterface01",
+		models.AddressFamilyIPv4, 1, iface2.Name)
+	errs := agentConfig.Validate()
+	suite.Equal(2, len(errs))
+	for _, e := range errs {
+		suite.Equal("interface iface01 subinterface subinterface01 ipv4 vrrp 1 track-interface",
+			e.Path())
+	}
+}
+
 func (suite *testAgentConfigTestSuite) TestAgentConfigCopy() {
 	src := createInterface1(suite)
 	dst := src.Copy()
 	suite.True(reflect.DeepEqual(src, dst))
 }
 
+func (suite *testAgentConfigTestSuite) TestAgentConfigSetNotExist() {
+	agentConfig := newAgentConfig()
+	agentConfig.SetSubifIP("iface01", "iface01-0", net.ParseIP("172.16.0.1"))
+	agentConfig.SetVrrpAccept("iface02", "iface02-0", models.AddressFamilyIPv4, 1, true)
+
+	suite.Equal("iface01", agentConfig.Interfaces["iface01"].Name)
+	suite.Equal(net.ParseIP("172.16.0.1"),
+		agentConfig.Interfaces["iface01"].Subinterfaces["iface01-0"].IP)
+	suite.True(agentConfig.Interfaces["iface02"].Subinterfaces["iface02-0"].VRRPs[1].Accept)
+}
+
 func TestAgentConfigTestSuite(t *testing.T) {
 	suite.Run(t, new(testAgentConfigTestSuite))
 }
@@ -0,0 +1,264 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/lagopus/vrrpd/models"
+	"github.com/spf13/viper"
+)
+
+// vrrp group in config file(YAML).
+// Unset values are defaults of models.VRRP.
+type vrrpGroupConfig struct {
+	Vrid                  uint8    `mapstructure:"virtual-router-id"`
+	VirtualAddresses      []string `mapstructure:"virtual-address"`
+	VirtualLinkLocal      string   `mapstructure:"virtual-link-local"`
+	Priority              *uint8   `mapstructure:"priority"`
+	Preempt               *bool    `mapstructure:"preempt"`
+	PreemptDelay          *uint16  `mapstructure:"preempt-delay"`
+	AcceptMode            *bool    `mapstructure:"accept-mode"`
+	AdvertisementInterval *uint16  `mapstructure:"advertisement-interval"`
+	Version               string   `mapstructure:"version"`
+	TrackInterfaces       []string `mapstructure:"track-interface"`
+	PriorityDecrement     *uint8   `mapstructure:"priority-decrement"`
+	HealthChecks          []string `mapstructure:"health-check"`
+	ReachabilityTargets   []string `mapstructure:"reachability-target"`
+	ReachabilityGateway   string   `mapstructure:"reachability-gateway"`
+	ReachabilityDecrement *uint8   `mapstructure:"reachability-decrement"`
+	SyncGroup             string   `mapstructure:"sync-group"`
+	NotifyScript          string   `mapstructure:"notify-script"`
+	AllowOutOfPrefix      *bool    `mapstructure:"allow-out-of-prefix"`
+}
+
+// address of subinterface in config file(YAML).
+type subifAddressConfig struct {
+	Address      string            `mapstructure:"address"`
+	PrefixLength uint32            `mapstructure:"prefix-length"`
+	Vrrp         []vrrpGroupConfig `mapstructure:"vrrp"`
+}
+
+// subinterface in config file(YAML).
+type subinterfaceConfig struct {
+	Index uint64              `mapstructure:"index"`
+	IPv4  *subifAddressConfig `mapstructure:"ipv4"`
+	IPv6  *subifAddressConfig `mapstructure:"ipv6"`
+}
+
+// interface in config file(YAML).
+type interfaceConfig struct {
+	Name          string               `mapstructure:"name"`
+	Type          string               `mapstructure:"type"`
+	Subinterfaces []subinterfaceConfig `mapstructure:"subinterfaces"`
+}
+
+func parseIPs(field string, strs []string) ([]net.IP, error) {
+	ips := []net.IP{}
+	for _, str := range strs {
+		ip := net.ParseIP(str)
+		if ip == nil {
+			return nil, fmt.Errorf("%s is invalid: %s", field, str)
+		}
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+// setVrrpGroup Set vrrp group to agent config.
+func setVrrpGroup(agentConfig *AgentConfig, ifname string, subifname string,
+	af models.AddressFamily, conf *vrrpGroupConfig) error {
+	vrid := conf.Vrid
+	field := fmt.Sprintf("interfaces %s vrrp %d", subifname, vrid)
+
+	vaddrs, err := parseIPs(field+" virtual-address", conf.VirtualAddresses)
+	if err != nil {
+		return err
+	}
+	targets, err := parseIPs(field+" reachability-target", conf.ReachabilityTargets)
+	if err != nil {
+		return err
+	}
+
+	agentConfig.AddVrrp(ifname, subifname, af, vrid)
+	for _, vaddr := range vaddrs {
+		if models.ToAddressFamily(vaddr) != af {
+			return fmt.Errorf("%s virtual-address is not %v: %v", field, af, vaddr)
+		}
+		agentConfig.AddVrrpVirtualAddress(ifname, subifname, af, vrid, vaddr)
+	}
+	if conf.VirtualLinkLocal != "" {
+		addr := net.ParseIP(conf.VirtualLinkLocal)
+		if af != models.AddressFamilyIPv6 || addr == nil || !addr.IsLinkLocalUnicast() {
+			return fmt.Errorf("%s virtual-link-local is invalid: %s",
+				field, conf.VirtualLinkLocal)
+		}
+		agentConfig.SetVrrpVirtualLinkLocal(ifname, subifname, vrid, addr)
+	}
+	if conf.Priority != nil {
+		agentConfig.SetVrrpPriority(ifname, subifname, af, vrid, *conf.Priority)
+	}
+	if conf.Preempt != nil {
+		agentConfig.SetVrrpPreempt(ifname, subifname, af, vrid, *conf.Preempt)
+	}
+	if conf.PreemptDelay != nil {
+		agentConfig.SetVrrpPreemptDelay(ifname, subifname, af, vrid, *conf.PreemptDelay)
+	}
+	if conf.AcceptMode != nil {
+		agentConfig.SetVrrpAccept(ifname, subifname, af, vrid, *conf.AcceptMode)
+	}
+	if conf.AdvertisementInterval != nil {
+		agentConfig.SetVrrpInterval(ifname, subifname, af, vrid, *conf.AdvertisementInterval)
+	}
+	if conf.Version != "" {
+		agentConfig.SetVrrpVersion(ifname, subifname, af, vrid, conf.Version)
+	}
+	for _, trackIfname := range conf.TrackInterfaces {
+		agentConfig.AddVrrpTrackInterface(ifname, subifname, af, vrid, trackIfname)
+	}
+	if conf.PriorityDecrement != nil {
+		agentConfig.SetVrrpPriorityDecrement(ifname, subifname, af, vrid, *conf.PriorityDecrement)
+	}
+	for _, hcname := range conf.HealthChecks {
+		agentConfig.AddVrrpHealthCheck(ifname, subifname, af, vrid, hcname)
+	}
+	for _, target := range targets {
+		agentConfig.AddVrrpReachabilityTarget(ifname, subifname, af, vrid, target)
+	}
+	if conf.ReachabilityGateway != "" {
+		gateway := net.ParseIP(conf.ReachabilityGateway)
+		if gateway == nil || models.ToAddressFamily(gateway) != af {
+			return fmt.Errorf("%s reachability-gateway is invalid: %s",
+				field, conf.ReachabilityGateway)
+		}
+		agentConfig.UpdateVrrp(ifname, subifname, af, vrid, func(vrrp *models.VRRP) {
+			vrrp.SetReachabilityGateway(gateway)
+		})
+	}
+	if conf.ReachabilityDecrement != nil {
+		agentConfig.SetVrrpReachabilityDecrement(ifname, subifname, af, vrid,
+			*conf.ReachabilityDecrement)
+	}
+	if conf.SyncGroup != "" {
+		agentConfig.SetVrrpSyncGroup(ifname, subifname, af, vrid, conf.SyncGroup)
+	}
+	if conf.NotifyScript != "" {
+		agentConfig.SetVrrpNotifyScript(ifname, subifname, af, vrid, conf.NotifyScript)
+	}
+	if conf.AllowOutOfPrefix != nil {
+		agentConfig.SetVrrpAllowOutOfPrefix(ifname, subifname, af, vrid, *conf.AllowOutOfPrefix)
+	}
+
+	return nil
+}
+
+// setSubifAddressConfig Set address and vrrp groups of subinterface
+// to agent config.
+func setSubifAddressConfig(agentConfig *AgentConfig, ifname string, subifname string,
+	af models.AddressFamily, conf *subifAddressConfig) error {
+	field := fmt.Sprintf("interfaces %s %v", subifname, af)
+
+	addr := net.ParseIP(conf.Address)
+	if addr == nil || models.ToAddressFamily(addr) != af {
+		return fmt.Errorf("%s address is invalid: %s", field, conf.Address)
+	}
+	if af == models.AddressFamilyIPv6 {
+		agentConfig.SetSubifIPv6(ifname, subifname, addr)
+		agentConfig.SetSubifIPv6Prefix(ifname, subifname, conf.PrefixLength)
+	} else {
+		agentConfig.SetSubifIP(ifname, subifname, addr)
+		agentConfig.SetSubifPrefix(ifname, subifname, conf.PrefixLength)
+	}
+
+	vrids := map[uint8]bool{}
+	for i := range conf.Vrrp {
+		vrid := conf.Vrrp[i].Vrid
+		if vrids[vrid] {
+			return fmt.Errorf("%s vrrp is duplicated: %d", field, vrid)
+		}
+		vrids[vrid] = true
+
+		if err := setVrrpGroup(agentConfig, ifname, subifname, af, &conf.Vrrp[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setInterfaceConfigs Set interfaces to agent config, and validate them.
+func setInterfaceConfigs(agentConfig *AgentConfig, confs []interfaceConfig) error {
+	for _, iconf := range confs {
+		if iconf.Name == "" {
+			return fmt.Errorf("interfaces name is required")
+		}
+		if _, ok := agentConfig.Interfaces[iconf.Name]; ok {
+			return fmt.Errorf("interfaces is duplicated: %s", iconf.Name)
+		}
+
+		agentConfig.AddInterface(iconf.Name)
+		if iconf.Type != "" {
+			agentConfig.SetInterfaceType(iconf.Name, iconf.Type)
+		}
+
+		for _, sconf := range iconf.Subinterfaces {
+			subifname := createSubifname(iconf.Name, sconf.Index)
+			if _, ok := agentConfig.Interfaces[iconf.Name].Subinterfaces[subifname]; ok {
+				return fmt.Errorf("interfaces %s subinterfaces is duplicated: %d",
+					iconf.Name, sconf.Index)
+			}
+
+			agentConfig.AddSubinterface(iconf.Name, subifname)
+			agentConfig.SetSubifIndex(iconf.Name, subifname, sconf.Index)
+			if sconf.IPv4 != nil {
+				if err := setSubifAddressConfig(agentConfig, iconf.Name, subifname,
+					models.AddressFamilyIPv4, sconf.IPv4); err != nil {
+					return err
+				}
+			}
+			if sconf.IPv6 != nil {
+				if err := setSubifAddressConfig(agentConfig, iconf.Name, subifname,
+					models.AddressFamilyIPv6, sconf.IPv6); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, iface := range agentConfig.Interfaces {
+		if errs := iface.Validate(); len(errs) > 0 {
+			return errs
+		}
+	}
+
+	return nil
+}
+
+// readInterfaces Read interfaces in config file(YAML) to agent config.
+func readInterfaces(agentConfig *AgentConfig) error {
+	if !viper.IsSet("interfaces") {
+		return nil
+	}
+
+	confs := []interfaceConfig{}
+	if err := viper.UnmarshalKey("interfaces", &confs); err != nil {
+		return err
+	}
+
+	return setInterfaceConfigs(agentConfig, confs)
+}
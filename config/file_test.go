@@ -0,0 +1,179 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/lagopus/vrrpd/models"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+)
+
+type testFileTestSuite struct {
+	suite.Suite
+}
+
+func (suite *testFileTestSuite) readInterfaces(yml string) (*AgentConfig, error) {
+	viper.Reset()
+	viper.SetConfigType("yaml")
+	suite.Empty(viper.ReadConfig(bytes.NewBufferString(yml)))
+
+	agentConfig := newAgentConfig()
+	err := readInterfaces(agentConfig)
+	return agentConfig, err
+}
+
+func (suite *testFileTestSuite) TearDownTest() {
+	viper.Reset()
+}
+
+func (suite *testFileTestSuite) TestReadInterfaces() {
+	agentConfig, err := suite.readInterfaces(`
+interfaces:
+  - name: if0
+    type: ethernetCsmacd
+    subinterfaces:
+      - index: 1
+        ipv4:
+          address: 192.168.0.1
+          prefix-length: 24
+          vrrp:
+            - virtual-router-id: 10
+              virtual-address: [192.168.0.254]
+              priority: 200
+              preempt: false
+              advertisement-interval: 200
+              version: 3
+              track-interface: [lo]
+              priority-decrement: 20
+              reachability-target: [192.168.0.100, 10.0.0.1]
+              reachability-gateway: 192.168.0.100
+              sync-group: group1
+              notify-script: script1
+        ipv6:
+          address: 2001:db8::1
+          prefix-length: 64
+          vrrp:
+            - virtual-router-id: 10
+              virtual-link-local: fe80::1
+              virtual-address: [2001:db8::254]
+`)
+	suite.Empty(err)
+
+	iface := agentConfig.Interfaces["if0"]
+	suite.NotNil(iface)
+	suite.Equal(models.IfTypeEthernetCsmacd, iface.Type)
+
+	subif := iface.Subinterfaces["if0-1"]
+	suite.NotNil(subif)
+	suite.Equal(net.ParseIP("192.168.0.1").To4(), subif.IP.To4())
+	suite.Equal(uint32(24), subif.Prefix)
+	suite.Equal(uint32(64), subif.IPv6Prefix)
+
+	vrrp := subif.VRRPs[10]
+	suite.NotNil(vrrp)
+	suite.Equal(uint8(200), vrrp.Priority)
+	suite.False(vrrp.Preempt)
+	suite.Equal(uint16(200), vrrp.Interval)
+	suite.Equal(models.VRRPVersion3, vrrp.Version)
+	suite.Equal([]string{"lo"}, vrrp.TrackInterfaces)
+	suite.Equal(uint8(20), vrrp.PriorityDecrement)
+	suite.Equal(2, len(vrrp.ReachabilityTargets))
+	suite.True(net.ParseIP("192.168.0.100").Equal(vrrp.ReachabilityGateway))
+	suite.Equal("group1", vrrp.SyncGroup)
+	suite.Equal("script1", vrrp.NotifyScript)
+	suite.Equal(1, len(vrrp.VirtualAddresses))
+	// unset values are defaults.
+	suite.Equal(models.DefaultAccept, vrrp.Accept)
+
+	vrrp6 := subif.IPv6VRRPs[10]
+	suite.NotNil(vrrp6)
+	suite.Equal(uint8(models.DefaultPriority), vrrp6.Priority)
+	suite.True(net.ParseIP("fe80::1").Equal(vrrp6.VirtualLinkLocal))
+}
+
+func (suite *testFileTestSuite) TestReadInterfacesNotSet() {
+	agentConfig, err := suite.readInterfaces(`
+datastore:
+  addr: 127.0.0.1
+`)
+	suite.Empty(err)
+	suite.Empty(agentConfig.Interfaces)
+}
+
+func (suite *testFileTestSuite) TestReadInterfacesInvalid() {
+	// address family mismatch.
+	_, err := suite.readInterfaces(`
+interfaces:
+  - name: if0
+    subinterfaces:
+      - index: 0
+        ipv4:
+          address: 2001:db8::1
+          prefix-length: 24
+`)
+	suite.NotEmpty(err)
+
+	// duplicated VRID.
+	_, err = suite.readInterfaces(`
+interfaces:
+  - name: if0
+    subinterfaces:
+      - index: 0
+        ipv4:
+          address: 192.168.0.1
+          prefix-length: 24
+          vrrp:
+            - virtual-router-id: 1
+              virtual-address: [192.168.0.254]
+            - virtual-router-id: 1
+              virtual-address: [192.168.0.253]
+`)
+	suite.NotEmpty(err)
+
+	// no virtual address.
+	_, err = suite.readInterfaces(`
+interfaces:
+  - name: if0
+    subinterfaces:
+      - index: 0
+        ipv4:
+          address: 192.168.0.1
+          prefix-length: 24
+          vrrp:
+            - virtual-router-id: 1
+`)
+	suite.NotEmpty(err)
+}
+
+func (suite *testFileTestSuite) TestParseSource() {
+	for _, s := range []Source{SourceOpenconfigd, SourceFile, SourceMerged} {
+		source, err := ParseSource(s.String())
+		suite.Empty(err)
+		suite.Equal(s, source)
+	}
+
+	_, err := ParseSource("unknown")
+	suite.NotEmpty(err)
+}
+
+func TestFileTestSuite(t *testing.T) {
+	suite.Run(t, new(testFileTestSuite))
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/coreswitch/cmd"
 	ocd "github.com/coreswitch/openconfigd/proto"
+	"github.com/lagopus/vrrpd/models"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -38,12 +39,15 @@ type Handler struct {
 var handler = newHandler()
 
 func (h *Handler) doValidate() error {
-	if cmgr.GetModifiedConfig().IsValid() {
-		cmgr.Rollback()
-		return nil
+	if errs := cmgr.Validate(); len(errs) > 0 {
+		for _, e := range errs {
+			log.Errorf("validation error: %v", e)
+		}
+		return errs
 	}
 
-	return errors.New("validation failed")
+	cmgr.Rollback()
+	return nil
 }
 
 func (h *Handler) doCommit() error {
@@ -59,6 +63,7 @@ func (h *Handler) doSet(path []string) error {
 	ret, fn, args, _ := h.parser.ParseCmd(path)
 	if ret == cmd.ParseSuccess {
 		fn.(func(int, cmd.Args) int)(cmd.Set, args)
+		cmgr.setByOpenconfigd(path)
 		return nil
 	}
 
@@ -117,6 +122,7 @@ func (h *Handler) NextState(t ocd.ConfigType, path []string) error {
 	case StateInitialize:
 		switch t {
 		case ocd.ConfigType_VALIDATE_START:
+			cmgr.resetInvalids()
 			h.state = StateValidation
 			return nil
 		case ocd.ConfigType_COMMIT_START:
@@ -167,7 +173,8 @@ func (h *Handler) NextState(t ocd.ConfigType, path []string) error {
 			}
 
 			log.Info("commit success")
-			h.state = StateInitialize
+			// reload of config file is refused until it is applied.
+			h.state = StateApply
 			return nil
 		case ocd.ConfigType_SET:
 			if err := h.doSet(path); err != nil {
@@ -189,6 +196,21 @@ func (h *Handler) NextState(t ocd.ConfigType, path []string) error {
 	}
 }
 
+// Apply Apply committed config.
+// It is serialized with reload of config file and rollback by
+// confirm timeout.
+func (h *Handler) Apply(apply ApplyFunc) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.state != StateApply {
+		return fmt.Errorf("Bad config state %v to apply", h.state)
+	}
+	h.state = StateInitialize
+
+	return cmgr.Apply(apply)
+}
+
 // Reset Reset handler processing.
 func (h *Handler) Reset() {
 	h.lock.Lock()
@@ -202,6 +224,14 @@ func createSubifname(ifname string, subifidx uint64) string {
 	return fmt.Sprintf("%s-%d", ifname, subifidx)
 }
 
+func setSubifAddress(ifname string, subifname string, addr net.IP) {
+	if models.ToAddressFamily(addr) == models.AddressFamilyIPv6 {
+		cmgr.modified.SetSubifIPv6(ifname, subifname, addr)
+	} else {
+		cmgr.modified.SetSubifIP(ifname, subifname, addr)
+	}
+}
+
 func interfaceConf(Cmd int, Args cmd.Args) int {
 	log.Debugf("command type: %d, args: %v", Cmd, Args)
 
@@ -299,22 +329,20 @@ func subIfIpv4PrefixConf(Cmd int, Args cmd.Args) int {
 	return cmd.Success
 }
 
-func vrrpGroupConf(Cmd int, Args cmd.Args) int {
+func subIfIpv6AddressConf(Cmd int, Args cmd.Args) int {
 	log.Debugf("command type: %d, args: %v", Cmd, Args)
 
 	ifname := Args[0].(string)
 	subifidx := Args[1].(uint64)
 	subifaddr := Args[2].(net.IP)
-	vrid := uint8(Args[3].(uint64))
 
 	subifname := createSubifname(ifname, subifidx)
 
 	if Cmd == cmd.Set {
 		cmgr.modified.SetSubifIndex(ifname, subifname, subifidx)
-		cmgr.modified.SetSubifIP(ifname, subifname, subifaddr)
-		cmgr.modified.AddVrrp(ifname, subifname, vrid)
+		cmgr.modified.SetSubifIPv6(ifname, subifname, subifaddr)
 	} else if Cmd == cmd.Delete {
-		cmgr.modified.DeleteVrrp(ifname, subifname, vrid)
+		cmgr.modified.DeleteSubifIPv6(ifname, subifname)
 	}
 
 	log.Debugf("modified config: %v", cmgr.modified.String())
@@ -322,23 +350,22 @@ func vrrpGroupConf(Cmd int, Args cmd.Args) int {
 	return cmd.Success
 }
 
-func vrrpVaddressConf(Cmd int, Args cmd.Args) int {
+func subIfIpv6PrefixConf(Cmd int, Args cmd.Args) int {
 	log.Debugf("command type: %d, args: %v", Cmd, Args)
 
 	ifname := Args[0].(string)
 	subifidx := Args[1].(uint64)
 	subifaddr := Args[2].(net.IP)
-	vrid := uint8(Args[3].(uint64))
-	addr := Args[4].(net.IP)
+	prefix := uint32(Args[3].(uint64))
 
 	subifname := createSubifname(ifname, subifidx)
 
 	if Cmd == cmd.Set {
 		cmgr.modified.SetSubifIndex(ifname, subifname, subifidx)
-		cmgr.modified.SetSubifIP(ifname, subifname, subifaddr)
-		cmgr.modified.AddVrrpVirtualAddress(ifname, subifname, vrid, addr)
+		cmgr.modified.SetSubifIPv6(ifname, subifname, subifaddr)
+		cmgr.modified.SetSubifIPv6Prefix(ifname, subifname, prefix)
 	} else if Cmd == cmd.Delete {
-		cmgr.modified.DeleteVrrpVirtualAddress(ifname, subifname, vrid, addr)
+		cmgr.modified.DeleteSubifIPv6Prefix(ifname, subifname)
 	}
 
 	log.Debugf("modified config: %v", cmgr.modified.String())
@@ -346,23 +373,23 @@ func vrrpVaddressConf(Cmd int, Args cmd.Args) int {
 	return cmd.Success
 }
 
-func vrrpPriorityConf(Cmd int, Args cmd.Args) int {
+func vrrpGroupConf(Cmd int, Args cmd.Args) int {
 	log.Debugf("command type: %d, args: %v", Cmd, Args)
 
 	ifname := Args[0].(string)
 	subifidx := Args[1].(uint64)
 	subifaddr := Args[2].(net.IP)
 	vrid := uint8(Args[3].(uint64))
-	priority := uint8(Args[4].(uint64))
 
 	subifname := createSubifname(ifname, subifidx)
+	af := models.ToAddressFamily(subifaddr)
 
 	if Cmd == cmd.Set {
 		cmgr.modified.SetSubifIndex(ifname, subifname, subifidx)
-		cmgr.modified.SetSubifIP(ifname, subifname, subifaddr)
-		cmgr.modified.SetVrrpPriority(ifname, subifname, vrid, priority)
+		setSubifAddress(ifname, subifname, subifaddr)
+		cmgr.modified.AddVrrp(ifname, subifname, af, vrid)
 	} else if Cmd == cmd.Delete {
-		cmgr.modified.SetDefaultVrrpPriority(ifname, subifname, vrid)
+		cmgr.modified.DeleteVrrp(ifname, subifname, af, vrid)
 	}
 
 	log.Debugf("modified config: %v", cmgr.modified.String())
@@ -370,48 +397,198 @@ func vrrpPriorityConf(Cmd int, Args cmd.Args) int {
 	return cmd.Success
 }
 
-func vrrpPreemptConf(Cmd int, Args cmd.Args) int {
-	log.Debugf("command type: %d, args: %v", Cmd, Args)
+// addressToken Token in vrrpLeaf path, replaced with address of
+// each address family.
+const addressToken = "ADDRESS"
+
+// vrrpLeaf Config leaf under vrrp-group.
+type vrrpLeaf struct {
+	path     []string // path under "vrrp-group <1-255>"
+	ipv6Only bool
+	parse    func(value interface{}) (interface{}, error)
+	set      func(vrrp *models.VRRP, value interface{})
+	delete   func(vrrp *models.VRRP, value interface{})
+}
 
-	ifname := Args[0].(string)
-	subifidx := Args[1].(uint64)
-	subifaddr := Args[2].(net.IP)
-	vrid := uint8(Args[3].(uint64))
-	// TODO: error check
-	preempt, _ := strconv.ParseBool(Args[4].(string))
+// parseBool Parse boolean of VRRP config.
+func parseBool(value interface{}) (interface{}, error) {
+	b, err := strconv.ParseBool(value.(string))
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid boolean", value)
+	}
+	return b, nil
+}
 
-	subifname := createSubifname(ifname, subifidx)
+var vrrpLeaves = []*vrrpLeaf{
+	{
+		path:   []string{"config", "virtual-address", addressToken},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.AddVirtualAddress(v.(net.IP)) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.DeleteVirtualAddress(v.(net.IP)) },
+	},
+	{
+		path:   []string{"config", "priority", "<1-254>"},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetPriority(uint8(v.(uint64))) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.SetDefaultPriority() },
+	},
+	{
+		path:   []string{"config", "preempt", "WORD"},
+		parse:  parseBool,
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetPreempt(v.(bool)) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.SetDefaultPreempt() },
+	},
+	{
+		path:   []string{"config", "preempt-delay", "<0-3600>"},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetPreemptDelay(uint16(v.(uint64))) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.SetDefaultPreemptDelay() },
+	},
+	{
+		path:   []string{"config", "accept-mode", "WORD"},
+		parse:  parseBool,
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetAccept(v.(bool)) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.SetDefaultAccept() },
+	},
+	{
+		path:   []string{"config", "allow-out-of-prefix", "WORD"},
+		parse:  parseBool,
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetAllowOutOfPrefix(v.(bool)) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.SetDefaultAllowOutOfPrefix() },
+	},
+	{
+		path:   []string{"config", "health-check", "WORD"},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.AddHealthCheck(v.(string)) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.DeleteHealthCheck(v.(string)) },
+	},
+	{
+		path:   []string{"config", "sync-group", "WORD"},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetSyncGroup(v.(string)) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.DeleteSyncGroup() },
+	},
+	{
+		path:   []string{"config", "notify-script", "WORD"},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetNotifyScript(v.(string)) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.DeleteNotifyScript() },
+	},
+	{
+		path:   []string{"interface-tracking", "config", "track-interface", "WORD"},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.AddTrackInterface(v.(string)) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.DeleteTrackInterface(v.(string)) },
+	},
+	{
+		path:   []string{"interface-tracking", "config", "priority-decrement", "<0-254>"},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetPriorityDecrement(uint8(v.(uint64))) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.SetDefaultPriorityDecrement() },
+	},
+	{
+		path:   []string{"reachability", "config", "target", addressToken},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.AddReachabilityTarget(v.(net.IP)) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.DeleteReachabilityTarget(v.(net.IP)) },
+	},
+	{
+		path:   []string{"reachability", "config", "gateway", addressToken},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetReachabilityGateway(v.(net.IP)) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.DeleteReachabilityGateway() },
+	},
+	{
+		path:   []string{"reachability", "config", "priority-decrement", "<0-254>"},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetReachabilityDecrement(uint8(v.(uint64))) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.SetDefaultReachabilityDecrement() },
+	},
+	{
+		path:   []string{"config", "advertisement-interval", "<1-4095>"},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetInterval(uint16(v.(uint64))) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.SetDefaultInterval() },
+	},
+	{
+		path:   []string{"config", "version", "WORD"},
+		set:    func(vrrp *models.VRRP, v interface{}) { vrrp.SetVersion(v.(string)) },
+		delete: func(vrrp *models.VRRP, v interface{}) { vrrp.SetDefaultVersion() },
+	},
+	{
+		path:     []string{"config", "virtual-link-local", addressToken},
+		ipv6Only: true,
+		set:      func(vrrp *models.VRRP, v interface{}) { vrrp.SetVirtualLinkLocal(v.(net.IP)) },
+		delete:   func(vrrp *models.VRRP, v interface{}) { vrrp.DeleteVirtualLinkLocal() },
+	},
+}
 
-	if Cmd == cmd.Set {
-		cmgr.modified.SetSubifIndex(ifname, subifname, subifidx)
-		cmgr.modified.SetSubifIP(ifname, subifname, subifaddr)
-		cmgr.modified.SetVrrpPreempt(ifname, subifname, vrid, preempt)
-	} else if Cmd == cmd.Delete {
-		cmgr.modified.SetDefaultVrrpPreempt(ifname, subifname, vrid)
+// field Name of the leaf.
+func (leaf *vrrpLeaf) field() string {
+	return leaf.path[len(leaf.path)-2]
+}
+
+// install Install commands of the leaf for each address family.
+func (leaf *vrrpLeaf) install(p *cmd.Node) {
+	afs := []struct {
+		name    string
+		address string
+	}{
+		{"ipv4", "A.B.C.D"},
+		{"ipv6", "X:X::X:X"},
 	}
 
-	log.Debugf("modified config: %v", cmgr.modified.String())
+	for _, af := range afs {
+		if leaf.ipv6Only && af.name != "ipv6" {
+			continue
+		}
 
-	return cmd.Success
+		path := []string{"interfaces",
+			"interface", "WORD",
+			"subinterfaces",
+			"subinterface", "<0-4294967295>",
+			af.name,
+			"addresses",
+			"address", af.address,
+			"vrrp",
+			"vrrp-group", "<1-255>"}
+		for _, token := range leaf.path {
+			if token == addressToken {
+				token = af.address
+			}
+			path = append(path, token)
+		}
+		p.InstallCmd(path, leaf.conf)
+	}
 }
 
-func vrrpAdvIntervalConf(Cmd int, Args cmd.Args) int {
+// conf Set or delete the leaf of VRRP.
+// Invalid value is reported in validation.
+func (leaf *vrrpLeaf) conf(Cmd int, Args cmd.Args) int {
 	log.Debugf("command type: %d, args: %v", Cmd, Args)
 
 	ifname := Args[0].(string)
 	subifidx := Args[1].(uint64)
 	subifaddr := Args[2].(net.IP)
 	vrid := uint8(Args[3].(uint64))
-	interval := uint16(Args[4].(uint64))
+	value := Args[4]
 
 	subifname := createSubifname(ifname, subifidx)
+	af := models.ToAddressFamily(subifaddr)
 
 	if Cmd == cmd.Set {
+		if leaf.parse != nil {
+			v, err := leaf.parse(value)
+			if err != nil {
+				cmgr.addInvalid(&models.ValidationError{
+					Interface:    ifname,
+					Subinterface: subifname,
+					AF:           af,
+					Vrid:         vrid,
+					Field:        leaf.field(),
+					Reason:       err.Error(),
+				})
+				return cmd.Success
+			}
+			value = v
+		}
 		cmgr.modified.SetSubifIndex(ifname, subifname, subifidx)
-		cmgr.modified.SetSubifIP(ifname, subifname, subifaddr)
-		cmgr.modified.SetVrrpInterval(ifname, subifname, vrid, interval)
+		setSubifAddress(ifname, subifname, subifaddr)
+		cmgr.modified.UpdateVrrp(ifname, subifname, af, vrid, func(vrrp *models.VRRP) {
+			leaf.set(vrrp, value)
+		})
 	} else if Cmd == cmd.Delete {
-		cmgr.modified.SetDefaultVrrpInterval(ifname, subifname, vrid)
+		cmgr.modified.UpdateExistingVrrp(ifname, subifname, af, vrid, func(vrrp *models.VRRP) {
+			leaf.delete(vrrp, value)
+		})
 	}
 
 	log.Debugf("modified config: %v", cmgr.modified.String())
@@ -466,50 +643,33 @@ func newHandler() *Handler {
 		"interface", "WORD",
 		"subinterfaces",
 		"subinterface", "<0-4294967295>",
-		"ipv4",
+		"ipv6",
 		"addresses",
-		"address", "A.B.C.D",
-		"vrrp",
-		"vrrp-group", "<1-255>",
-		"config",
-		"virtual-address", "A.B.C.D"},
-		vrrpVaddressConf)
+		"address", "X:X::X:X"},
+		subIfIpv6AddressConf)
 	p.InstallCmd([]string{"interfaces",
 		"interface", "WORD",
 		"subinterfaces",
 		"subinterface", "<0-4294967295>",
-		"ipv4",
+		"ipv6",
 		"addresses",
-		"address", "A.B.C.D",
-		"vrrp",
-		"vrrp-group", "<1-255>",
+		"address", "X:X::X:X",
 		"config",
-		"priority", "<1-254>"},
-		vrrpPriorityConf)
+		"prefix-length", "<0-128>"},
+		subIfIpv6PrefixConf)
 	p.InstallCmd([]string{"interfaces",
 		"interface", "WORD",
 		"subinterfaces",
 		"subinterface", "<0-4294967295>",
-		"ipv4",
+		"ipv6",
 		"addresses",
-		"address", "A.B.C.D",
+		"address", "X:X::X:X",
 		"vrrp",
-		"vrrp-group", "<1-255>",
-		"config",
-		"preempt", "WORD"},
-		vrrpPreemptConf)
-	p.InstallCmd([]string{"interfaces",
-		"interface", "WORD",
-		"subinterfaces",
-		"subinterface", "<0-4294967295>",
-		"ipv4",
-		"addresses",
-		"address", "A.B.C.D",
-		"vrrp",
-		"vrrp-group", "<1-255>",
-		"config",
-		"advertisement-interval", "<1-4095>"},
-		vrrpAdvIntervalConf)
+		"vrrp-group", "<1-255>"},
+		vrrpGroupConf)
+	for _, leaf := range vrrpLeaves {
+		leaf.install(p)
+	}
 
 	return &Handler{
 		parser: p,
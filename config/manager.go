@@ -18,35 +18,81 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"sync"
+	"time"
 
+	"github.com/lagopus/vrrpd/models"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
+// ApplyFunc Func of applying config to agent.
+type ApplyFunc func(conf *AgentConfig) error
+
+// PartialError Error of ApplyFunc failed in a part of config.
+// The part is retried by agent, so config is not rolled back.
+type PartialError interface {
+	error
+	Partial() bool
+}
+
+// isPartialError Report whether err is failed in a part of config.
+func isPartialError(err error) bool {
+	perr, ok := err.(PartialError)
+	return ok && perr.Partial()
+}
+
+// pending commit, waiting for confirm.
+type pendingConfirm struct {
+	previous *AgentConfig
+	apply    ApplyFunc
+	deadline time.Time
+	timer    *time.Timer
+}
+
 // Mgr Config manager.
 type Mgr struct {
-	current  *AgentConfig
-	modified *AgentConfig
+	current        *AgentConfig
+	modified       *AgentConfig
+	applied        *AgentConfig
+	confirmTimeout time.Duration
+	pending        *pendingConfirm
+	// time of last rollback by confirm timeout.
+	rolledBack time.Time
+	// resync with openconfigd is requested.
+	resyncChannel chan bool
+	source        Source
+	// path of config file, and interfaces read from it.
+	path       string
+	fileIfaces map[string]*models.Interface
+	// interfaces and subinterfaces set by openconfigd.
+	ocd *ocdNames
+	// errors of invalid values in set commands.
+	invalids models.ValidationErrors
 	lock     sync.RWMutex
 }
 
 func newMgr() *Mgr {
 	return &Mgr{
-		current:  newAgentConfig(),
-		modified: newAgentConfig(),
+		current:       newAgentConfig(),
+		modified:      newAgentConfig(),
+		applied:       newAgentConfig(),
+		fileIfaces:    map[string]*models.Interface{},
+		ocd:           newOcdNames(),
+		resyncChannel: make(chan bool, 1),
 	}
 }
 
 var cmgr = newMgr()
 
 func (cmgr *Mgr) setModifiedConfig(agencConfig *AgentConfig) {
-	cmgr.lock.RLock()
-	defer cmgr.lock.RUnlock()
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
 	cmgr.modified = agencConfig
 }
 
@@ -66,8 +112,8 @@ func (cmgr *Mgr) GetModifiedConfig() *AgentConfig {
 
 // Commit Commit modified config.
 func (cmgr *Mgr) Commit() bool {
-	cmgr.lock.RLock()
-	defer cmgr.lock.RUnlock()
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
 
 	log.Infof("[commit] current config : %v", cmgr.current.String())
 	log.Infof("[commit] modified config: %v", cmgr.modified.String())
@@ -77,16 +123,505 @@ func (cmgr *Mgr) Commit() bool {
 	return true
 }
 
-// Rollback Rollback modified config.
-func (cmgr *Mgr) Rollback() {
+// GetAppliedConfig Get last applied config.
+func (cmgr *Mgr) GetAppliedConfig() *AgentConfig {
+	cmgr.lock.RLock()
+	defer cmgr.lock.RUnlock()
+	return cmgr.applied.Copy()
+}
+
+func (cmgr *Mgr) setAppliedConfig(agentConfig *AgentConfig) {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+	cmgr.applied = agentConfig
+}
+
+// restoreAppliedConfig Restore current config to last applied config.
+func (cmgr *Mgr) restoreAppliedConfig() *AgentConfig {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+
+	log.Infof("[restore] current config: %v", cmgr.current.String())
+	log.Infof("[restore] applied config: %v", cmgr.applied.String())
+
+	cmgr.current = cmgr.applied.Copy()
+	cmgr.modified = cmgr.applied.Copy()
+
+	return cmgr.applied.Copy()
+}
+
+// setFileConfig Set path of config file, and interfaces read from it.
+func (cmgr *Mgr) setFileConfig(path string, agentConfig *AgentConfig) {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+
+	cmgr.path = path
+	cmgr.fileIfaces = copyInterfaces(agentConfig.Interfaces)
+}
+
+func copyInterfaces(ifaces map[string]*models.Interface) map[string]*models.Interface {
+	copied := map[string]*models.Interface{}
+	for ifname, iface := range ifaces {
+		copied[ifname] = iface.Copy()
+	}
+	return copied
+}
+
+// ocdNames Names of interfaces and subinterfaces set by openconfigd.
+type ocdNames struct {
+	ifnames    map[string]bool
+	subifnames map[string]bool
+}
+
+func newOcdNames() *ocdNames {
+	return &ocdNames{
+		ifnames:    map[string]bool{},
+		subifnames: map[string]bool{},
+	}
+}
+
+func (o *ocdNames) copy() *ocdNames {
+	c := newOcdNames()
+	for name := range o.ifnames {
+		c.ifnames[name] = true
+	}
+	for name := range o.subifnames {
+		c.subifnames[name] = true
+	}
+	return c
+}
+
+// setByOpenconfigd Record interface and subinterface in path of
+// set command, they are kept on reload of config file.
+// e.g.) interfaces interface <ifname> subinterfaces subinterface <index> ...
+func (cmgr *Mgr) setByOpenconfigd(path []string) {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+
+	if len(path) < 3 || path[0] != "interfaces" || path[1] != "interface" {
+		return
+	}
+	ifname := path[2]
+	cmgr.ocd.ifnames[ifname] = true
+
+	if len(path) < 6 || path[3] != "subinterfaces" || path[4] != "subinterface" {
+		return
+	}
+	if index, err := strconv.ParseUint(path[5], 10, 64); err == nil {
+		cmgr.ocd.subifnames[createSubifname(ifname, index)] = true
+	}
+}
+
+// SetSource Set source of interfaces and VRRP config.
+// It must be set before ReadConfig.
+func (cmgr *Mgr) SetSource(source Source) {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+	cmgr.source = source
+}
+
+// GetSource Get source of interfaces and VRRP config.
+func (cmgr *Mgr) GetSource() Source {
+	cmgr.lock.RLock()
+	defer cmgr.lock.RUnlock()
+	return cmgr.source
+}
+
+// SetConfirmTimeout Set timeout of commit confirm.
+// If timeout is 0, commit is confirmed immediately.
+func (cmgr *Mgr) SetConfirmTimeout(timeout time.Duration) {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+	cmgr.confirmTimeout = timeout
+}
+
+// GetConfirmTimeout Get timeout of commit confirm.
+func (cmgr *Mgr) GetConfirmTimeout() time.Duration {
 	cmgr.lock.RLock()
 	defer cmgr.lock.RUnlock()
+	return cmgr.confirmTimeout
+}
+
+// setPendingConfirm Hold applied config as pending confirmation.
+// Previous config of pending is kept until it is confirmed.
+func (cmgr *Mgr) setPendingConfirm(previous *AgentConfig, apply ApplyFunc) {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+
+	if cmgr.confirmTimeout == 0 {
+		return
+	}
+
+	if cmgr.pending != nil {
+		cmgr.pending.timer.Stop()
+		previous = cmgr.pending.previous
+	}
+
+	p := &pendingConfirm{
+		previous: previous,
+		apply:    apply,
+		deadline: time.Now().Add(cmgr.confirmTimeout),
+	}
+	p.timer = time.AfterFunc(cmgr.confirmTimeout, func() {
+		cmgr.expireConfirm(p)
+	})
+	cmgr.pending = p
+
+	log.Infof("[commit] pending confirmation, rollback after %v", cmgr.confirmTimeout)
+}
+
+// expireConfirm Rollback to previous config of pending,
+// and apply it again.
+// openconfigd keeps the commit, so resync with it is requested
+// unless source is SourceFile.
+func (cmgr *Mgr) expireConfirm(p *pendingConfirm) {
+	// serialized with reloading config file.
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+
+	cmgr.lock.Lock()
+	if cmgr.pending != p {
+		cmgr.lock.Unlock()
+		return
+	}
+	cmgr.pending = nil
+	cmgr.rolledBack = time.Now()
+	cmgr.current = p.previous.Copy()
+	cmgr.modified = p.previous.Copy()
+	cmgr.applied = p.previous.Copy()
+	source := cmgr.source
+	cmgr.lock.Unlock()
+
+	log.Warnf("[commit] confirm timeout, rollback to previous config: %v",
+		p.previous.String())
+	if err := p.apply(p.previous.Copy()); err != nil {
+		log.Errorf("[commit] reapply previous config failed: %v", err)
+	}
+
+	if source != SourceFile {
+		cmgr.requestResync()
+	}
+}
+
+// requestResync Request resync with openconfigd.
+func (cmgr *Mgr) requestResync() {
+	select {
+	case cmgr.resyncChannel <- true:
+	default:
+		// already requested.
+	}
+}
+
+// ResyncChannel Channel receiving requests of resync with openconfigd.
+func (cmgr *Mgr) ResyncChannel() <-chan bool {
+	return cmgr.resyncChannel
+}
+
+// LastRollback Get time of last rollback by confirm timeout,
+// zero if it is not rolled back.
+func (cmgr *Mgr) LastRollback() time.Time {
+	cmgr.lock.RLock()
+	defer cmgr.lock.RUnlock()
+	return cmgr.rolledBack
+}
+
+// Confirm Confirm pending commit.
+func (cmgr *Mgr) Confirm() error {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+
+	if cmgr.pending == nil {
+		return errors.New("no pending commit")
+	}
+	cmgr.pending.timer.Stop()
+	cmgr.pending = nil
+
+	log.Info("[commit] confirmed")
+
+	return nil
+}
+
+// PendingConfirm Get whether commit is pending confirmation,
+// and remaining time until rollback.
+func (cmgr *Mgr) PendingConfirm() (bool, time.Duration) {
+	cmgr.lock.RLock()
+	defer cmgr.lock.RUnlock()
+
+	if cmgr.pending == nil {
+		return false, 0
+	}
+
+	remaining := time.Until(cmgr.pending.deadline)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return true, remaining
+}
+
+// Apply Apply current config to agent.
+// If it failed, current config is restored to last applied config,
+// and it is applied again.
+// If it failed in a part of config(PartialError), config is applied,
+// and the part is retried by agent.
+// If confirm timeout is set, applied config is pending confirmation.
+func (cmgr *Mgr) Apply(apply ApplyFunc) error {
+	return cmgr.apply(apply, true)
+}
+
+func (cmgr *Mgr) apply(apply ApplyFunc, confirm bool) error {
+	previous := cmgr.GetAppliedConfig()
+	conf := cmgr.GetCurrentConfig()
+	err := apply(conf)
+	if isPartialError(err) {
+		log.Warnf("[apply] partially failed, retried by agent: %v", err)
+		err = nil
+	}
+	if err == nil {
+		cmgr.setAppliedConfig(conf.Copy())
+		if confirm {
+			cmgr.setPendingConfirm(previous, apply)
+		}
+		return nil
+	}
+
+	log.Errorf("[apply] failed, rollback to last applied config: %v", err)
+	if rerr := apply(cmgr.restoreAppliedConfig()); rerr != nil {
+		log.Errorf("[apply] reapply last applied config failed: %v", rerr)
+	}
+
+	return err
+}
+
+// ResetForResync Reset modified config to receive whole config
+// from openconfigd again.
+// Interfaces are reset to ones read from config file, and changes
+// set by openconfigd are deleted.
+func (cmgr *Mgr) ResetForResync() {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
 
 	cmgr.modified = cmgr.current.Copy()
+	cmgr.modified.Interfaces = copyInterfaces(cmgr.fileIfaces)
+	cmgr.ocd = newOcdNames()
+	cmgr.resetInvalidsNoLock()
 }
 
-// ReadConfig Read config(YAML).
-func (cmgr *Mgr) ReadConfig(path string) error {
+// Rollback Rollback modified config.
+func (cmgr *Mgr) Rollback() {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+
+	cmgr.modified = cmgr.current.Copy()
+	cmgr.resetInvalidsNoLock()
+}
+
+func (cmgr *Mgr) resetInvalidsNoLock() {
+	cmgr.invalids = models.ValidationErrors{}
+}
+
+// resetInvalids Reset errors of invalid values in set commands.
+func (cmgr *Mgr) resetInvalids() {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+	cmgr.resetInvalidsNoLock()
+}
+
+// addInvalid Add error of invalid value in set command.
+func (cmgr *Mgr) addInvalid(e *models.ValidationError) {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+	cmgr.invalids = append(cmgr.invalids, e)
+}
+
+// Validate Validate modified config, and returns errors
+// including invalid values in set commands.
+func (cmgr *Mgr) Validate() models.ValidationErrors {
+	cmgr.lock.RLock()
+	errs := append(models.ValidationErrors{}, cmgr.invalids...)
+	modified := cmgr.modified
+	cmgr.lock.RUnlock()
+
+	return append(errs, modified.Validate()...)
+}
+
+// health check in config(YAML).
+type healthCheckConfig struct {
+	Name     string `mapstructure:"name"`
+	Command  string `mapstructure:"command"`
+	Interval uint16 `mapstructure:"interval"`
+	Timeout  uint16 `mapstructure:"timeout"`
+	Rise     uint8  `mapstructure:"rise"`
+	Fall     uint8  `mapstructure:"fall"`
+	Weight   uint8  `mapstructure:"weight"`
+}
+
+func readHealthChecks() (map[string]*models.HealthCheck, error) {
+	hcs := map[string]*models.HealthCheck{}
+	if !viper.IsSet("health-checks") {
+		return hcs, nil
+	}
+
+	confs := []healthCheckConfig{}
+	if err := viper.UnmarshalKey("health-checks", &confs); err != nil {
+		return nil, err
+	}
+
+	for _, conf := range confs {
+		hc := models.NewHealthCheck()
+		hc.Name = conf.Name
+		hc.Command = conf.Command
+		hc.Weight = conf.Weight
+		if conf.Interval > 0 {
+			hc.Interval = conf.Interval
+		}
+		if conf.Timeout > 0 {
+			hc.Timeout = conf.Timeout
+		}
+		if conf.Rise > 0 {
+			hc.Rise = conf.Rise
+		}
+		if conf.Fall > 0 {
+			hc.Fall = conf.Fall
+		}
+
+		if !hc.IsValid() {
+			return nil, fmt.Errorf("health-checks is invalid: %v", hc)
+		}
+		if _, ok := hcs[hc.Name]; ok {
+			return nil, fmt.Errorf("health-checks is duplicated: %s", hc.Name)
+		}
+		hcs[hc.Name] = hc
+	}
+
+	return hcs, nil
+}
+
+// notify script in config(YAML).
+type notifyScriptConfig struct {
+	Name       string `mapstructure:"name"`
+	Master     string `mapstructure:"master"`
+	Backup     string `mapstructure:"backup"`
+	Fault      string `mapstructure:"fault"`
+	Initialize string `mapstructure:"initialize"`
+	Timeout    uint16 `mapstructure:"timeout"`
+}
+
+func newNotifyScript(conf *notifyScriptConfig) *models.NotifyScript {
+	ns := models.NewNotifyScript()
+	ns.Name = conf.Name
+	ns.Master = conf.Master
+	ns.Backup = conf.Backup
+	ns.Fault = conf.Fault
+	ns.Initialize = conf.Initialize
+	if conf.Timeout > 0 {
+		ns.Timeout = conf.Timeout
+	}
+	return ns
+}
+
+// readNotifyScripts Read notify scripts.
+// Global notify script(notify) is named models.NotifyScriptGlobal,
+// and it is run for all virtual routers.
+func readNotifyScripts() (map[string]*models.NotifyScript, error) {
+	nss := map[string]*models.NotifyScript{}
+
+	if viper.IsSet("notify") {
+		conf := notifyScriptConfig{}
+		if err := viper.UnmarshalKey("notify", &conf); err != nil {
+			return nil, err
+		}
+		conf.Name = models.NotifyScriptGlobal
+		ns := newNotifyScript(&conf)
+		if !ns.IsValid() {
+			return nil, fmt.Errorf("notify is invalid: %v", ns)
+		}
+		nss[ns.Name] = ns
+	}
+
+	if !viper.IsSet("notify-scripts") {
+		return nss, nil
+	}
+
+	confs := []notifyScriptConfig{}
+	if err := viper.UnmarshalKey("notify-scripts", &confs); err != nil {
+		return nil, err
+	}
+
+	for i := range confs {
+		ns := newNotifyScript(&confs[i])
+		if !ns.IsValid() {
+			return nil, fmt.Errorf("notify-scripts is invalid: %v", ns)
+		}
+		if ns.Name == models.NotifyScriptGlobal {
+			return nil, fmt.Errorf("notify-scripts is reserved: %s", ns.Name)
+		}
+		if _, ok := nss[ns.Name]; ok {
+			return nil, fmt.Errorf("notify-scripts is duplicated: %s", ns.Name)
+		}
+		nss[ns.Name] = ns
+	}
+
+	return nss, nil
+}
+
+// webhook in config(YAML).
+type webhookConfig struct {
+	Name      string   `mapstructure:"name"`
+	URL       string   `mapstructure:"url"`
+	Secret    string   `mapstructure:"secret"`
+	Events    []string `mapstructure:"events"`
+	Timeout   uint16   `mapstructure:"timeout"`
+	Retries   *uint8   `mapstructure:"retries"`
+	QueueSize uint16   `mapstructure:"queue-size"`
+}
+
+func readWebhooks() (map[string]*models.Webhook, error) {
+	whs := map[string]*models.Webhook{}
+	if !viper.IsSet("webhooks") {
+		return whs, nil
+	}
+
+	confs := []webhookConfig{}
+	if err := viper.UnmarshalKey("webhooks", &confs); err != nil {
+		return nil, err
+	}
+
+	for _, conf := range confs {
+		wh := models.NewWebhook()
+		wh.Name = conf.Name
+		wh.URL = conf.URL
+		wh.Secret = conf.Secret
+		if conf.Events != nil {
+			wh.Events = conf.Events
+		}
+		if conf.Timeout > 0 {
+			wh.Timeout = conf.Timeout
+		}
+		// retries may be 0.
+		if conf.Retries != nil {
+			wh.Retries = *conf.Retries
+		}
+		if conf.QueueSize > 0 {
+			wh.QueueSize = conf.QueueSize
+		}
+
+		if !wh.IsValid() {
+			return nil, fmt.Errorf("webhooks is invalid: %v", wh)
+		}
+		if _, ok := whs[wh.Name]; ok {
+			return nil, fmt.Errorf("webhooks is duplicated: %s", wh.Name)
+		}
+		whs[wh.Name] = wh
+	}
+
+	return whs, nil
+}
+
+// readConfigFile Read config(YAML), and returns agent config
+// and timeout of commit confirm.
+// Interfaces are read unless source is SourceOpenconfigd,
+// and datastore is not required if source is SourceFile.
+func readConfigFile(path string, source Source) (*AgentConfig, time.Duration, error) {
 	agentConfig := newAgentConfig()
 
 	dir := filepath.Dir(path)
@@ -98,39 +633,39 @@ func (cmgr *Mgr) ReadConfig(path string) error {
 	viper.SetConfigName(file)
 	err := viper.ReadInConfig()
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
-	var dsAddr net.IP
+	dsAddr := agentConfig.DsAddr
 	if viper.IsSet("datastore.addr") {
 		dsAddr = net.ParseIP(viper.GetString("datastore.addr"))
 		if dsAddr == nil {
-			return errors.New("datastore.addr is invalid")
+			return nil, 0, errors.New("datastore.addr is invalid")
 		}
-	} else {
-		return errors.New("datastore.addr is null")
+	} else if source != SourceFile {
+		return nil, 0, errors.New("datastore.addr is null")
 	}
 
-	var dsPort uint16
+	dsPort := agentConfig.DsPort
 	if viper.IsSet("datastore.port") {
 		tmp, err := strconv.ParseUint(viper.GetString("datastore.port"), 10, 16)
 		if err == nil {
 			dsPort = uint16(tmp)
 		} else {
-			return err
+			return nil, 0, err
 		}
-	} else {
-		return errors.New("datastore.port is null")
+	} else if source != SourceFile {
+		return nil, 0, errors.New("datastore.port is null")
 	}
 
 	var dpaAddr net.IP
 	if viper.IsSet("dpa.addr") {
 		dpaAddr = net.ParseIP(viper.GetString("dpa.addr"))
 		if dpaAddr == nil {
-			return errors.New("dpa.addr is invalid")
+			return nil, 0, errors.New("dpa.addr is invalid")
 		}
 	} else {
-		return errors.New("dpa.addr is null")
+		return nil, 0, errors.New("dpa.addr is null")
 	}
 
 	var dpaPort uint16
@@ -139,20 +674,20 @@ func (cmgr *Mgr) ReadConfig(path string) error {
 		if err == nil {
 			dpaPort = uint16(tmp)
 		} else {
-			return err
+			return nil, 0, err
 		}
 	} else {
-		return errors.New("dpa.port is null")
+		return nil, 0, errors.New("dpa.port is null")
 	}
 
 	var hostifAddr net.IP
 	if viper.IsSet("hostif.addr") {
 		hostifAddr = net.ParseIP(viper.GetString("hostif.addr"))
 		if hostifAddr == nil {
-			return errors.New("hostif.addr is invalid")
+			return nil, 0, errors.New("hostif.addr is invalid")
 		}
 	} else {
-		return errors.New("hostif.addr is null")
+		return nil, 0, errors.New("hostif.addr is null")
 	}
 
 	var hostifPort uint16
@@ -161,10 +696,98 @@ func (cmgr *Mgr) ReadConfig(path string) error {
 		if err == nil {
 			hostifPort = uint16(tmp)
 		} else {
-			return err
+			return nil, 0, err
 		}
 	} else {
-		return errors.New("hostif.port is null")
+		return nil, 0, errors.New("hostif.port is null")
+	}
+
+	// metrics is optional.
+	var metricsAddr net.IP
+	var metricsPort uint16
+	if viper.IsSet("metrics.port") {
+		tmp, err := strconv.ParseUint(viper.GetString("metrics.port"), 10, 16)
+		if err == nil {
+			metricsPort = uint16(tmp)
+		} else {
+			return nil, 0, err
+		}
+
+		if viper.IsSet("metrics.addr") {
+			metricsAddr = net.ParseIP(viper.GetString("metrics.addr"))
+			if metricsAddr == nil {
+				return nil, 0, errors.New("metrics.addr is invalid")
+			}
+		} else {
+			return nil, 0, errors.New("metrics.addr is null")
+		}
+	}
+
+	// management is optional.
+	var mgmtAddr net.IP
+	var mgmtPort uint16
+	if viper.IsSet("management.port") {
+		tmp, err := strconv.ParseUint(viper.GetString("management.port"), 10, 16)
+		if err == nil {
+			mgmtPort = uint16(tmp)
+		} else {
+			return nil, 0, err
+		}
+
+		if viper.IsSet("management.addr") {
+			mgmtAddr = net.ParseIP(viper.GetString("management.addr"))
+			if mgmtAddr == nil {
+				return nil, 0, errors.New("management.addr is invalid")
+			}
+		} else {
+			return nil, 0, errors.New("management.addr is null")
+		}
+	}
+
+	// control socket is disabled if it is empty.
+	ctrlSocket := agentConfig.CtrlSocket
+	if viper.IsSet("control.socket") {
+		ctrlSocket = viper.GetString("control.socket")
+	}
+
+	// snmp is optional.
+	var agentXAddr string
+	if viper.IsSet("snmp.agentx") {
+		agentXAddr = viper.GetString("snmp.agentx")
+		if agentXAddr == "" {
+			return nil, 0, errors.New("snmp.agentx is invalid")
+		}
+	}
+
+	healthChecks, err := readHealthChecks()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	notifyScripts, err := readNotifyScripts()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	webhooks, err := readWebhooks()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if source != SourceOpenconfigd {
+		if err = readInterfaces(agentConfig); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var confirmTimeout time.Duration
+	if viper.IsSet("commit.confirm-timeout") {
+		tmp, err := strconv.ParseUint(viper.GetString("commit.confirm-timeout"), 10, 32)
+		if err == nil {
+			confirmTimeout = time.Duration(tmp) * time.Second
+		} else {
+			return nil, 0, err
+		}
 	}
 
 	agentConfig.DsAddr = dsAddr
@@ -173,9 +796,34 @@ func (cmgr *Mgr) ReadConfig(path string) error {
 	agentConfig.DpaPort = dpaPort
 	agentConfig.HostifAddr = hostifAddr
 	agentConfig.HostifPort = hostifPort
+	agentConfig.MetricsAddr = metricsAddr
+	agentConfig.MetricsPort = metricsPort
+	agentConfig.AgentXAddr = agentXAddr
+	agentConfig.MgmtAddr = mgmtAddr
+	agentConfig.MgmtPort = mgmtPort
+	agentConfig.CtrlSocket = ctrlSocket
+	agentConfig.HealthChecks = healthChecks
+	agentConfig.NotifyScripts = notifyScripts
+	agentConfig.Webhooks = webhooks
+
+	return agentConfig, confirmTimeout, nil
+}
+
+// ReadConfig Read config(YAML).
+// Interfaces are read unless source is SourceOpenconfigd,
+// and datastore is not required if source is SourceFile.
+func (cmgr *Mgr) ReadConfig(path string) error {
+	source := cmgr.GetSource()
+	agentConfig, confirmTimeout, err := readConfigFile(path, source)
+	if err != nil {
+		return err
+	}
 
+	cmgr.SetConfirmTimeout(confirmTimeout)
+	cmgr.setFileConfig(path, agentConfig)
 	cmgr.setModifiedConfig(agentConfig)
 	cmgr.Commit()
+	cmgr.setAppliedConfig(cmgr.GetCurrentConfig())
 
 	return nil
 }
@@ -0,0 +1,263 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lagopus/vrrpd/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type testMgrTestSuite struct {
+	suite.Suite
+}
+
+func (suite *testMgrTestSuite) TestApply() {
+	mgr := newMgr()
+	mgr.modified.AddInterface("iface01")
+	mgr.Commit()
+
+	applied := []*AgentConfig{}
+	err := mgr.Apply(func(conf *AgentConfig) error {
+		applied = append(applied, conf)
+		return nil
+	})
+	suite.Empty(err)
+	suite.Equal(1, len(applied))
+	suite.Equal(mgr.GetCurrentConfig(), mgr.GetAppliedConfig())
+	suite.Contains(mgr.GetAppliedConfig().Interfaces, "iface01")
+}
+
+func (suite *testMgrTestSuite) TestApplyFailed() {
+	mgr := newMgr()
+	mgr.modified.AddInterface("iface01")
+	mgr.Commit()
+	suite.Empty(mgr.Apply(func(conf *AgentConfig) error {
+		return nil
+	}))
+
+	mgr.modified.AddInterface("iface02")
+	mgr.Commit()
+
+	applied := []*AgentConfig{}
+	err := mgr.Apply(func(conf *AgentConfig) error {
+		applied = append(applied, conf)
+		if _, ok := conf.Interfaces["iface02"]; ok {
+			return errors.New("apply failed")
+		}
+		return nil
+	})
+	suite.NotEmpty(err)
+
+	// applied, and reapplied last applied config.
+	suite.Equal(2, len(applied))
+	suite.Contains(applied[0].Interfaces, "iface02")
+	suite.NotContains(applied[1].Interfaces, "iface02")
+
+	// restored.
+	suite.NotContains(mgr.GetCurrentConfig().Interfaces, "iface02")
+	suite.NotContains(mgr.GetModifiedConfig().Interfaces, "iface02")
+	suite.Contains(mgr.GetCurrentConfig().Interfaces, "iface01")
+}
+
+type testPartialError struct {
+	partial bool
+}
+
+func (e *testPartialError) Error() string {
+	return "vrrp failed"
+}
+
+func (e *testPartialError) Partial() bool {
+	return e.partial
+}
+
+func (suite *testMgrTestSuite) TestApplyPartiallyFailed() {
+	mgr := newMgr()
+	mgr.modified.AddInterface("iface01")
+	mgr.Commit()
+
+	applied := []*AgentConfig{}
+	err := mgr.Apply(func(conf *AgentConfig) error {
+		applied = append(applied, conf)
+		return &testPartialError{partial: true}
+	})
+
+	// not rolled back.
+	suite.Empty(err)
+	suite.Equal(1, len(applied))
+	suite.Contains(mgr.GetCurrentConfig().Interfaces, "iface01")
+	suite.Contains(mgr.GetAppliedConfig().Interfaces, "iface01")
+}
+
+func (suite *testMgrTestSuite) TestApplyAllFailed() {
+	mgr := newMgr()
+	mgr.modified.AddInterface("iface01")
+	mgr.Commit()
+	suite.Empty(mgr.Apply(func(conf *AgentConfig) error {
+		return nil
+	}))
+
+	mgr.modified.AddInterface("iface02")
+	mgr.Commit()
+
+	applied := []*AgentConfig{}
+	err := mgr.Apply(func(conf *AgentConfig) error {
+		applied = append(applied, conf)
+		if _, ok := conf.Interfaces["iface02"]; ok {
+			// all VRRPs failed.
+			return &testPartialError{partial: false}
+		}
+		return nil
+	})
+
+	// rolled back, and last applied config is applied again.
+	suite.Error(err)
+	suite.Equal(2, len(applied))
+	suite.NotContains(applied[1].Interfaces, "iface02")
+	suite.NotContains(mgr.GetCurrentConfig().Interfaces, "iface02")
+	suite.NotContains(mgr.GetAppliedConfig().Interfaces, "iface02")
+	suite.Contains(mgr.GetAppliedConfig().Interfaces, "iface01")
+}
+
+func (suite *testMgrTestSuite) TestResetForResync() {
+	mgr := newMgr()
+	// iface01 is read from config file.
+	mgr.modified.AddInterface("iface01")
+	mgr.setFileConfig("vrrpd.yml", mgr.modified)
+	mgr.modified.AddInterface("iface02")
+	mgr.Commit()
+
+	mgr.ResetForResync()
+
+	// interfaces of openconfigd are set again by resent config.
+	modified := mgr.GetModifiedConfig()
+	suite.Contains(modified.Interfaces, "iface01")
+	suite.NotContains(modified.Interfaces, "iface02")
+	suite.Contains(mgr.GetCurrentConfig().Interfaces, "iface02")
+}
+
+func (suite *testMgrTestSuite) TestConfirm() {
+	mgr := newMgr()
+	mgr.SetConfirmTimeout(time.Minute)
+	mgr.modified.AddInterface("iface01")
+	mgr.Commit()
+
+	suite.Empty(mgr.Apply(func(conf *AgentConfig) error {
+		return nil
+	}))
+	pending, remaining := mgr.PendingConfirm()
+	suite.True(pending)
+	suite.True(remaining > 0 && remaining <= time.Minute)
+
+	suite.Empty(mgr.Confirm())
+	pending, remaining = mgr.PendingConfirm()
+	suite.False(pending)
+	suite.Equal(time.Duration(0), remaining)
+	suite.Contains(mgr.GetCurrentConfig().Interfaces, "iface01")
+
+	// not pending.
+	suite.NotEmpty(mgr.Confirm())
+}
+
+func (suite *testMgrTestSuite) TestConfirmTimeout() {
+	mgr := newMgr()
+	mgr.modified.AddInterface("iface01")
+	mgr.Commit()
+	suite.Empty(mgr.Apply(func(conf *AgentConfig) error {
+		return nil
+	}))
+
+	mgr.SetConfirmTimeout(10 * time.Millisecond)
+	mgr.modified.AddInterface("iface02")
+	mgr.Commit()
+
+	applied := make(chan *AgentConfig, 2)
+	suite.Empty(mgr.Apply(func(conf *AgentConfig) error {
+		applied <- conf
+		return nil
+	}))
+	suite.Contains((<-applied).Interfaces, "iface02")
+
+	// rollback.
+	select {
+	case conf := <-applied:
+		suite.NotContains(conf.Interfaces, "iface02")
+		suite.Contains(conf.Interfaces, "iface01")
+	case <-time.After(time.Second):
+		suite.Fail("not rollback")
+	}
+	pending, _ := mgr.PendingConfirm()
+	suite.False(pending)
+	suite.False(mgr.LastRollback().IsZero())
+	suite.NotContains(mgr.GetCurrentConfig().Interfaces, "iface02")
+	suite.NotContains(mgr.GetAppliedConfig().Interfaces, "iface02")
+
+	// resync with openconfigd keeping the commit.
+	select {
+	case <-mgr.ResyncChannel():
+	case <-time.After(time.Second):
+		suite.Fail("resync is not requested")
+	}
+}
+
+func (suite *testMgrTestSuite) TestConfirmTimeoutFile() {
+	mgr := newMgr()
+	mgr.SetSource(SourceFile)
+	mgr.SetConfirmTimeout(10 * time.Millisecond)
+	mgr.modified.AddInterface("iface01")
+	mgr.Commit()
+
+	applied := make(chan *AgentConfig, 2)
+	suite.Empty(mgr.Apply(func(conf *AgentConfig) error {
+		applied <- conf
+		return nil
+	}))
+	<-applied
+	select {
+	case <-applied:
+	case <-time.After(time.Second):
+		suite.Fail("not rollback")
+	}
+
+	// openconfigd is not used.
+	select {
+	case <-mgr.ResyncChannel():
+		suite.Fail("resync is requested")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func (suite *testMgrTestSuite) TestValidate() {
+	mgr := newMgr()
+	suite.Empty(mgr.Validate())
+
+	mgr.addInvalid(&models.ValidationError{Field: "preempt", Reason: "invalid"})
+	errs := mgr.Validate()
+	suite.Equal(1, len(errs))
+	suite.Equal("preempt", errs[0].Field)
+
+	mgr.Rollback()
+	suite.Empty(mgr.Validate())
+}
+
+func TestMgrTestSuite(t *testing.T) {
+	suite.Run(t, new(testMgrTestSuite))
+}
@@ -0,0 +1,357 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lagopus/vrrpd/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReloadResult Result of reloading config file.
+// VRRP groups are identified by "subinterface:af:vrid".
+type ReloadResult struct {
+	Added   []string
+	Deleted []string
+	Changed []string
+	// notify scripts are changed.
+	NotifyScripts bool
+	// settings changed in config file, but not applied until restart.
+	RestartRequired []string
+}
+
+// IsChanged Reports whether config is changed by reloading.
+func (r *ReloadResult) IsChanged() bool {
+	return len(r.Added) > 0 || len(r.Deleted) > 0 || len(r.Changed) > 0 ||
+		r.NotifyScripts
+}
+
+func (r *ReloadResult) String() string {
+	var str string
+	str = fmt.Sprintf("Added: %v", r.Added)
+	str = fmt.Sprintf("%s, Deleted: %v", str, r.Deleted)
+	str = fmt.Sprintf("%s, Changed: %v", str, r.Changed)
+	str = fmt.Sprintf("%s, NotifyScripts: %t", str, r.NotifyScripts)
+	str = fmt.Sprintf("%s, RestartRequired: %v", str, r.RestartRequired)
+	return str
+}
+
+// vrrpSettings Settings of VRRP groups per "subinterface:af:vrid".
+// Address of subinterface and type of interface are included,
+// because VRRP groups are rebuilt when they are changed.
+func vrrpSettings(agentConfig *AgentConfig) map[string]string {
+	settings := map[string]string{}
+	for _, iface := range agentConfig.Interfaces {
+		for _, subif := range iface.Subinterfaces {
+			for _, af := range models.AddressFamilies {
+				ip, prefix := subif.Address(af)
+				for vrid, vrrp := range subif.VRRPTable(af) {
+					key := fmt.Sprintf("%s:%s:%d", subif.Name, af, vrid)
+					settings[key] = fmt.Sprintf("Type: %d, Address: %v/%d, %s",
+						iface.Type, ip, prefix, vrrp.String())
+				}
+			}
+		}
+	}
+	return settings
+}
+
+// diffVrrps Diff VRRP groups between current and next config.
+func diffVrrps(current *AgentConfig, next *AgentConfig, result *ReloadResult) {
+	cur := vrrpSettings(current)
+	nxt := vrrpSettings(next)
+
+	result.Added = []string{}
+	result.Deleted = []string{}
+	result.Changed = []string{}
+	for key, setting := range nxt {
+		if s, ok := cur[key]; !ok {
+			result.Added = append(result.Added, key)
+		} else if s != setting {
+			result.Changed = append(result.Changed, key)
+		}
+	}
+	for key := range cur {
+		if _, ok := nxt[key]; !ok {
+			result.Deleted = append(result.Deleted, key)
+		}
+	}
+	sort.Strings(result.Added)
+	sort.Strings(result.Deleted)
+	sort.Strings(result.Changed)
+}
+
+func notifyScriptsString(nss map[string]*models.NotifyScript) string {
+	strs := []string{}
+	for _, ns := range nss {
+		strs = append(strs, ns.String())
+	}
+	sort.Strings(strs)
+	return strings.Join(strs, ", ")
+}
+
+func healthChecksString(hcs map[string]*models.HealthCheck) string {
+	strs := []string{}
+	for _, hc := range hcs {
+		strs = append(strs, hc.String())
+	}
+	sort.Strings(strs)
+	return strings.Join(strs, ", ")
+}
+
+// webhooksString String of webhooks, secret is included
+// to detect changes of it.
+func webhooksString(whs map[string]*models.Webhook) string {
+	strs := []string{}
+	for _, wh := range whs {
+		strs = append(strs, wh.String()+", Secret: "+wh.Secret)
+	}
+	sort.Strings(strs)
+	return strings.Join(strs, ", ")
+}
+
+// restartRequired Names of settings changed in conf,
+// they are used by modules only at start.
+func restartRequired(current *AgentConfig, conf *AgentConfig, source Source) []string {
+	names := []string{}
+	check := func(name string, same bool) {
+		if !same {
+			names = append(names, name)
+		}
+	}
+
+	if source != SourceFile {
+		check("datastore", current.DsAddr.Equal(conf.DsAddr) &&
+			current.DsPort == conf.DsPort)
+	}
+	check("dpa", current.DpaAddr.Equal(conf.DpaAddr) &&
+		current.DpaPort == conf.DpaPort)
+	check("hostif", current.HostifAddr.Equal(conf.HostifAddr) &&
+		current.HostifPort == conf.HostifPort)
+	check("metrics", current.MetricsAddr.Equal(conf.MetricsAddr) &&
+		current.MetricsPort == conf.MetricsPort)
+	check("management", current.MgmtAddr.Equal(conf.MgmtAddr) &&
+		current.MgmtPort == conf.MgmtPort)
+	check("control", current.CtrlSocket == conf.CtrlSocket)
+	check("snmp", current.AgentXAddr == conf.AgentXAddr)
+	check("health-checks",
+		healthChecksString(current.HealthChecks) == healthChecksString(conf.HealthChecks))
+	check("webhooks",
+		webhooksString(current.Webhooks) == webhooksString(conf.Webhooks))
+
+	return names
+}
+
+func hasVrrps(subif *models.Subinterface) bool {
+	for _, af := range models.AddressFamilies {
+		if len(subif.VRRPTable(af)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeVrrps Add VRRP groups of src to dst.
+func mergeVrrps(dst *models.Subinterface, src *models.Subinterface) error {
+	for _, af := range models.AddressFamilies {
+		table := dst.VRRPTable(af)
+		for vrid, vrrp := range src.VRRPTable(af) {
+			if _, ok := table[vrid]; ok {
+				return fmt.Errorf("%s:%v:%d is set by both of config file and openconfigd",
+					dst.Name, af, vrid)
+			}
+			table[vrid] = vrrp
+		}
+	}
+	return nil
+}
+
+// mergeFileInterfaces Replace interfaces read from config file at last
+// with ones read now in agentConfig.
+// VRRP groups, and interfaces and subinterfaces set by openconfigd are
+// kept, and attributes set by openconfigd take precedence.
+// It fails if VRRP group is set by both of config file and openconfigd.
+func mergeFileInterfaces(agentConfig *AgentConfig, old map[string]*models.Interface,
+	ifaces map[string]*models.Interface, ocd *ocdNames) error {
+	// delete VRRP groups read from config file at last,
+	// and subinterfaces and interfaces left empty unless openconfigd set them.
+	for ifname, oldIface := range old {
+		iface, ok := agentConfig.Interfaces[ifname]
+		if !ok {
+			continue
+		}
+		for subifname, oldSubif := range oldIface.Subinterfaces {
+			subif, ok := iface.Subinterfaces[subifname]
+			if !ok {
+				continue
+			}
+			for _, af := range models.AddressFamilies {
+				for vrid := range oldSubif.VRRPTable(af) {
+					subif.DeleteVrrp(af, vrid)
+				}
+			}
+			if !hasVrrps(subif) && !ocd.subifnames[subifname] {
+				iface.DeleteSubinterface(subifname)
+			}
+		}
+		if len(iface.Subinterfaces) == 0 && !ocd.ifnames[ifname] {
+			delete(agentConfig.Interfaces, ifname)
+		}
+	}
+
+	for ifname, fileIface := range ifaces {
+		fileIface = fileIface.Copy()
+		iface, ok := agentConfig.Interfaces[ifname]
+		if !ok {
+			agentConfig.Interfaces[ifname] = fileIface
+			continue
+		}
+		if !ocd.ifnames[ifname] {
+			iface.Type = fileIface.Type
+		}
+		for subifname, fileSubif := range fileIface.Subinterfaces {
+			subif, ok := iface.Subinterfaces[subifname]
+			if !ok {
+				iface.Subinterfaces[subifname] = fileSubif
+				continue
+			}
+			if ocd.subifnames[subifname] {
+				if err := mergeVrrps(subif, fileSubif); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := mergeVrrps(fileSubif, subif); err != nil {
+				return err
+			}
+			iface.Subinterfaces[subifname] = fileSubif
+		}
+	}
+
+	return nil
+}
+
+// setReloadedConfig Set reloaded config as current config.
+func (cmgr *Mgr) setReloadedConfig(agentConfig *AgentConfig) {
+	cmgr.lock.Lock()
+	defer cmgr.lock.Unlock()
+
+	log.Infof("[reload] current config : %v", cmgr.current.String())
+	log.Infof("[reload] reloaded config: %v", agentConfig.String())
+
+	cmgr.current = agentConfig
+	cmgr.modified = agentConfig.Copy()
+}
+
+// rebasePendingNoLock Apply changes of reloaded config file to
+// previous config of pending commit.
+// Pending commit is confirmed if it can't be rebased.
+func (cmgr *Mgr) rebasePendingNoLock(conf *AgentConfig) {
+	if cmgr.pending == nil {
+		return
+	}
+
+	previous := cmgr.pending.previous.Copy()
+	if cmgr.source != SourceOpenconfigd {
+		err := mergeFileInterfaces(previous, cmgr.fileIfaces, conf.Interfaces, cmgr.ocd)
+		if err != nil {
+			cmgr.pending.timer.Stop()
+			cmgr.pending = nil
+			log.Warnf("[reload] can't rebase pending commit, confirmed: %v", err)
+			return
+		}
+	}
+	previous.NotifyScripts = conf.NotifyScripts
+	cmgr.pending.previous = previous
+
+	log.Info("[reload] rebased pending commit on reloaded config")
+}
+
+// ReloadConfig Read config file again, and apply changes to agent.
+// Interfaces read from config file at last are replaced with new ones,
+// and VRRP groups set by openconfigd are kept if source is SourceMerged.
+// Notify scripts and commit confirm timeout are reloaded too,
+// but other settings are not applied until restart.
+// Reloaded config is not pending confirmation, and previous config of
+// pending commit is rebased on it, so that rollback keeps the reload.
+func (cmgr *Mgr) ReloadConfig(apply ApplyFunc) (*ReloadResult, error) {
+	// serialized with transactions of openconfigd.
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	if handler.state != StateInitialize {
+		return nil, fmt.Errorf("can't reload in %v of openconfigd", handler.state)
+	}
+
+	cmgr.lock.RLock()
+	path := cmgr.path
+	source := cmgr.source
+	fileIfaces := cmgr.fileIfaces
+	ocd := cmgr.ocd.copy()
+	cmgr.lock.RUnlock()
+
+	if path == "" {
+		return nil, errors.New("config file is not read")
+	}
+
+	conf, confirmTimeout, err := readConfigFile(path, source)
+	if err != nil {
+		return nil, err
+	}
+
+	current := cmgr.GetCurrentConfig()
+	next := current.Copy()
+	if source != SourceOpenconfigd {
+		if err := mergeFileInterfaces(next, fileIfaces, conf.Interfaces, ocd); err != nil {
+			return nil, err
+		}
+	}
+	next.NotifyScripts = conf.NotifyScripts
+
+	if errs := next.Validate(); len(errs) > 0 {
+		return nil, errs
+	}
+
+	result := &ReloadResult{
+		NotifyScripts: notifyScriptsString(current.NotifyScripts) !=
+			notifyScriptsString(next.NotifyScripts),
+		RestartRequired: restartRequired(current, conf, source),
+	}
+	diffVrrps(current, next, result)
+
+	cmgr.SetConfirmTimeout(confirmTimeout)
+	if !result.IsChanged() {
+		return result, nil
+	}
+
+	// if it failed, config is restored to last applied config,
+	// so interfaces read from config file are not changed.
+	cmgr.setReloadedConfig(next)
+	if err := cmgr.apply(apply, false); err != nil {
+		return nil, err
+	}
+
+	cmgr.lock.Lock()
+	cmgr.rebasePendingNoLock(conf)
+	cmgr.fileIfaces = copyInterfaces(conf.Interfaces)
+	cmgr.lock.Unlock()
+
+	return result, nil
+}
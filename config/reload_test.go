@@ -0,0 +1,337 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/lagopus/vrrpd/models"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+)
+
+const reloadTestConfig = `
+dpa:
+  addr: 127.0.0.1
+  port: %d
+hostif:
+  addr: 127.0.0.1
+  port: 30020
+interfaces:
+  - name: if0
+    subinterfaces:
+      - index: 1
+        ipv4:
+          address: 192.168.0.1
+          prefix-length: 24
+          vrrp:
+            - virtual-router-id: 10
+              virtual-address: [192.168.0.254]
+              priority: %d
+            - virtual-router-id: %d
+              virtual-address: [192.168.0.253]
+`
+
+func sortedVrids(table map[uint8]*models.VRRP) []uint8 {
+	ids := []uint8{}
+	for vrid := range table {
+		ids = append(ids, vrid)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+type testReloadTestSuite struct {
+	suite.Suite
+	dir     string
+	path    string
+	applied []*AgentConfig
+}
+
+func (suite *testReloadTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "vrrpd")
+	suite.Empty(err)
+	suite.dir = dir
+	suite.path = filepath.Join(dir, "vrrpd.yml")
+	suite.applied = []*AgentConfig{}
+}
+
+func (suite *testReloadTestSuite) TearDownTest() {
+	viper.Reset()
+	_ = os.RemoveAll(suite.dir)
+}
+
+func (suite *testReloadTestSuite) writeConfig(yml string) {
+	suite.Empty(ioutil.WriteFile(suite.path, []byte(yml), 0644))
+}
+
+func (suite *testReloadTestSuite) apply(conf *AgentConfig) error {
+	suite.applied = append(suite.applied, conf)
+	return nil
+}
+
+func (suite *testReloadTestSuite) newMgr(source Source) *Mgr {
+	mgr := newMgr()
+	mgr.SetSource(source)
+	suite.Empty(mgr.ReadConfig(suite.path))
+	return mgr
+}
+
+func (suite *testReloadTestSuite) TestReloadConfig() {
+	suite.writeConfig(fmt.Sprintf(reloadTestConfig, 30010, 100, 20))
+	mgr := suite.newMgr(SourceFile)
+
+	// not changed.
+	result, err := mgr.ReloadConfig(suite.apply)
+	suite.Empty(err)
+	suite.False(result.IsChanged())
+	suite.Empty(result.RestartRequired)
+	suite.Empty(suite.applied)
+
+	// priority of 10 is changed, 20 is replaced with 30.
+	suite.writeConfig(fmt.Sprintf(reloadTestConfig, 30011, 200, 30) + `
+notify:
+  master: /bin/true
+`)
+	result, err = mgr.ReloadConfig(suite.apply)
+	suite.Empty(err)
+	suite.True(result.IsChanged())
+	suite.Equal([]string{"if0-1:ipv4:30"}, result.Added)
+	suite.Equal([]string{"if0-1:ipv4:20"}, result.Deleted)
+	suite.Equal([]string{"if0-1:ipv4:10"}, result.Changed)
+	suite.True(result.NotifyScripts)
+	suite.Equal([]string{"dpa"}, result.RestartRequired)
+
+	suite.Equal(1, len(suite.applied))
+	current := mgr.GetCurrentConfig()
+	suite.Equal(current, mgr.GetAppliedConfig())
+	subif := current.Interfaces["if0"].Subinterfaces["if0-1"]
+	suite.Equal(uint8(200), subif.VRRPTable(models.AddressFamilyIPv4)[10].Priority)
+	suite.Contains(subif.VRRPTable(models.AddressFamilyIPv4), uint8(30))
+	suite.NotContains(subif.VRRPTable(models.AddressFamilyIPv4), uint8(20))
+	suite.Contains(current.NotifyScripts, models.NotifyScriptGlobal)
+	// not applied until restart.
+	suite.Equal(uint16(30010), current.DpaPort)
+
+	// reloaded config is not pending confirmation.
+	pending, _ := mgr.PendingConfirm()
+	suite.False(pending)
+}
+
+func (suite *testReloadTestSuite) TestReloadConfigMerged() {
+	datastore := `
+datastore:
+  addr: 127.0.0.1
+  port: 2650
+`
+	suite.writeConfig(datastore + fmt.Sprintf(reloadTestConfig, 30010, 100, 20))
+	mgr := suite.newMgr(SourceMerged)
+
+	// set by openconfigd.
+	mgr.modified.AddInterface("if1")
+	mgr.modified.AddVrrpVirtualAddress("if0", "if0-1", models.AddressFamilyIPv4, 30,
+		net.ParseIP("192.168.0.252"))
+	mgr.Commit()
+
+	// priority of 10 is changed, 20 is replaced with 40.
+	suite.writeConfig(datastore + fmt.Sprintf(reloadTestConfig, 30010, 200, 40))
+	result, err := mgr.ReloadConfig(suite.apply)
+	suite.Empty(err)
+	suite.Equal([]string{"if0-1:ipv4:40"}, result.Added)
+	suite.Equal([]string{"if0-1:ipv4:20"}, result.Deleted)
+	suite.Equal([]string{"if0-1:ipv4:10"}, result.Changed)
+
+	// VRRP group set by openconfigd is kept.
+	current := mgr.GetCurrentConfig()
+	subif := current.Interfaces["if0"].Subinterfaces["if0-1"]
+	suite.Contains(subif.VRRPTable(models.AddressFamilyIPv4), uint8(30))
+	suite.Equal(uint8(200), subif.VRRPTable(models.AddressFamilyIPv4)[10].Priority)
+
+	// VRRP group set by both of config file and openconfigd.
+	suite.writeConfig(datastore + fmt.Sprintf(reloadTestConfig, 30010, 200, 30))
+	_, err = mgr.ReloadConfig(suite.apply)
+	suite.NotEmpty(err)
+
+	suite.writeConfig(datastore + `
+dpa:
+  addr: 127.0.0.1
+  port: 30010
+hostif:
+  addr: 127.0.0.1
+  port: 30020
+`)
+	result, err = mgr.ReloadConfig(suite.apply)
+	suite.Empty(err)
+	suite.Empty(result.RestartRequired)
+	suite.Equal([]string{"if0-1:ipv4:10", "if0-1:ipv4:40"}, result.Deleted)
+
+	current = mgr.GetCurrentConfig()
+	subif = current.Interfaces["if0"].Subinterfaces["if0-1"]
+	suite.Equal([]uint8{30}, sortedVrids(subif.VRRPTable(models.AddressFamilyIPv4)))
+	suite.Contains(current.Interfaces, "if1")
+
+	// only set by config file.
+	mgr.modified.DeleteVrrp("if0", "if0-1", models.AddressFamilyIPv4, 30)
+	mgr.Commit()
+	suite.writeConfig(datastore + fmt.Sprintf(reloadTestConfig, 30010, 100, 20))
+	_, err = mgr.ReloadConfig(suite.apply)
+	suite.Empty(err)
+	suite.writeConfig(datastore + `
+dpa:
+  addr: 127.0.0.1
+  port: 30010
+hostif:
+  addr: 127.0.0.1
+  port: 30020
+`)
+	_, err = mgr.ReloadConfig(suite.apply)
+	suite.Empty(err)
+	suite.NotContains(mgr.GetCurrentConfig().Interfaces, "if0")
+}
+
+func (suite *testReloadTestSuite) TestReloadConfigPendingConfirm() {
+	suite.writeConfig(fmt.Sprintf(reloadTestConfig, 30010, 100, 20))
+	mgr := suite.newMgr(SourceFile)
+
+	// pending commit.
+	mgr.SetConfirmTimeout(100 * time.Millisecond)
+	mgr.modified.AddInterface("if1")
+	mgr.Commit()
+	applied := make(chan *AgentConfig, 3)
+	apply := func(conf *AgentConfig) error {
+		applied <- conf
+		return nil
+	}
+	suite.Empty(mgr.Apply(apply))
+	<-applied
+
+	suite.writeConfig(fmt.Sprintf(reloadTestConfig, 30010, 200, 20))
+	_, err := mgr.ReloadConfig(apply)
+	suite.Empty(err)
+	<-applied
+	pending, _ := mgr.PendingConfirm()
+	suite.True(pending)
+
+	// rollback keeps reloaded config.
+	select {
+	case conf := <-applied:
+		suite.NotContains(conf.Interfaces, "if1")
+		subif := conf.Interfaces["if0"].Subinterfaces["if0-1"]
+		suite.Equal(uint8(200), subif.VRRPTable(models.AddressFamilyIPv4)[10].Priority)
+	case <-time.After(time.Second):
+		suite.Fail("not rollback")
+	}
+}
+
+func (suite *testReloadTestSuite) TestReloadConfigMergedOpenconfigd() {
+	datastore := `
+datastore:
+  addr: 127.0.0.1
+  port: 2650
+`
+	suite.writeConfig(datastore + fmt.Sprintf(reloadTestConfig, 30010, 100, 20))
+	mgr := suite.newMgr(SourceMerged)
+
+	// address of subinterface in config file is set by openconfigd.
+	path := []string{"interfaces", "interface", "if0", "subinterfaces", "subinterface", "1",
+		"ipv4", "addresses", "address", "192.168.0.2"}
+	mgr.modified.SetSubifIP("if0", "if0-1", net.ParseIP("192.168.0.2"))
+	mgr.setByOpenconfigd(path)
+	mgr.Commit()
+
+	suite.writeConfig(datastore + `
+dpa:
+  addr: 127.0.0.1
+  port: 30010
+hostif:
+  addr: 127.0.0.1
+  port: 30020
+`)
+	_, err := mgr.ReloadConfig(suite.apply)
+	suite.Empty(err)
+
+	// subinterface set by openconfigd is kept without VRRP groups.
+	current := mgr.GetCurrentConfig()
+	suite.Contains(current.Interfaces, "if0")
+	subif := current.Interfaces["if0"].Subinterfaces["if0-1"]
+	suite.Equal(net.ParseIP("192.168.0.2"), subif.IP)
+	suite.Empty(subif.VRRPTable(models.AddressFamilyIPv4))
+
+	// address set by openconfigd takes precedence.
+	suite.writeConfig(datastore + fmt.Sprintf(reloadTestConfig, 30010, 100, 20))
+	_, err = mgr.ReloadConfig(suite.apply)
+	suite.Empty(err)
+	subif = mgr.GetCurrentConfig().Interfaces["if0"].Subinterfaces["if0-1"]
+	suite.Equal(net.ParseIP("192.168.0.2"), subif.IP)
+	suite.Equal([]uint8{10, 20}, sortedVrids(subif.VRRPTable(models.AddressFamilyIPv4)))
+}
+
+func (suite *testReloadTestSuite) TestReloadConfigApplying() {
+	suite.writeConfig(fmt.Sprintf(reloadTestConfig, 30010, 100, 20))
+	mgr := suite.newMgr(SourceFile)
+
+	// committed by openconfigd, and not applied yet.
+	handler.state = StateApply
+	defer handler.Reset()
+	_, err := mgr.ReloadConfig(suite.apply)
+	suite.NotEmpty(err)
+	suite.Empty(suite.applied)
+
+	suite.Empty(handler.Apply(suite.apply))
+	suite.Equal(StateInitialize, handler.state)
+	suite.Equal(1, len(suite.applied))
+
+	// not committed.
+	suite.NotEmpty(handler.Apply(suite.apply))
+}
+
+func (suite *testReloadTestSuite) TestReloadConfigInvalid() {
+	suite.writeConfig(fmt.Sprintf(reloadTestConfig, 30010, 100, 20))
+	mgr := suite.newMgr(SourceFile)
+
+	// duplicated interface.
+	suite.writeConfig(fmt.Sprintf(reloadTestConfig, 30010, 100, 20) + `
+  - name: if0
+`)
+	_, err := mgr.ReloadConfig(suite.apply)
+	suite.NotEmpty(err)
+
+	// priority of address owner.
+	suite.writeConfig(fmt.Sprintf(reloadTestConfig, 30010, 255, 20))
+	_, err = mgr.ReloadConfig(suite.apply)
+	suite.NotEmpty(err)
+
+	suite.Empty(suite.applied)
+	subif := mgr.GetCurrentConfig().Interfaces["if0"].Subinterfaces["if0-1"]
+	suite.Equal(uint8(100), subif.VRRPTable(models.AddressFamilyIPv4)[10].Priority)
+}
+
+func (suite *testReloadTestSuite) TestReloadConfigNotRead() {
+	_, err := newMgr().ReloadConfig(suite.apply)
+	suite.NotEmpty(err)
+}
+
+func TestReloadTestSuite(t *testing.T) {
+	suite.Run(t, new(testReloadTestSuite))
+}
@@ -28,6 +28,8 @@ const (
 	StateValidation
 	// StateCommit Commit.
 	StateCommit
+	// StateApply Committed, and waiting for apply.
+	StateApply
 )
 
 // String String
@@ -39,6 +41,8 @@ func (s State) String() string {
 		return "Validation"
 	case StateCommit:
 		return "Commit"
+	case StateApply:
+		return "Apply"
 	}
 	return fmt.Sprintf("Unknown(%d)", s)
 }
@@ -56,3 +60,38 @@ const (
 	// DefaultInterval Default interval.
 	DefaultInterval = 100
 )
+
+// Source Source of interfaces and VRRP config.
+type Source uint8
+
+const (
+	// SourceOpenconfigd Only openconfigd.
+	SourceOpenconfigd Source = iota
+	// SourceFile Only config file(YAML).
+	SourceFile
+	// SourceMerged Config file, and openconfigd on top of it.
+	SourceMerged
+)
+
+// String String
+func (s Source) String() string {
+	switch s {
+	case SourceOpenconfigd:
+		return "openconfigd"
+	case SourceFile:
+		return "file"
+	case SourceMerged:
+		return "merged"
+	}
+	return fmt.Sprintf("Unknown(%d)", s)
+}
+
+// ParseSource Parse source of config.
+func ParseSource(str string) (Source, error) {
+	for _, s := range []Source{SourceOpenconfigd, SourceFile, SourceMerged} {
+		if str == s.String() {
+			return s, nil
+		}
+	}
+	return SourceOpenconfigd, fmt.Errorf("unknown config source: %s", str)
+}
@@ -164,3 +164,16 @@ func Rotate() error {
 
 	return nil
 }
+
+// SetLevel Set log level, and returns previous log level.
+func SetLevel(logLevel string) (string, error) {
+	logmgr.lock.Lock()
+	defer logmgr.lock.Unlock()
+
+	previous := logmgr.logLevel
+	if err := setLoglevel(logLevel); err != nil {
+		return previous, err
+	}
+
+	return previous, nil
+}
@@ -46,6 +46,7 @@ var opts struct {
 	LogLevel   string `short:"l" long:"loglevel" default:"info" description:"Log Level (debug, info, warning, error, fatal, panic)"`
 	ConfigFile string `short:"c" long:"conf" default:"/usr/local/etc/vsw_vrrpd.yml" description:"Path to config file"`
 	PidFile    string `short:"p" long:"pid" default:"/var/run/vrrpd.pid" description:"Path to config file"`
+	Source     string `short:"s" long:"source" default:"openconfigd" choice:"openconfigd" choice:"file" choice:"merged" description:"Source of interfaces and VRRP config"`
 	Version    bool   `short:"v" long:"version" description:"Show version"`
 }
 
@@ -75,12 +76,22 @@ func removePidFile(file string) {
 
 func registModules(agentConfig *config.AgentConfig, wg *sync.WaitGroup) {
 	updateHandler := agent.NewUpdateHandler(wg)
-	updateFunc := func(conf *config.AgentConfig) {
-		updateHandler.SendHandlerChannel(conf)
+	updateFunc := func(conf *config.AgentConfig) error {
+		return updateHandler.Apply(conf)
 	}
 
-	datastore := rpc.NewDatastore(agentConfig.DsAddr.String(),
-		int(agentConfig.DsPort), updateFunc, wg)
+	source := config.GetMgr().GetSource()
+
+	var datastore *rpc.Datastore
+	if source != config.SourceFile {
+		datastore = rpc.NewDatastore(agentConfig.DsAddr.String(),
+			int(agentConfig.DsPort), updateFunc, wg)
+	}
+
+	// interfaces in config file are applied when UpdateHandler starts.
+	if source != config.SourceOpenconfigd {
+		updateHandler.SendHandlerChannel(agentConfig.Copy())
+	}
 
 	recvHandler := agent.NewRecvHandler(wg)
 	recvFunc := func(packets *rpc.BulkPackets) {
@@ -97,16 +108,65 @@ func registModules(agentConfig *config.AgentConfig, wg *sync.WaitGroup) {
 
 	mDownTimer := agent.NewMDownTimer(wg)
 
+	ifTracker := agent.NewIfTracker(wg)
+
+	healthChecker := agent.NewHealthChecker(agentConfig.HealthChecks, wg)
+
+	icmpProber := agent.NewICMPProber(hostif, wg)
+
 	signaleHandler := agent.NewSignalHandler(wg)
 
+	notifier := agent.NewNotifier(agentConfig.NotifyScripts, wg)
+
+	var metrics *agent.Metrics
+	if agentConfig.MetricsPort > 0 {
+		metrics = agent.NewMetrics(agentConfig.MetricsAddr.String(),
+			int(agentConfig.MetricsPort), datastore, hostif, dpagent, wg)
+	}
+
+	var mgmtServer *agent.MgmtServer
+	if agentConfig.MgmtPort > 0 || agentConfig.CtrlSocket != "" {
+		mgmtServer = agent.NewMgmtServer(agentConfig.MgmtAddr.String(),
+			int(agentConfig.MgmtPort), agentConfig.CtrlSocket, wg)
+	}
+
+	var webhook *agent.Webhook
+	if len(agentConfig.Webhooks) > 0 {
+		webhook = agent.NewWebhook(agentConfig.Webhooks, wg)
+	}
+
+	var snmpSubagent *agent.SNMPSubagent
+	if agentConfig.AgentXAddr != "" {
+		snmpSubagent = agent.NewSNMPSubagent(agentConfig.AgentXAddr, wg)
+	}
+
 	module.RegisterModule(signaleHandler)
-	module.RegisterModule(datastore)
+	module.RegisterModule(notifier)
 	module.RegisterModule(hostif)
 	module.RegisterModule(dpagent)
 	module.RegisterModule(advTimer)
 	module.RegisterModule(mDownTimer)
+	module.RegisterModule(ifTracker)
+	module.RegisterModule(healthChecker)
+	module.RegisterModule(icmpProber)
 	module.RegisterModule(recvHandler)
 	module.RegisterModule(updateHandler)
+	if metrics != nil {
+		module.RegisterModule(metrics)
+	}
+	if webhook != nil {
+		module.RegisterModule(webhook)
+	}
+	if snmpSubagent != nil {
+		module.RegisterModule(snmpSubagent)
+	}
+	if mgmtServer != nil {
+		module.RegisterModule(mgmtServer)
+	}
+	// datastore is started last, because it waits for openconfigd.
+	if datastore != nil {
+		module.RegisterModule(datastore)
+	}
 }
 
 func daemonize() error {
@@ -170,12 +230,19 @@ func main() {
 
 	// read config
 	cmgr := config.GetMgr()
+	source, err := config.ParseSource(opts.Source)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	cmgr.SetSource(source)
 	if err = cmgr.ReadConfig(opts.ConfigFile); err != nil {
 		log.Errorf("config read error: %v", err)
 		os.Exit(1)
 	}
 	agentConfig := cmgr.GetCurrentConfig()
-	log.Infof("agent config(%s): %s", opts.ConfigFile, agentConfig.String())
+	log.Infof("agent config(%s, source: %v): %s", opts.ConfigFile, source,
+		agentConfig.String())
 
 	var wg sync.WaitGroup
 	registModules(agentConfig, &wg)
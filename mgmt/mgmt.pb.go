@@ -0,0 +1,1838 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: mgmt.proto
+
+package mgmt
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Address family.
+type AddressFamily int32
+
+const (
+	AddressFamily_IPV4 AddressFamily = 0
+	AddressFamily_IPV6 AddressFamily = 1
+)
+
+var AddressFamily_name = map[int32]string{
+	0: "IPV4",
+	1: "IPV6",
+}
+
+var AddressFamily_value = map[string]int32{
+	"IPV4": 0,
+	"IPV6": 1,
+}
+
+func (x AddressFamily) String() string {
+	return proto.EnumName(AddressFamily_name, int32(x))
+}
+
+func (AddressFamily) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{0}
+}
+
+// State of virtual router.
+type State int32
+
+const (
+	State_INITIALIZE State = 0
+	State_BACKUP     State = 1
+	State_MASTER     State = 2
+	State_FAULT      State = 3
+	// failed to apply config, retried with backoff.
+	State_ERROR State = 4
+)
+
+var State_name = map[int32]string{
+	0: "INITIALIZE",
+	1: "BACKUP",
+	2: "MASTER",
+	3: "FAULT",
+	4: "ERROR",
+}
+
+var State_value = map[string]int32{
+	"INITIALIZE": 0,
+	"BACKUP":     1,
+	"MASTER":     2,
+	"FAULT":      3,
+	"ERROR":      4,
+}
+
+func (x State) String() string {
+	return proto.EnumName(State_name, int32(x))
+}
+
+func (State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{1}
+}
+
+// Event triggering state change.
+type Trigger int32
+
+const (
+	Trigger_START               Trigger = 0
+	Trigger_START_MASTER        Trigger = 1
+	Trigger_START_BACKUP        Trigger = 2
+	Trigger_MASTER_DOWN         Trigger = 3
+	Trigger_DETECTED_NEW_MASTER Trigger = 4
+	Trigger_PREEMPT             Trigger = 5
+	Trigger_SHUTDOWN            Trigger = 6
+	Trigger_FAULT               Trigger = 7
+	Trigger_RECOVER             Trigger = 8
+)
+
+var Trigger_name = map[int32]string{
+	0: "START",
+	1: "START_MASTER",
+	2: "START_BACKUP",
+	3: "MASTER_DOWN",
+	4: "DETECTED_NEW_MASTER",
+	5: "PREEMPT",
+	6: "SHUTDOWN",
+	7: "FAULT",
+	8: "RECOVER",
+}
+
+var Trigger_value = map[string]int32{
+	"START":               0,
+	"START_MASTER":        1,
+	"START_BACKUP":        2,
+	"MASTER_DOWN":         3,
+	"DETECTED_NEW_MASTER": 4,
+	"PREEMPT":             5,
+	"SHUTDOWN":            6,
+	"FAULT":               7,
+	"RECOVER":             8,
+}
+
+func (x Trigger) String() string {
+	return proto.EnumName(Trigger_name, int32(x))
+}
+
+func (Trigger) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{2}
+}
+
+// Runtime state of virtual router.
+type VRRP struct {
+	Subinterface string        `protobuf:"bytes,1,opt,name=subinterface,proto3" json:"subinterface,omitempty"`
+	Af           AddressFamily `protobuf:"varint,2,opt,name=af,proto3,enum=mgmt.AddressFamily" json:"af,omitempty"`
+	Vrid         uint32        `protobuf:"varint,3,opt,name=vrid,proto3" json:"vrid,omitempty"`
+	State        State         `protobuf:"varint,4,opt,name=state,proto3,enum=mgmt.State" json:"state,omitempty"`
+	// priority without decrements(255 for IP address owner).
+	Priority uint32 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	// priority with decrements of tracking.
+	EffectivePriority uint32 `protobuf:"varint,6,opt,name=effective_priority,json=effectivePriority,proto3" json:"effective_priority,omitempty"`
+	Preempt           bool   `protobuf:"varint,7,opt,name=preempt,proto3" json:"preempt,omitempty"`
+	// advertisement interval in centiseconds.
+	AdvertisementInterval uint32 `protobuf:"varint,8,opt,name=advertisement_interval,json=advertisementInterval,proto3" json:"advertisement_interval,omitempty"`
+	// advertisement interval learned from master in centiseconds.
+	MasterAdvertisementInterval uint32 `protobuf:"varint,9,opt,name=master_advertisement_interval,json=masterAdvertisementInterval,proto3" json:"master_advertisement_interval,omitempty"`
+	// master down interval in centiseconds.
+	MasterDownInterval uint32 `protobuf:"varint,10,opt,name=master_down_interval,json=masterDownInterval,proto3" json:"master_down_interval,omitempty"`
+	// skew time in centiseconds.
+	SkewTime         uint32   `protobuf:"varint,11,opt,name=skew_time,json=skewTime,proto3" json:"skew_time,omitempty"`
+	VirtualAddresses []string `protobuf:"bytes,12,rep,name=virtual_addresses,json=virtualAddresses,proto3" json:"virtual_addresses,omitempty"`
+	VirtualMac       string   `protobuf:"bytes,13,opt,name=virtual_mac,json=virtualMac,proto3" json:"virtual_mac,omitempty"`
+	// empty if master is unknown.
+	MasterAddress string `protobuf:"bytes,14,opt,name=master_address,json=masterAddress,proto3" json:"master_address,omitempty"`
+	// milliseconds since the last transition.
+	SinceLastTransition uint64 `protobuf:"varint,15,opt,name=since_last_transition,json=sinceLastTransition,proto3" json:"since_last_transition,omitempty"`
+	Accept              bool   `protobuf:"varint,16,opt,name=accept,proto3" json:"accept,omitempty"`
+	PrimaryAddress      string `protobuf:"bytes,17,opt,name=primary_address,json=primaryAddress,proto3" json:"primary_address,omitempty"`
+	// version of VRRP(2, 3 or 3-compat).
+	Version string `protobuf:"bytes,18,opt,name=version,proto3" json:"version,omitempty"`
+	// last error in ERROR state.
+	Error string `protobuf:"bytes,19,opt,name=error,proto3" json:"error,omitempty"`
+	// milliseconds until next retry in ERROR state.
+	NextRetry            uint64   `protobuf:"varint,20,opt,name=next_retry,json=nextRetry,proto3" json:"next_retry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VRRP) Reset()         { *m = VRRP{} }
+func (m *VRRP) String() string { return proto.CompactTextString(m) }
+func (*VRRP) ProtoMessage()    {}
+func (*VRRP) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{0}
+}
+
+func (m *VRRP) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VRRP.Unmarshal(m, b)
+}
+func (m *VRRP) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VRRP.Marshal(b, m, deterministic)
+}
+func (m *VRRP) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VRRP.Merge(m, src)
+}
+func (m *VRRP) XXX_Size() int {
+	return xxx_messageInfo_VRRP.Size(m)
+}
+func (m *VRRP) XXX_DiscardUnknown() {
+	xxx_messageInfo_VRRP.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VRRP proto.InternalMessageInfo
+
+func (m *VRRP) GetSubinterface() string {
+	if m != nil {
+		return m.Subinterface
+	}
+	return ""
+}
+
+func (m *VRRP) GetAf() AddressFamily {
+	if m != nil {
+		return m.Af
+	}
+	return AddressFamily_IPV4
+}
+
+func (m *VRRP) GetVrid() uint32 {
+	if m != nil {
+		return m.Vrid
+	}
+	return 0
+}
+
+func (m *VRRP) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_INITIALIZE
+}
+
+func (m *VRRP) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *VRRP) GetEffectivePriority() uint32 {
+	if m != nil {
+		return m.EffectivePriority
+	}
+	return 0
+}
+
+func (m *VRRP) GetPreempt() bool {
+	if m != nil {
+		return m.Preempt
+	}
+	return false
+}
+
+func (m *VRRP) GetAdvertisementInterval() uint32 {
+	if m != nil {
+		return m.AdvertisementInterval
+	}
+	return 0
+}
+
+func (m *VRRP) GetMasterAdvertisementInterval() uint32 {
+	if m != nil {
+		return m.MasterAdvertisementInterval
+	}
+	return 0
+}
+
+func (m *VRRP) GetMasterDownInterval() uint32 {
+	if m != nil {
+		return m.MasterDownInterval
+	}
+	return 0
+}
+
+func (m *VRRP) GetSkewTime() uint32 {
+	if m != nil {
+		return m.SkewTime
+	}
+	return 0
+}
+
+func (m *VRRP) GetVirtualAddresses() []string {
+	if m != nil {
+		return m.VirtualAddresses
+	}
+	return nil
+}
+
+func (m *VRRP) GetVirtualMac() string {
+	if m != nil {
+		return m.VirtualMac
+	}
+	return ""
+}
+
+func (m *VRRP) GetMasterAddress() string {
+	if m != nil {
+		return m.MasterAddress
+	}
+	return ""
+}
+
+func (m *VRRP) GetSinceLastTransition() uint64 {
+	if m != nil {
+		return m.SinceLastTransition
+	}
+	return 0
+}
+
+func (m *VRRP) GetAccept() bool {
+	if m != nil {
+		return m.Accept
+	}
+	return false
+}
+
+func (m *VRRP) GetPrimaryAddress() string {
+	if m != nil {
+		return m.PrimaryAddress
+	}
+	return ""
+}
+
+func (m *VRRP) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *VRRP) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *VRRP) GetNextRetry() uint64 {
+	if m != nil {
+		return m.NextRetry
+	}
+	return 0
+}
+
+// Request of GetVRRPStates.
+type GetVRRPStatesRequest struct {
+	// all subinterfaces if empty.
+	Subinterface string `protobuf:"bytes,1,opt,name=subinterface,proto3" json:"subinterface,omitempty"`
+	// all virtual routers if 0.
+	Vrid                 uint32   `protobuf:"varint,2,opt,name=vrid,proto3" json:"vrid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetVRRPStatesRequest) Reset()         { *m = GetVRRPStatesRequest{} }
+func (m *GetVRRPStatesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetVRRPStatesRequest) ProtoMessage()    {}
+func (*GetVRRPStatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{1}
+}
+
+func (m *GetVRRPStatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetVRRPStatesRequest.Unmarshal(m, b)
+}
+func (m *GetVRRPStatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetVRRPStatesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetVRRPStatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVRRPStatesRequest.Merge(m, src)
+}
+func (m *GetVRRPStatesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetVRRPStatesRequest.Size(m)
+}
+func (m *GetVRRPStatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVRRPStatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetVRRPStatesRequest proto.InternalMessageInfo
+
+func (m *GetVRRPStatesRequest) GetSubinterface() string {
+	if m != nil {
+		return m.Subinterface
+	}
+	return ""
+}
+
+func (m *GetVRRPStatesRequest) GetVrid() uint32 {
+	if m != nil {
+		return m.Vrid
+	}
+	return 0
+}
+
+// Reply of GetVRRPStates.
+type GetVRRPStatesReply struct {
+	Vrrps                []*VRRP  `protobuf:"bytes,1,rep,name=vrrps,proto3" json:"vrrps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetVRRPStatesReply) Reset()         { *m = GetVRRPStatesReply{} }
+func (m *GetVRRPStatesReply) String() string { return proto.CompactTextString(m) }
+func (*GetVRRPStatesReply) ProtoMessage()    {}
+func (*GetVRRPStatesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{2}
+}
+
+func (m *GetVRRPStatesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetVRRPStatesReply.Unmarshal(m, b)
+}
+func (m *GetVRRPStatesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetVRRPStatesReply.Marshal(b, m, deterministic)
+}
+func (m *GetVRRPStatesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVRRPStatesReply.Merge(m, src)
+}
+func (m *GetVRRPStatesReply) XXX_Size() int {
+	return xxx_messageInfo_GetVRRPStatesReply.Size(m)
+}
+func (m *GetVRRPStatesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVRRPStatesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetVRRPStatesReply proto.InternalMessageInfo
+
+func (m *GetVRRPStatesReply) GetVrrps() []*VRRP {
+	if m != nil {
+		return m.Vrrps
+	}
+	return nil
+}
+
+// Request of GetModuleStates.
+type GetModuleStatesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetModuleStatesRequest) Reset()         { *m = GetModuleStatesRequest{} }
+func (m *GetModuleStatesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetModuleStatesRequest) ProtoMessage()    {}
+func (*GetModuleStatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{3}
+}
+
+func (m *GetModuleStatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetModuleStatesRequest.Unmarshal(m, b)
+}
+func (m *GetModuleStatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetModuleStatesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetModuleStatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetModuleStatesRequest.Merge(m, src)
+}
+func (m *GetModuleStatesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetModuleStatesRequest.Size(m)
+}
+func (m *GetModuleStatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetModuleStatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetModuleStatesRequest proto.InternalMessageInfo
+
+// Reply of GetModuleStates.
+type GetModuleStatesReply struct {
+	// state of modules(Initialize, Started or Suspended).
+	State string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// registered modules in order of start.
+	Modules              []string `protobuf:"bytes,2,rep,name=modules,proto3" json:"modules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetModuleStatesReply) Reset()         { *m = GetModuleStatesReply{} }
+func (m *GetModuleStatesReply) String() string { return proto.CompactTextString(m) }
+func (*GetModuleStatesReply) ProtoMessage()    {}
+func (*GetModuleStatesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{4}
+}
+
+func (m *GetModuleStatesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetModuleStatesReply.Unmarshal(m, b)
+}
+func (m *GetModuleStatesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetModuleStatesReply.Marshal(b, m, deterministic)
+}
+func (m *GetModuleStatesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetModuleStatesReply.Merge(m, src)
+}
+func (m *GetModuleStatesReply) XXX_Size() int {
+	return xxx_messageInfo_GetModuleStatesReply.Size(m)
+}
+func (m *GetModuleStatesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetModuleStatesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetModuleStatesReply proto.InternalMessageInfo
+
+func (m *GetModuleStatesReply) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *GetModuleStatesReply) GetModules() []string {
+	if m != nil {
+		return m.Modules
+	}
+	return nil
+}
+
+// Request of GetConfig.
+type GetConfigRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigRequest) Reset()         { *m = GetConfigRequest{} }
+func (m *GetConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConfigRequest) ProtoMessage()    {}
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{5}
+}
+
+func (m *GetConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigRequest.Unmarshal(m, b)
+}
+func (m *GetConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *GetConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigRequest.Merge(m, src)
+}
+func (m *GetConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_GetConfigRequest.Size(m)
+}
+func (m *GetConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigRequest proto.InternalMessageInfo
+
+// Reply of GetConfig.
+type GetConfigReply struct {
+	// current config in JSON.
+	Current string `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	// candidate config in JSON.
+	Candidate string `protobuf:"bytes,2,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	// commit is waiting for confirm.
+	ConfirmPending bool `protobuf:"varint,3,opt,name=confirm_pending,json=confirmPending,proto3" json:"confirm_pending,omitempty"`
+	// milliseconds until rollback of unconfirmed commit.
+	ConfirmRemaining uint64 `protobuf:"varint,4,opt,name=confirm_remaining,json=confirmRemaining,proto3" json:"confirm_remaining,omitempty"`
+	// unix time in nanoseconds of last rollback by confirm timeout,
+	// 0 if it is not rolled back.
+	LastRollback         int64    `protobuf:"varint,5,opt,name=last_rollback,json=lastRollback,proto3" json:"last_rollback,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigReply) Reset()         { *m = GetConfigReply{} }
+func (m *GetConfigReply) String() string { return proto.CompactTextString(m) }
+func (*GetConfigReply) ProtoMessage()    {}
+func (*GetConfigReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{6}
+}
+
+func (m *GetConfigReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigReply.Unmarshal(m, b)
+}
+func (m *GetConfigReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigReply.Marshal(b, m, deterministic)
+}
+func (m *GetConfigReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigReply.Merge(m, src)
+}
+func (m *GetConfigReply) XXX_Size() int {
+	return xxx_messageInfo_GetConfigReply.Size(m)
+}
+func (m *GetConfigReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigReply proto.InternalMessageInfo
+
+func (m *GetConfigReply) GetCurrent() string {
+	if m != nil {
+		return m.Current
+	}
+	return ""
+}
+
+func (m *GetConfigReply) GetCandidate() string {
+	if m != nil {
+		return m.Candidate
+	}
+	return ""
+}
+
+func (m *GetConfigReply) GetConfirmPending() bool {
+	if m != nil {
+		return m.ConfirmPending
+	}
+	return false
+}
+
+func (m *GetConfigReply) GetConfirmRemaining() uint64 {
+	if m != nil {
+		return m.ConfirmRemaining
+	}
+	return 0
+}
+
+func (m *GetConfigReply) GetLastRollback() int64 {
+	if m != nil {
+		return m.LastRollback
+	}
+	return 0
+}
+
+// Statistics of virtual router.
+type Statistics struct {
+	Subinterface           string        `protobuf:"bytes,1,opt,name=subinterface,proto3" json:"subinterface,omitempty"`
+	Af                     AddressFamily `protobuf:"varint,2,opt,name=af,proto3,enum=mgmt.AddressFamily" json:"af,omitempty"`
+	Vrid                   uint32        `protobuf:"varint,3,opt,name=vrid,proto3" json:"vrid,omitempty"`
+	MasterTransitions      uint64        `protobuf:"varint,4,opt,name=master_transitions,json=masterTransitions,proto3" json:"master_transitions,omitempty"`
+	RcvdAdvertisements     uint64        `protobuf:"varint,5,opt,name=rcvd_advertisements,json=rcvdAdvertisements,proto3" json:"rcvd_advertisements,omitempty"`
+	SentAdvertisements     uint64        `protobuf:"varint,6,opt,name=sent_advertisements,json=sentAdvertisements,proto3" json:"sent_advertisements,omitempty"`
+	AdvIntervalErrors      uint64        `protobuf:"varint,7,opt,name=adv_interval_errors,json=advIntervalErrors,proto3" json:"adv_interval_errors,omitempty"`
+	IpTtlErrors            uint64        `protobuf:"varint,8,opt,name=ip_ttl_errors,json=ipTtlErrors,proto3" json:"ip_ttl_errors,omitempty"`
+	ChecksumErrors         uint64        `protobuf:"varint,9,opt,name=checksum_errors,json=checksumErrors,proto3" json:"checksum_errors,omitempty"`
+	VersionErrors          uint64        `protobuf:"varint,10,opt,name=version_errors,json=versionErrors,proto3" json:"version_errors,omitempty"`
+	RcvdInvalidTypePackets uint64        `protobuf:"varint,11,opt,name=rcvd_invalid_type_packets,json=rcvdInvalidTypePackets,proto3" json:"rcvd_invalid_type_packets,omitempty"`
+	AddressListErrors      uint64        `protobuf:"varint,12,opt,name=address_list_errors,json=addressListErrors,proto3" json:"address_list_errors,omitempty"`
+	RcvdPriZeroPackets     uint64        `protobuf:"varint,13,opt,name=rcvd_pri_zero_packets,json=rcvdPriZeroPackets,proto3" json:"rcvd_pri_zero_packets,omitempty"`
+	SentPriZeroPackets     uint64        `protobuf:"varint,14,opt,name=sent_pri_zero_packets,json=sentPriZeroPackets,proto3" json:"sent_pri_zero_packets,omitempty"`
+	PacketLengthErrors     uint64        `protobuf:"varint,15,opt,name=packet_length_errors,json=packetLengthErrors,proto3" json:"packet_length_errors,omitempty"`
+	// time of creating virtual router in unix time(seconds).
+	DiscontinuityTime    int64    `protobuf:"varint,16,opt,name=discontinuity_time,json=discontinuityTime,proto3" json:"discontinuity_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Statistics) Reset()         { *m = Statistics{} }
+func (m *Statistics) String() string { return proto.CompactTextString(m) }
+func (*Statistics) ProtoMessage()    {}
+func (*Statistics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{7}
+}
+
+func (m *Statistics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Statistics.Unmarshal(m, b)
+}
+func (m *Statistics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Statistics.Marshal(b, m, deterministic)
+}
+func (m *Statistics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Statistics.Merge(m, src)
+}
+func (m *Statistics) XXX_Size() int {
+	return xxx_messageInfo_Statistics.Size(m)
+}
+func (m *Statistics) XXX_DiscardUnknown() {
+	xxx_messageInfo_Statistics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Statistics proto.InternalMessageInfo
+
+func (m *Statistics) GetSubinterface() string {
+	if m != nil {
+		return m.Subinterface
+	}
+	return ""
+}
+
+func (m *Statistics) GetAf() AddressFamily {
+	if m != nil {
+		return m.Af
+	}
+	return AddressFamily_IPV4
+}
+
+func (m *Statistics) GetVrid() uint32 {
+	if m != nil {
+		return m.Vrid
+	}
+	return 0
+}
+
+func (m *Statistics) GetMasterTransitions() uint64 {
+	if m != nil {
+		return m.MasterTransitions
+	}
+	return 0
+}
+
+func (m *Statistics) GetRcvdAdvertisements() uint64 {
+	if m != nil {
+		return m.RcvdAdvertisements
+	}
+	return 0
+}
+
+func (m *Statistics) GetSentAdvertisements() uint64 {
+	if m != nil {
+		return m.SentAdvertisements
+	}
+	return 0
+}
+
+func (m *Statistics) GetAdvIntervalErrors() uint64 {
+	if m != nil {
+		return m.AdvIntervalErrors
+	}
+	return 0
+}
+
+func (m *Statistics) GetIpTtlErrors() uint64 {
+	if m != nil {
+		return m.IpTtlErrors
+	}
+	return 0
+}
+
+func (m *Statistics) GetChecksumErrors() uint64 {
+	if m != nil {
+		return m.ChecksumErrors
+	}
+	return 0
+}
+
+func (m *Statistics) GetVersionErrors() uint64 {
+	if m != nil {
+		return m.VersionErrors
+	}
+	return 0
+}
+
+func (m *Statistics) GetRcvdInvalidTypePackets() uint64 {
+	if m != nil {
+		return m.RcvdInvalidTypePackets
+	}
+	return 0
+}
+
+func (m *Statistics) GetAddressListErrors() uint64 {
+	if m != nil {
+		return m.AddressListErrors
+	}
+	return 0
+}
+
+func (m *Statistics) GetRcvdPriZeroPackets() uint64 {
+	if m != nil {
+		return m.RcvdPriZeroPackets
+	}
+	return 0
+}
+
+func (m *Statistics) GetSentPriZeroPackets() uint64 {
+	if m != nil {
+		return m.SentPriZeroPackets
+	}
+	return 0
+}
+
+func (m *Statistics) GetPacketLengthErrors() uint64 {
+	if m != nil {
+		return m.PacketLengthErrors
+	}
+	return 0
+}
+
+func (m *Statistics) GetDiscontinuityTime() int64 {
+	if m != nil {
+		return m.DiscontinuityTime
+	}
+	return 0
+}
+
+// Statistics not attributed to a virtual router.
+type GlobalStatistics struct {
+	ChecksumErrors       uint64   `protobuf:"varint,1,opt,name=checksum_errors,json=checksumErrors,proto3" json:"checksum_errors,omitempty"`
+	VersionErrors        uint64   `protobuf:"varint,2,opt,name=version_errors,json=versionErrors,proto3" json:"version_errors,omitempty"`
+	VridErrors           uint64   `protobuf:"varint,3,opt,name=vrid_errors,json=vridErrors,proto3" json:"vrid_errors,omitempty"`
+	OtherErrors          uint64   `protobuf:"varint,4,opt,name=other_errors,json=otherErrors,proto3" json:"other_errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GlobalStatistics) Reset()         { *m = GlobalStatistics{} }
+func (m *GlobalStatistics) String() string { return proto.CompactTextString(m) }
+func (*GlobalStatistics) ProtoMessage()    {}
+func (*GlobalStatistics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{8}
+}
+
+func (m *GlobalStatistics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GlobalStatistics.Unmarshal(m, b)
+}
+func (m *GlobalStatistics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GlobalStatistics.Marshal(b, m, deterministic)
+}
+func (m *GlobalStatistics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GlobalStatistics.Merge(m, src)
+}
+func (m *GlobalStatistics) XXX_Size() int {
+	return xxx_messageInfo_GlobalStatistics.Size(m)
+}
+func (m *GlobalStatistics) XXX_DiscardUnknown() {
+	xxx_messageInfo_GlobalStatistics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GlobalStatistics proto.InternalMessageInfo
+
+func (m *GlobalStatistics) GetChecksumErrors() uint64 {
+	if m != nil {
+		return m.ChecksumErrors
+	}
+	return 0
+}
+
+func (m *GlobalStatistics) GetVersionErrors() uint64 {
+	if m != nil {
+		return m.VersionErrors
+	}
+	return 0
+}
+
+func (m *GlobalStatistics) GetVridErrors() uint64 {
+	if m != nil {
+		return m.VridErrors
+	}
+	return 0
+}
+
+func (m *GlobalStatistics) GetOtherErrors() uint64 {
+	if m != nil {
+		return m.OtherErrors
+	}
+	return 0
+}
+
+// Request of GetStatistics.
+type GetStatisticsRequest struct {
+	// all subinterfaces if empty.
+	Subinterface string `protobuf:"bytes,1,opt,name=subinterface,proto3" json:"subinterface,omitempty"`
+	// all virtual routers if 0.
+	Vrid                 uint32   `protobuf:"varint,2,opt,name=vrid,proto3" json:"vrid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStatisticsRequest) Reset()         { *m = GetStatisticsRequest{} }
+func (m *GetStatisticsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStatisticsRequest) ProtoMessage()    {}
+func (*GetStatisticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{9}
+}
+
+func (m *GetStatisticsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatisticsRequest.Unmarshal(m, b)
+}
+func (m *GetStatisticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatisticsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStatisticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatisticsRequest.Merge(m, src)
+}
+func (m *GetStatisticsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStatisticsRequest.Size(m)
+}
+func (m *GetStatisticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatisticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatisticsRequest proto.InternalMessageInfo
+
+func (m *GetStatisticsRequest) GetSubinterface() string {
+	if m != nil {
+		return m.Subinterface
+	}
+	return ""
+}
+
+func (m *GetStatisticsRequest) GetVrid() uint32 {
+	if m != nil {
+		return m.Vrid
+	}
+	return 0
+}
+
+// Reply of GetStatistics.
+type GetStatisticsReply struct {
+	Vrrps                []*Statistics     `protobuf:"bytes,1,rep,name=vrrps,proto3" json:"vrrps,omitempty"`
+	Global               *GlobalStatistics `protobuf:"bytes,2,opt,name=global,proto3" json:"global,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetStatisticsReply) Reset()         { *m = GetStatisticsReply{} }
+func (m *GetStatisticsReply) String() string { return proto.CompactTextString(m) }
+func (*GetStatisticsReply) ProtoMessage()    {}
+func (*GetStatisticsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{10}
+}
+
+func (m *GetStatisticsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatisticsReply.Unmarshal(m, b)
+}
+func (m *GetStatisticsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatisticsReply.Marshal(b, m, deterministic)
+}
+func (m *GetStatisticsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatisticsReply.Merge(m, src)
+}
+func (m *GetStatisticsReply) XXX_Size() int {
+	return xxx_messageInfo_GetStatisticsReply.Size(m)
+}
+func (m *GetStatisticsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatisticsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatisticsReply proto.InternalMessageInfo
+
+func (m *GetStatisticsReply) GetVrrps() []*Statistics {
+	if m != nil {
+		return m.Vrrps
+	}
+	return nil
+}
+
+func (m *GetStatisticsReply) GetGlobal() *GlobalStatistics {
+	if m != nil {
+		return m.Global
+	}
+	return nil
+}
+
+// Request of SetLogLevel.
+type SetLogLevelRequest struct {
+	// debug, info, warning, error, fatal or panic.
+	Level                string   `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetLogLevelRequest) Reset()         { *m = SetLogLevelRequest{} }
+func (m *SetLogLevelRequest) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelRequest) ProtoMessage()    {}
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{11}
+}
+
+func (m *SetLogLevelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelRequest.Unmarshal(m, b)
+}
+func (m *SetLogLevelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelRequest.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelRequest.Merge(m, src)
+}
+func (m *SetLogLevelRequest) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelRequest.Size(m)
+}
+func (m *SetLogLevelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelRequest proto.InternalMessageInfo
+
+func (m *SetLogLevelRequest) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+// Reply of SetLogLevel.
+type SetLogLevelReply struct {
+	// previous log level.
+	Previous             string   `protobuf:"bytes,1,opt,name=previous,proto3" json:"previous,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetLogLevelReply) Reset()         { *m = SetLogLevelReply{} }
+func (m *SetLogLevelReply) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelReply) ProtoMessage()    {}
+func (*SetLogLevelReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{12}
+}
+
+func (m *SetLogLevelReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelReply.Unmarshal(m, b)
+}
+func (m *SetLogLevelReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelReply.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelReply.Merge(m, src)
+}
+func (m *SetLogLevelReply) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelReply.Size(m)
+}
+func (m *SetLogLevelReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelReply proto.InternalMessageInfo
+
+func (m *SetLogLevelReply) GetPrevious() string {
+	if m != nil {
+		return m.Previous
+	}
+	return ""
+}
+
+// Request of WatchEvents.
+type WatchEventsRequest struct {
+	// all subinterfaces if empty.
+	Subinterface string `protobuf:"bytes,1,opt,name=subinterface,proto3" json:"subinterface,omitempty"`
+	// all virtual routers if 0.
+	Vrid                 uint32   `protobuf:"varint,2,opt,name=vrid,proto3" json:"vrid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchEventsRequest) Reset()         { *m = WatchEventsRequest{} }
+func (m *WatchEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchEventsRequest) ProtoMessage()    {}
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{13}
+}
+
+func (m *WatchEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEventsRequest.Unmarshal(m, b)
+}
+func (m *WatchEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEventsRequest.Merge(m, src)
+}
+func (m *WatchEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchEventsRequest.Size(m)
+}
+func (m *WatchEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEventsRequest proto.InternalMessageInfo
+
+func (m *WatchEventsRequest) GetSubinterface() string {
+	if m != nil {
+		return m.Subinterface
+	}
+	return ""
+}
+
+func (m *WatchEventsRequest) GetVrid() uint32 {
+	if m != nil {
+		return m.Vrid
+	}
+	return 0
+}
+
+// State change event of virtual router.
+type Event struct {
+	Subinterface string        `protobuf:"bytes,1,opt,name=subinterface,proto3" json:"subinterface,omitempty"`
+	Af           AddressFamily `protobuf:"varint,2,opt,name=af,proto3,enum=mgmt.AddressFamily" json:"af,omitempty"`
+	Vrid         uint32        `protobuf:"varint,3,opt,name=vrid,proto3" json:"vrid,omitempty"`
+	OldState     State         `protobuf:"varint,4,opt,name=old_state,json=oldState,proto3,enum=mgmt.State" json:"old_state,omitempty"`
+	NewState     State         `protobuf:"varint,5,opt,name=new_state,json=newState,proto3,enum=mgmt.State" json:"new_state,omitempty"`
+	Trigger      Trigger       `protobuf:"varint,6,opt,name=trigger,proto3,enum=mgmt.Trigger" json:"trigger,omitempty"`
+	// unix time in nanoseconds.
+	Timestamp int64 `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// priority of advertisement causing state change.
+	AdvPriority uint32 `protobuf:"varint,8,opt,name=adv_priority,json=advPriority,proto3" json:"adv_priority,omitempty"`
+	// source address of advertisement causing state change,
+	// empty if it is not caused by advertisement.
+	AdvSource string `protobuf:"bytes,9,opt,name=adv_source,json=advSource,proto3" json:"adv_source,omitempty"`
+	// number of events dropped before this event by overflow of buffer.
+	Dropped              uint64   `protobuf:"varint,10,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{14}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetSubinterface() string {
+	if m != nil {
+		return m.Subinterface
+	}
+	return ""
+}
+
+func (m *Event) GetAf() AddressFamily {
+	if m != nil {
+		return m.Af
+	}
+	return AddressFamily_IPV4
+}
+
+func (m *Event) GetVrid() uint32 {
+	if m != nil {
+		return m.Vrid
+	}
+	return 0
+}
+
+func (m *Event) GetOldState() State {
+	if m != nil {
+		return m.OldState
+	}
+	return State_INITIALIZE
+}
+
+func (m *Event) GetNewState() State {
+	if m != nil {
+		return m.NewState
+	}
+	return State_INITIALIZE
+}
+
+func (m *Event) GetTrigger() Trigger {
+	if m != nil {
+		return m.Trigger
+	}
+	return Trigger_START
+}
+
+func (m *Event) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Event) GetAdvPriority() uint32 {
+	if m != nil {
+		return m.AdvPriority
+	}
+	return 0
+}
+
+func (m *Event) GetAdvSource() string {
+	if m != nil {
+		return m.AdvSource
+	}
+	return ""
+}
+
+func (m *Event) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+// Request of ReloadConfig.
+type ReloadConfigRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReloadConfigRequest) Reset()         { *m = ReloadConfigRequest{} }
+func (m *ReloadConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*ReloadConfigRequest) ProtoMessage()    {}
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{15}
+}
+
+func (m *ReloadConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReloadConfigRequest.Unmarshal(m, b)
+}
+func (m *ReloadConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReloadConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *ReloadConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadConfigRequest.Merge(m, src)
+}
+func (m *ReloadConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_ReloadConfigRequest.Size(m)
+}
+func (m *ReloadConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadConfigRequest proto.InternalMessageInfo
+
+// Reply of ReloadConfig.
+// Virtual routers are "subinterface:af:vrid".
+type ReloadConfigReply struct {
+	Added   []string `protobuf:"bytes,1,rep,name=added,proto3" json:"added,omitempty"`
+	Deleted []string `protobuf:"bytes,2,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	Changed []string `protobuf:"bytes,3,rep,name=changed,proto3" json:"changed,omitempty"`
+	// notify scripts are changed.
+	NotifyScripts bool `protobuf:"varint,4,opt,name=notify_scripts,json=notifyScripts,proto3" json:"notify_scripts,omitempty"`
+	// settings changed, but not applied until restart.
+	RestartRequired      []string `protobuf:"bytes,5,rep,name=restart_required,json=restartRequired,proto3" json:"restart_required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReloadConfigReply) Reset()         { *m = ReloadConfigReply{} }
+func (m *ReloadConfigReply) String() string { return proto.CompactTextString(m) }
+func (*ReloadConfigReply) ProtoMessage()    {}
+func (*ReloadConfigReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{16}
+}
+
+func (m *ReloadConfigReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReloadConfigReply.Unmarshal(m, b)
+}
+func (m *ReloadConfigReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReloadConfigReply.Marshal(b, m, deterministic)
+}
+func (m *ReloadConfigReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadConfigReply.Merge(m, src)
+}
+func (m *ReloadConfigReply) XXX_Size() int {
+	return xxx_messageInfo_ReloadConfigReply.Size(m)
+}
+func (m *ReloadConfigReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadConfigReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadConfigReply proto.InternalMessageInfo
+
+func (m *ReloadConfigReply) GetAdded() []string {
+	if m != nil {
+		return m.Added
+	}
+	return nil
+}
+
+func (m *ReloadConfigReply) GetDeleted() []string {
+	if m != nil {
+		return m.Deleted
+	}
+	return nil
+}
+
+func (m *ReloadConfigReply) GetChanged() []string {
+	if m != nil {
+		return m.Changed
+	}
+	return nil
+}
+
+func (m *ReloadConfigReply) GetNotifyScripts() bool {
+	if m != nil {
+		return m.NotifyScripts
+	}
+	return false
+}
+
+func (m *ReloadConfigReply) GetRestartRequired() []string {
+	if m != nil {
+		return m.RestartRequired
+	}
+	return nil
+}
+
+// Request of Confirm.
+type ConfirmRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfirmRequest) Reset()         { *m = ConfirmRequest{} }
+func (m *ConfirmRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfirmRequest) ProtoMessage()    {}
+func (*ConfirmRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{17}
+}
+
+func (m *ConfirmRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmRequest.Unmarshal(m, b)
+}
+func (m *ConfirmRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmRequest.Marshal(b, m, deterministic)
+}
+func (m *ConfirmRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmRequest.Merge(m, src)
+}
+func (m *ConfirmRequest) XXX_Size() int {
+	return xxx_messageInfo_ConfirmRequest.Size(m)
+}
+func (m *ConfirmRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmRequest proto.InternalMessageInfo
+
+// Reply of Confirm.
+type ConfirmReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfirmReply) Reset()         { *m = ConfirmReply{} }
+func (m *ConfirmReply) String() string { return proto.CompactTextString(m) }
+func (*ConfirmReply) ProtoMessage()    {}
+func (*ConfirmReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_24cf82780fd24e73, []int{18}
+}
+
+func (m *ConfirmReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmReply.Unmarshal(m, b)
+}
+func (m *ConfirmReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmReply.Marshal(b, m, deterministic)
+}
+func (m *ConfirmReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmReply.Merge(m, src)
+}
+func (m *ConfirmReply) XXX_Size() int {
+	return xxx_messageInfo_ConfirmReply.Size(m)
+}
+func (m *ConfirmReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmReply proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("mgmt.AddressFamily", AddressFamily_name, AddressFamily_value)
+	proto.RegisterEnum("mgmt.State", State_name, State_value)
+	proto.RegisterEnum("mgmt.Trigger", Trigger_name, Trigger_value)
+	proto.RegisterType((*VRRP)(nil), "mgmt.VRRP")
+	proto.RegisterType((*GetVRRPStatesRequest)(nil), "mgmt.GetVRRPStatesRequest")
+	proto.RegisterType((*GetVRRPStatesReply)(nil), "mgmt.GetVRRPStatesReply")
+	proto.RegisterType((*GetModuleStatesRequest)(nil), "mgmt.GetModuleStatesRequest")
+	proto.RegisterType((*GetModuleStatesReply)(nil), "mgmt.GetModuleStatesReply")
+	proto.RegisterType((*GetConfigRequest)(nil), "mgmt.GetConfigRequest")
+	proto.RegisterType((*GetConfigReply)(nil), "mgmt.GetConfigReply")
+	proto.RegisterType((*Statistics)(nil), "mgmt.Statistics")
+	proto.RegisterType((*GlobalStatistics)(nil), "mgmt.GlobalStatistics")
+	proto.RegisterType((*GetStatisticsRequest)(nil), "mgmt.GetStatisticsRequest")
+	proto.RegisterType((*GetStatisticsReply)(nil), "mgmt.GetStatisticsReply")
+	proto.RegisterType((*SetLogLevelRequest)(nil), "mgmt.SetLogLevelRequest")
+	proto.RegisterType((*SetLogLevelReply)(nil), "mgmt.SetLogLevelReply")
+	proto.RegisterType((*WatchEventsRequest)(nil), "mgmt.WatchEventsRequest")
+	proto.RegisterType((*Event)(nil), "mgmt.Event")
+	proto.RegisterType((*ReloadConfigRequest)(nil), "mgmt.ReloadConfigRequest")
+	proto.RegisterType((*ReloadConfigReply)(nil), "mgmt.ReloadConfigReply")
+	proto.RegisterType((*ConfirmRequest)(nil), "mgmt.ConfirmRequest")
+	proto.RegisterType((*ConfirmReply)(nil), "mgmt.ConfirmReply")
+}
+
+func init() { proto.RegisterFile("mgmt.proto", fileDescriptor_24cf82780fd24e73) }
+
+var fileDescriptor_24cf82780fd24e73 = []byte{
+	// 1585 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x6e, 0x1b, 0xc7,
+	0x15, 0xf6, 0x52, 0xa4, 0x48, 0x1e, 0xfe, 0x68, 0x35, 0xa2, 0x94, 0x0d, 0x13, 0xa3, 0x0a, 0x8d,
+	0x34, 0xaa, 0x0b, 0xab, 0x8e, 0xd2, 0x1a, 0xc8, 0x55, 0x41, 0xcb, 0xb4, 0x2b, 0x84, 0xb2, 0x89,
+	0x11, 0x6d, 0x03, 0xb9, 0x59, 0x8c, 0x77, 0x47, 0xd4, 0xc0, 0xcb, 0xdd, 0xcd, 0xcc, 0x70, 0x55,
+	0xf6, 0x39, 0xda, 0x57, 0xe8, 0x6d, 0x1f, 0xa2, 0x2f, 0xd0, 0xfb, 0xbe, 0x43, 0x9f, 0xa1, 0x98,
+	0xbf, 0xa5, 0x48, 0xd1, 0x40, 0x80, 0x20, 0x77, 0x3b, 0xdf, 0xf7, 0x9d, 0x33, 0x7f, 0x67, 0xbe,
+	0x99, 0x05, 0x98, 0xcf, 0xe6, 0xf2, 0x34, 0xe7, 0x99, 0xcc, 0x50, 0x55, 0x7d, 0x0f, 0xfe, 0x57,
+	0x83, 0xea, 0x3b, 0x8c, 0x27, 0x68, 0x00, 0x6d, 0xb1, 0xf8, 0xc0, 0x52, 0x49, 0xf9, 0x35, 0x89,
+	0x68, 0xe0, 0x1d, 0x7b, 0x27, 0x4d, 0xbc, 0x86, 0xa1, 0x47, 0x50, 0x21, 0xd7, 0x41, 0xe5, 0xd8,
+	0x3b, 0xe9, 0x9e, 0x1d, 0x9c, 0xea, 0x5c, 0xc3, 0x38, 0xe6, 0x54, 0x88, 0x97, 0x64, 0xce, 0x92,
+	0x25, 0xae, 0x90, 0x6b, 0x84, 0xa0, 0x5a, 0x70, 0x16, 0x07, 0x3b, 0xc7, 0xde, 0x49, 0x07, 0xeb,
+	0x6f, 0xf4, 0x15, 0xd4, 0x84, 0x24, 0x92, 0x06, 0x55, 0x1d, 0xdb, 0x32, 0xb1, 0x57, 0x0a, 0xc2,
+	0x86, 0x41, 0x7d, 0x68, 0xe4, 0x9c, 0x65, 0x9c, 0xc9, 0x65, 0x50, 0xd3, 0xa1, 0x65, 0x1b, 0x3d,
+	0x01, 0x44, 0xaf, 0xaf, 0x69, 0x24, 0x59, 0x41, 0xc3, 0x52, 0xb5, 0xab, 0x55, 0xfb, 0x25, 0x33,
+	0x71, 0xf2, 0x00, 0xea, 0x39, 0xa7, 0x74, 0x9e, 0xcb, 0xa0, 0x7e, 0xec, 0x9d, 0x34, 0xb0, 0x6b,
+	0xa2, 0x3f, 0xc1, 0x11, 0x89, 0x0b, 0xca, 0x25, 0x13, 0x74, 0x4e, 0x53, 0x19, 0xea, 0xb9, 0x15,
+	0x24, 0x09, 0x1a, 0x3a, 0xd9, 0xe1, 0x1a, 0x7b, 0x61, 0x49, 0xf4, 0x1c, 0x1e, 0xce, 0x89, 0x90,
+	0x94, 0x87, 0x9f, 0x88, 0x6e, 0xea, 0xe8, 0x2f, 0x8c, 0x68, 0xb8, 0x35, 0xc7, 0x53, 0xe8, 0xd9,
+	0x1c, 0x71, 0x76, 0x9b, 0xae, 0x42, 0x41, 0x87, 0x22, 0xc3, 0xbd, 0xc8, 0x6e, 0xd3, 0x32, 0xe2,
+	0x0b, 0x68, 0x8a, 0x8f, 0xf4, 0x36, 0x94, 0x6c, 0x4e, 0x83, 0x96, 0x59, 0x12, 0x05, 0x4c, 0xd9,
+	0x9c, 0xa2, 0xdf, 0xc3, 0x7e, 0xc1, 0xb8, 0x5c, 0x90, 0x24, 0x24, 0x66, 0x0b, 0xa8, 0x08, 0xda,
+	0xc7, 0x3b, 0x27, 0x4d, 0xec, 0x5b, 0x62, 0xe8, 0x70, 0xf4, 0x1b, 0x68, 0x39, 0xf1, 0x9c, 0x44,
+	0x41, 0x47, 0x6f, 0x2d, 0x58, 0xe8, 0x92, 0x44, 0xe8, 0x6b, 0xe8, 0x96, 0x13, 0xd4, 0x41, 0x41,
+	0x57, 0x6b, 0x3a, 0x6e, 0x46, 0x1a, 0x44, 0x67, 0x70, 0x28, 0x58, 0x1a, 0xd1, 0x30, 0x21, 0x42,
+	0x86, 0x92, 0x93, 0x54, 0x30, 0xc9, 0xb2, 0x34, 0xd8, 0x3b, 0xf6, 0x4e, 0xaa, 0xf8, 0x40, 0x93,
+	0x63, 0x22, 0xe4, 0xb4, 0xa4, 0xd0, 0x11, 0xec, 0x92, 0x28, 0xa2, 0xb9, 0x0c, 0x7c, 0xbd, 0x17,
+	0xb6, 0x85, 0xbe, 0x81, 0xbd, 0x9c, 0xb3, 0x39, 0xe1, 0xcb, 0xb2, 0xcf, 0x7d, 0xdd, 0x67, 0xd7,
+	0xc2, 0xae, 0xd3, 0x00, 0xea, 0x05, 0xe5, 0x42, 0x75, 0x83, 0xb4, 0xc0, 0x35, 0x51, 0x0f, 0x6a,
+	0x94, 0xf3, 0x8c, 0x07, 0x07, 0x1a, 0x37, 0x0d, 0xf4, 0x10, 0x20, 0xa5, 0x7f, 0x95, 0x21, 0xa7,
+	0x92, 0x2f, 0x83, 0x9e, 0x1e, 0x59, 0x53, 0x21, 0x58, 0x01, 0x83, 0xd7, 0xd0, 0x7b, 0x45, 0xa5,
+	0x2a, 0x79, 0x5d, 0x7e, 0x02, 0xd3, 0x9f, 0x16, 0x54, 0xc8, 0x9f, 0x55, 0xff, 0xae, 0xb4, 0x2b,
+	0xab, 0xd2, 0x1e, 0x3c, 0x03, 0xb4, 0x91, 0x2f, 0x4f, 0x96, 0xe8, 0x18, 0x6a, 0x05, 0xe7, 0xb9,
+	0x08, 0xbc, 0xe3, 0x9d, 0x93, 0xd6, 0x19, 0x98, 0x82, 0x57, 0x2a, 0x6c, 0x88, 0x41, 0x00, 0x47,
+	0xaf, 0xa8, 0xbc, 0xcc, 0xe2, 0x45, 0x42, 0xd7, 0x46, 0x32, 0x78, 0x09, 0xbd, 0x7b, 0x8c, 0xca,
+	0xd9, 0x73, 0x87, 0xc8, 0x0c, 0xcd, 0x34, 0xd4, 0xf2, 0xcc, 0xb5, 0x54, 0x04, 0x15, 0xbd, 0xfd,
+	0xae, 0x39, 0x40, 0xe0, 0xbf, 0xa2, 0xf2, 0x3c, 0x4b, 0xaf, 0xd9, 0xcc, 0xe5, 0xfe, 0xb7, 0x07,
+	0xdd, 0x3b, 0xa0, 0x4a, 0x1b, 0x40, 0x3d, 0x5a, 0x70, 0x4e, 0x53, 0x69, 0x13, 0xbb, 0x26, 0xfa,
+	0x12, 0x9a, 0x11, 0x49, 0x63, 0x16, 0xab, 0x4e, 0x2b, 0x9a, 0x5b, 0x01, 0x6a, 0x03, 0x23, 0x95,
+	0x86, 0xcf, 0xc3, 0x9c, 0xa6, 0x31, 0x4b, 0x67, 0xfa, 0xc8, 0x37, 0x70, 0xd7, 0xc2, 0x13, 0x83,
+	0xaa, 0x52, 0x75, 0x42, 0x4e, 0xe7, 0x84, 0xa5, 0x4a, 0x5a, 0xd5, 0xfb, 0xe2, 0x5b, 0x02, 0x3b,
+	0x1c, 0x3d, 0x82, 0x8e, 0x2e, 0x2e, 0x9e, 0x25, 0xc9, 0x07, 0x12, 0x7d, 0xd4, 0x5e, 0xb0, 0x83,
+	0xdb, 0x0a, 0xc4, 0x16, 0x1b, 0xfc, 0xa7, 0x06, 0xa0, 0x56, 0x86, 0x09, 0xc9, 0x22, 0xf1, 0xeb,
+	0x5a, 0xd7, 0x13, 0xb0, 0x67, 0xf3, 0x4e, 0xbd, 0x0b, 0x3b, 0xfc, 0x7d, 0xc3, 0xac, 0xaa, 0x5d,
+	0xa0, 0x3f, 0xc0, 0x01, 0x8f, 0x8a, 0x78, 0xdd, 0x28, 0x84, 0x9e, 0x45, 0x15, 0x23, 0x45, 0xad,
+	0xd9, 0x83, 0x0e, 0x10, 0xca, 0x4b, 0x36, 0x02, 0x76, 0x4d, 0x80, 0xa2, 0x36, 0x02, 0x4e, 0xe1,
+	0x80, 0xc4, 0x45, 0x69, 0x20, 0xa1, 0xae, 0x7a, 0xa1, 0x9d, 0xae, 0x8a, 0xf7, 0x49, 0x5c, 0x38,
+	0x03, 0x19, 0x69, 0x02, 0x0d, 0xa0, 0xc3, 0xf2, 0x50, 0xca, 0x52, 0xd9, 0xd0, 0xca, 0x16, 0xcb,
+	0xa7, 0xd2, 0x69, 0xd4, 0x5e, 0xde, 0xd0, 0xe8, 0xa3, 0x58, 0xcc, 0x9d, 0xaa, 0xa9, 0x55, 0x5d,
+	0x07, 0x5b, 0xe1, 0xd7, 0xd0, 0xb5, 0xa7, 0xcf, 0xe9, 0x40, 0xeb, 0x3a, 0x16, 0xb5, 0xb2, 0xef,
+	0xe1, 0x73, 0xbd, 0x0a, 0x2c, 0x2d, 0x48, 0xc2, 0xe2, 0x50, 0x2e, 0x73, 0x1a, 0xe6, 0x24, 0xfa,
+	0x48, 0xa5, 0xd0, 0x56, 0x56, 0xc5, 0x47, 0x4a, 0x70, 0x61, 0xf8, 0xe9, 0x32, 0xa7, 0x13, 0xc3,
+	0x9a, 0xe9, 0xe9, 0x8d, 0x09, 0x13, 0x26, 0xa4, 0xeb, 0xa6, 0xed, 0xa6, 0xa7, 0xa9, 0x31, 0x13,
+	0xd2, 0x76, 0xf5, 0x2d, 0x1c, 0xea, 0xae, 0x72, 0xce, 0xc2, 0xbf, 0x51, 0x9e, 0x95, 0xdd, 0x74,
+	0x56, 0x4b, 0x3e, 0xe1, 0xec, 0x47, 0xca, 0x33, 0xd7, 0xc5, 0xb7, 0x70, 0xa8, 0x97, 0xfc, 0x5e,
+	0x48, 0x77, 0xb5, 0xe8, 0x1b, 0x21, 0x4f, 0xa1, 0x67, 0x44, 0x61, 0x42, 0xd3, 0x99, 0xbc, 0x71,
+	0xc3, 0x32, 0xc6, 0x87, 0x0c, 0x37, 0xd6, 0x94, 0x1d, 0xd7, 0x13, 0x40, 0x31, 0x13, 0x51, 0x96,
+	0x4a, 0x96, 0x2e, 0x98, 0x5c, 0x1a, 0x1b, 0xf7, 0x75, 0x35, 0xef, 0xaf, 0x31, 0xca, 0xcf, 0x07,
+	0xff, 0xf4, 0xc0, 0x7f, 0x95, 0x64, 0x1f, 0x48, 0x72, 0xa7, 0xb0, 0xb7, 0x6c, 0x8b, 0xf7, 0x33,
+	0xb7, 0xa5, 0xb2, 0x6d, 0x5b, 0xd4, 0x3d, 0xc0, 0x59, 0xec, 0x34, 0x3b, 0x5a, 0x03, 0x0a, 0xb2,
+	0x82, 0xaf, 0xa0, 0x9d, 0xc9, 0x1b, 0xca, 0x9d, 0xc2, 0x94, 0x79, 0x4b, 0x63, 0x46, 0x62, 0xfd,
+	0x73, 0x35, 0xc8, 0x5f, 0xea, 0x9f, 0x09, 0xa0, 0x8d, 0x7c, 0xca, 0x94, 0x7e, 0xbb, 0xee, 0x9f,
+	0xfe, 0xea, 0xc1, 0x60, 0x55, 0x86, 0x46, 0xa7, 0xb0, 0x3b, 0xd3, 0xab, 0xa6, 0x73, 0xb6, 0xce,
+	0x8e, 0x8c, 0x70, 0x73, 0x25, 0xb1, 0x55, 0x0d, 0x1e, 0x03, 0xba, 0xa2, 0x72, 0x9c, 0xcd, 0xc6,
+	0xb4, 0xa0, 0x89, 0x1b, 0x7b, 0x0f, 0x6a, 0x89, 0x6a, 0x3b, 0x67, 0xd5, 0x8d, 0xc1, 0x29, 0xf8,
+	0x6b, 0x5a, 0x35, 0x2e, 0xfd, 0x4a, 0xa1, 0x05, 0xcb, 0x16, 0xc2, 0x8a, 0xcb, 0xf6, 0x60, 0x0c,
+	0xe8, 0x3d, 0x91, 0xd1, 0xcd, 0xa8, 0x50, 0xe7, 0xf4, 0x97, 0xae, 0xcb, 0x7f, 0x2b, 0x50, 0xd3,
+	0x99, 0x7e, 0x5d, 0x7b, 0x3b, 0x81, 0x66, 0x96, 0xc4, 0xe1, 0x27, 0x5f, 0x67, 0x8d, 0x2c, 0x89,
+	0xf5, 0x97, 0x52, 0xa6, 0xf4, 0xd6, 0x2a, 0x6b, 0x5b, 0x94, 0x29, 0xbd, 0x35, 0xca, 0x6f, 0xa0,
+	0x2e, 0x39, 0x9b, 0xcd, 0x28, 0xd7, 0x36, 0xd6, 0x3d, 0xeb, 0x18, 0xdd, 0xd4, 0x80, 0xd8, 0xb1,
+	0xea, 0x82, 0x51, 0xa7, 0x42, 0x48, 0x32, 0xcf, 0xb5, 0x81, 0xed, 0xe0, 0x15, 0xa0, 0x8a, 0x51,
+	0x19, 0x5d, 0xf9, 0xde, 0x33, 0x4f, 0xb4, 0x16, 0x89, 0x8b, 0xf2, 0xa5, 0xf7, 0x10, 0x40, 0x49,
+	0x44, 0xb6, 0xe0, 0x11, 0xd5, 0x96, 0xd5, 0xc4, 0x4d, 0x12, 0x17, 0x57, 0x1a, 0x50, 0x57, 0x5b,
+	0xcc, 0xb3, 0x3c, 0xa7, 0xb1, 0xb5, 0x29, 0xd7, 0x1c, 0x1c, 0xc2, 0x01, 0xa6, 0x49, 0x46, 0xe2,
+	0xf5, 0xeb, 0xf1, 0x5f, 0x1e, 0xec, 0xaf, 0xe3, 0xf6, 0xe2, 0x25, 0x71, 0x4c, 0x63, 0x5d, 0x8c,
+	0x4d, 0x6c, 0x1a, 0x3a, 0x39, 0x4d, 0xa8, 0xa4, 0xb1, 0xbb, 0x78, 0x6d, 0x53, 0x31, 0xd1, 0x0d,
+	0x49, 0x67, 0x54, 0x2d, 0xb5, 0x66, 0x6c, 0x53, 0x9d, 0xd3, 0x34, 0x93, 0xec, 0x7a, 0x19, 0x8a,
+	0x88, 0xb3, 0x5c, 0x9a, 0x13, 0xd6, 0xc0, 0x1d, 0x83, 0x5e, 0x19, 0x10, 0xfd, 0x0e, 0x7c, 0xae,
+	0x16, 0x81, 0xab, 0x57, 0xcc, 0x4f, 0x0b, 0xc6, 0x69, 0x1c, 0xd4, 0x74, 0xa6, 0x3d, 0x8b, 0x63,
+	0x0b, 0x0f, 0x7c, 0xe8, 0x9e, 0xbb, 0x3b, 0xd4, 0xcc, 0xa1, 0x0b, 0xed, 0x12, 0xc9, 0x93, 0xe5,
+	0xe3, 0x47, 0xd0, 0x59, 0x2b, 0x05, 0xd4, 0x80, 0xea, 0xc5, 0xe4, 0xdd, 0x1f, 0xfd, 0x07, 0xf6,
+	0xeb, 0x99, 0xef, 0x3d, 0x1e, 0x41, 0xcd, 0xec, 0x5d, 0x17, 0xe0, 0xe2, 0xf5, 0xc5, 0xf4, 0x62,
+	0x38, 0xbe, 0xf8, 0x71, 0xe4, 0x3f, 0x40, 0x00, 0xbb, 0xcf, 0x87, 0xe7, 0x3f, 0xbc, 0x9d, 0xf8,
+	0x9e, 0xfa, 0xbe, 0x1c, 0x5e, 0x4d, 0x47, 0xd8, 0xaf, 0xa0, 0x26, 0xd4, 0x5e, 0x0e, 0xdf, 0x8e,
+	0xa7, 0xfe, 0x8e, 0xfa, 0x1c, 0x61, 0xfc, 0x06, 0xfb, 0xd5, 0xc7, 0xff, 0xf0, 0xa0, 0x6e, 0x77,
+	0x59, 0xc1, 0x57, 0xd3, 0x21, 0x9e, 0xfa, 0x0f, 0x90, 0x0f, 0x6d, 0xfd, 0x19, 0xda, 0x70, 0x6f,
+	0x85, 0xd8, 0xe4, 0x15, 0xb4, 0x07, 0x2d, 0xc3, 0x86, 0x2f, 0xde, 0xbc, 0x7f, 0xed, 0xef, 0xa0,
+	0xcf, 0xe0, 0xe0, 0xc5, 0x68, 0x3a, 0x3a, 0x9f, 0x8e, 0x5e, 0x84, 0xaf, 0x47, 0xef, 0x5d, 0x6c,
+	0x15, 0xb5, 0xa0, 0x3e, 0xc1, 0xa3, 0xd1, 0xe5, 0x64, 0xea, 0xd7, 0x50, 0x1b, 0x1a, 0x57, 0x7f,
+	0x79, 0x3b, 0xd5, 0x31, 0xbb, 0xab, 0x51, 0xd5, 0x95, 0x0a, 0x8f, 0xce, 0xdf, 0xbc, 0x1b, 0x61,
+	0xbf, 0x71, 0xf6, 0xf7, 0x2a, 0xc0, 0x25, 0x49, 0xc9, 0x4c, 0xdf, 0xa1, 0x68, 0x04, 0x9d, 0xb5,
+	0x37, 0x1b, 0xea, 0x5b, 0xdb, 0xd8, 0xf2, 0x30, 0xec, 0x07, 0x5b, 0x39, 0x55, 0x17, 0x3f, 0xc0,
+	0xde, 0xc6, 0x43, 0x0d, 0x7d, 0x59, 0x8a, 0xb7, 0xbc, 0xec, 0xfa, 0xfd, 0x4f, 0xb0, 0x2a, 0xd9,
+	0xf7, 0xd0, 0x2c, 0x1f, 0x66, 0xe8, 0xa8, 0x14, 0xae, 0xd5, 0x67, 0xbf, 0x77, 0x0f, 0x57, 0xa1,
+	0x66, 0x3a, 0x77, 0xee, 0x8d, 0x55, 0x3f, 0xf7, 0x7c, 0xba, 0x1f, 0x6c, 0xe5, 0x54, 0x9a, 0x3f,
+	0x43, 0xeb, 0x8e, 0xdf, 0x21, 0x2b, 0xbc, 0x6f, 0x97, 0xfd, 0xa3, 0x2d, 0x8c, 0x4a, 0xf0, 0x0c,
+	0x5a, 0x77, 0x0c, 0xd0, 0x25, 0xb8, 0xef, 0x89, 0x7d, 0xeb, 0x1b, 0x1a, 0x7c, 0xea, 0xa1, 0xe7,
+	0xd0, 0xbe, 0x7b, 0xe8, 0xd0, 0xe7, 0x86, 0xde, 0x72, 0x40, 0xfb, 0x9f, 0x6d, 0xa3, 0x54, 0xdf,
+	0xdf, 0x41, 0xdd, 0x56, 0x3d, 0xb2, 0x8b, 0xb4, 0x7e, 0x2c, 0xfa, 0x68, 0x03, 0xcd, 0x93, 0xe5,
+	0x87, 0x5d, 0xfd, 0x27, 0xfc, 0xdd, 0xff, 0x07, 0x00, 0x32, 0xb2, 0xb6, 0xbf, 0x17, 0x0f, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ManagementClient is the client API for Management service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ManagementClient interface {
+	// Get runtime state of virtual routers.
+	GetVRRPStates(ctx context.Context, in *GetVRRPStatesRequest, opts ...grpc.CallOption) (*GetVRRPStatesReply, error)
+	// Get state of modules.
+	GetModuleStates(ctx context.Context, in *GetModuleStatesRequest, opts ...grpc.CallOption) (*GetModuleStatesReply, error)
+	// Get current and candidate config.
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigReply, error)
+	// Get statistics of virtual routers.
+	GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsReply, error)
+	// Set log level.
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelReply, error)
+	// Watch state change events of virtual routers.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (Management_WatchEventsClient, error)
+	// Reload config file, and apply changes.
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigReply, error)
+	// Confirm pending commit.
+	Confirm(ctx context.Context, in *ConfirmRequest, opts ...grpc.CallOption) (*ConfirmReply, error)
+}
+
+type managementClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewManagementClient(cc *grpc.ClientConn) ManagementClient {
+	return &managementClient{cc}
+}
+
+func (c *managementClient) GetVRRPStates(ctx context.Context, in *GetVRRPStatesRequest, opts ...grpc.CallOption) (*GetVRRPStatesReply, error) {
+	out := new(GetVRRPStatesReply)
+	err := c.cc.Invoke(ctx, "/mgmt.Management/GetVRRPStates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) GetModuleStates(ctx context.Context, in *GetModuleStatesRequest, opts ...grpc.CallOption) (*GetModuleStatesReply, error) {
+	out := new(GetModuleStatesReply)
+	err := c.cc.Invoke(ctx, "/mgmt.Management/GetModuleStates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigReply, error) {
+	out := new(GetConfigReply)
+	err := c.cc.Invoke(ctx, "/mgmt.Management/GetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsReply, error) {
+	out := new(GetStatisticsReply)
+	err := c.cc.Invoke(ctx, "/mgmt.Management/GetStatistics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelReply, error) {
+	out := new(SetLogLevelReply)
+	err := c.cc.Invoke(ctx, "/mgmt.Management/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (Management_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Management_serviceDesc.Streams[0], "/mgmt.Management/WatchEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managementWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Management_WatchEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type managementWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *managementWatchEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *managementClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigReply, error) {
+	out := new(ReloadConfigReply)
+	err := c.cc.Invoke(ctx, "/mgmt.Management/ReloadConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) Confirm(ctx context.Context, in *ConfirmRequest, opts ...grpc.CallOption) (*ConfirmReply, error) {
+	out := new(ConfirmReply)
+	err := c.cc.Invoke(ctx, "/mgmt.Management/Confirm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ManagementServer is the server API for Management service.
+type ManagementServer interface {
+	// Get runtime state of virtual routers.
+	GetVRRPStates(context.Context, *GetVRRPStatesRequest) (*GetVRRPStatesReply, error)
+	// Get state of modules.
+	GetModuleStates(context.Context, *GetModuleStatesRequest) (*GetModuleStatesReply, error)
+	// Get current and candidate config.
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigReply, error)
+	// Get statistics of virtual routers.
+	GetStatistics(context.Context, *GetStatisticsRequest) (*GetStatisticsReply, error)
+	// Set log level.
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelReply, error)
+	// Watch state change events of virtual routers.
+	WatchEvents(*WatchEventsRequest, Management_WatchEventsServer) error
+	// Reload config file, and apply changes.
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigReply, error)
+	// Confirm pending commit.
+	Confirm(context.Context, *ConfirmRequest) (*ConfirmReply, error)
+}
+
+func RegisterManagementServer(s *grpc.Server, srv ManagementServer) {
+	s.RegisterService(&_Management_serviceDesc, srv)
+}
+
+func _Management_GetVRRPStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVRRPStatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).GetVRRPStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.Management/GetVRRPStates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).GetVRRPStates(ctx, req.(*GetVRRPStatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_GetModuleStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetModuleStatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).GetModuleStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.Management/GetModuleStates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).GetModuleStates(ctx, req.(*GetModuleStatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.Management/GetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_GetStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).GetStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.Management/GetStatistics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).GetStatistics(ctx, req.(*GetStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.Management/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManagementServer).WatchEvents(m, &managementWatchEventsServer{stream})
+}
+
+type Management_WatchEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type managementWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *managementWatchEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Management_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.Management/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_Confirm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).Confirm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.Management/Confirm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).Confirm(ctx, req.(*ConfirmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Management_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "mgmt.Management",
+	HandlerType: (*ManagementServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetVRRPStates",
+			Handler:    _Management_GetVRRPStates_Handler,
+		},
+		{
+			MethodName: "GetModuleStates",
+			Handler:    _Management_GetModuleStates_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _Management_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetStatistics",
+			Handler:    _Management_GetStatistics_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _Management_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _Management_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "Confirm",
+			Handler:    _Management_Confirm_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Management_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mgmt.proto",
+}
@@ -0,0 +1,115 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// HealthCheck model
+type HealthCheck struct {
+	Name     string
+	Command  string
+	Interval uint16
+	Timeout  uint16
+	Rise     uint8
+	Fall     uint8
+	Weight   uint8
+	lock     sync.RWMutex
+}
+
+// NewHealthCheck New HealthCheck model.
+func NewHealthCheck() *HealthCheck {
+	return &HealthCheck{
+		Name:     "",
+		Command:  "",
+		Interval: DefaultHealthCheckInterval,
+		Timeout:  DefaultHealthCheckTimeout,
+		Rise:     DefaultHealthCheckRise,
+		Fall:     DefaultHealthCheckFall,
+		Weight:   DefaultHealthCheckWeight,
+	}
+}
+
+// IsValid Reports whether HealthCheck represents a valid value.
+func (hc *HealthCheck) IsValid() bool {
+	return len(hc.Validate()) == 0
+}
+
+// Validate Validate HealthCheck, and returns errors.
+func (hc *HealthCheck) Validate() ValidationErrors {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+
+	errs := ValidationErrors{}
+	field := strings.TrimSpace("health-checks " + hc.Name)
+	if hc.Name == "" {
+		errs = append(errs, newValidationError(field+" name", "is required"))
+	}
+	if hc.Command == "" {
+		errs = append(errs, newValidationError(field+" command", "is required"))
+	}
+	if hc.Interval == 0 {
+		errs = append(errs, newValidationError(field+" interval", "must be greater than 0"))
+	}
+	if hc.Timeout == 0 {
+		errs = append(errs, newValidationError(field+" timeout", "must be greater than 0"))
+	}
+	if hc.Rise == 0 {
+		errs = append(errs, newValidationError(field+" rise", "must be greater than 0"))
+	}
+	if hc.Fall == 0 {
+		errs = append(errs, newValidationError(field+" fall", "must be greater than 0"))
+	}
+
+	return errs
+}
+
+// Copy Copy HealthCheck model.
+func (hc *HealthCheck) Copy() *HealthCheck {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+
+	return &HealthCheck{
+		Name:     hc.Name,
+		Command:  hc.Command,
+		Interval: hc.Interval,
+		Timeout:  hc.Timeout,
+		Rise:     hc.Rise,
+		Fall:     hc.Fall,
+		Weight:   hc.Weight,
+	}
+}
+
+// String Returns a string representation of the HealthCheck model.
+func (hc *HealthCheck) String() string {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+
+	var str string
+	str = fmt.Sprintf("Name: %s", hc.Name)
+	str = fmt.Sprintf("%s, Command: %s", str, hc.Command)
+	str = fmt.Sprintf("%s, Interval: %d", str, hc.Interval)
+	str = fmt.Sprintf("%s, Timeout: %d", str, hc.Timeout)
+	str = fmt.Sprintf("%s, Rise: %d", str, hc.Rise)
+	str = fmt.Sprintf("%s, Fall: %d", str, hc.Fall)
+	str = fmt.Sprintf("%s, Weight: %d", str, hc.Weight)
+
+	return str
+}
@@ -0,0 +1,73 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testHealthCheckTestSuite struct {
+	suite.Suite
+}
+
+func (suite *testHealthCheckTestSuite) TestHealthCheckNewHealthCheck() {
+	hc := NewHealthCheck()
+	suite.Equal(uint16(DefaultHealthCheckInterval), hc.Interval)
+	suite.Equal(uint16(DefaultHealthCheckTimeout), hc.Timeout)
+	suite.Equal(uint8(DefaultHealthCheckRise), hc.Rise)
+	suite.Equal(uint8(DefaultHealthCheckFall), hc.Fall)
+	suite.Equal(uint8(DefaultHealthCheckWeight), hc.Weight)
+	suite.False(hc.IsValid())
+}
+
+func (suite *testHealthCheckTestSuite) TestHealthCheckIsValid() {
+	hc := NewHealthCheck()
+	hc.Name = "check1"
+	hc.Command = "true"
+	suite.True(hc.IsValid())
+
+	hc.Interval = 0
+	suite.False(hc.IsValid())
+
+	hc.Interval = 1
+	hc.Fall = 0
+	suite.False(hc.IsValid())
+}
+
+func (suite *testHealthCheckTestSuite) TestHealthCheckCopy() {
+	hc := NewHealthCheck()
+	hc.Name = "check1"
+	hc.Command = "/usr/bin/check.sh"
+	hc.Rise = 2
+	hc.Fall = 3
+	hc.Weight = 20
+
+	dst := hc.Copy()
+	suite.Equal(hc.Name, dst.Name)
+	suite.Equal(hc.Command, dst.Command)
+	suite.Equal(hc.Interval, dst.Interval)
+	suite.Equal(hc.Timeout, dst.Timeout)
+	suite.Equal(hc.Rise, dst.Rise)
+	suite.Equal(hc.Fall, dst.Fall)
+	suite.Equal(hc.Weight, dst.Weight)
+}
+
+func TestHealthCheckTestSuite(t *testing.T) {
+	suite.Run(t, new(testHealthCheckTestSuite))
+}
@@ -19,6 +19,7 @@ package models
 import (
 	"fmt"
 	"net"
+	"sort"
 	"sync"
 )
 
@@ -41,24 +42,35 @@ func NewInterface() *Interface {
 
 // IsValid Reports whether Instance represents a valid value.
 func (iface *Interface) IsValid() bool {
+	return len(iface.Validate()) == 0
+}
+
+// Validate Validate Instance, and returns errors.
+func (iface *Interface) Validate() ValidationErrors {
 	iface.lock.RLock()
 	defer iface.lock.RUnlock()
 
+	errs := ValidationErrors{}
+
 	// ignore tunnel interface
 	if iface.Type == IfTypeTunnel {
-		return true
+		return errs
 	}
 
-	if len(iface.Subinterfaces) >= 0 {
-		for _, subiface := range iface.Subinterfaces {
-			if subiface.IsValid() == false {
-				return false
-			}
-		}
-		return true
+	names := []string{}
+	for name := range iface.Subinterfaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		errs = append(errs, iface.Subinterfaces[name].Validate()...)
 	}
 
-	return false
+	for _, e := range errs {
+		e.Interface = iface.Name
+	}
+
+	return errs
 }
 
 // Copy Copy Instance model.
@@ -99,12 +111,18 @@ func (iface *Interface) AddSubinterface(subifname string) {
 	iface.lock.Lock()
 	defer iface.lock.Unlock()
 
-	_, ret := iface.Subinterfaces[subifname]
+	iface.addSubinterfaceNoLock(subifname)
+}
+
+// addSubinterfaceNoLock Add subinterface if it doesn't exist, and returns it.
+func (iface *Interface) addSubinterfaceNoLock(subifname string) *Subinterface {
+	subiface, ret := iface.Subinterfaces[subifname]
 	if ret == false {
-		subiface := NewSubinterface()
+		subiface = NewSubinterface()
 		subiface.Name = subifname
 		iface.Subinterfaces[subifname] = subiface
 	}
+	return subiface
 }
 
 // DeleteSubinterface Delete interface.
@@ -124,8 +142,7 @@ func (iface *Interface) SetSubifIndex(subifname string, index uint64) {
 	if ret {
 		subiface.SetIndex(index)
 	} else {
-		iface.AddSubinterface(subifname)
-		iface.Subinterfaces[subifname].SetIndex(index)
+		iface.addSubinterfaceNoLock(subifname).SetIndex(index)
 	}
 }
 
@@ -149,8 +166,7 @@ func (iface *Interface) SetSubifIP(subifname string, ip net.IP) {
 	if ret {
 		subiface.SetIP(ip)
 	} else {
-		iface.AddSubinterface(subifname)
-		iface.Subinterfaces[subifname].SetIP(ip)
+		iface.addSubinterfaceNoLock(subifname).SetIP(ip)
 	}
 }
 
@@ -174,8 +190,7 @@ func (iface *Interface) SetSubifPrefix(subifname string, prefix uint32) {
 	if ret {
 		subiface.SetPrefix(prefix)
 	} else {
-		iface.AddSubinterface(subifname)
-		iface.Subinterfaces[subifname].SetPrefix(prefix)
+		iface.addSubinterfaceNoLock(subifname).SetPrefix(prefix)
 	}
 }
 
@@ -190,128 +205,480 @@ func (iface *Interface) DeleteSubifPrefix(subifname string) {
 	}
 }
 
+// SetSubifIPv6 Set IPv6.
+func (iface *Interface) SetSubifIPv6(subifname string, ip net.IP) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetIPv6(ip)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).SetIPv6(ip)
+	}
+}
+
+// DeleteSubifIPv6 Delete IPv6.
+func (iface *Interface) DeleteSubifIPv6(subifname string) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.DeleteIPv6()
+	}
+}
+
+// SetSubifIPv6Prefix Set IPv6 prefix.
+func (iface *Interface) SetSubifIPv6Prefix(subifname string, prefix uint32) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetIPv6Prefix(prefix)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).SetIPv6Prefix(prefix)
+	}
+}
+
+// DeleteSubifIPv6Prefix Delete IPv6 prefix.
+func (iface *Interface) DeleteSubifIPv6Prefix(subifname string) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.DeleteIPv6Prefix()
+	}
+}
+
 // AddVrrp Add VRRP.
-func (iface *Interface) AddVrrp(subifname string, vrid uint8) {
+func (iface *Interface) AddVrrp(subifname string, af AddressFamily, vrid uint8) {
 	iface.lock.Lock()
 	defer iface.lock.Unlock()
 
 	subiface, ret := iface.Subinterfaces[subifname]
 	if ret {
-		subiface.AddVrrp(vrid)
+		subiface.AddVrrp(af, vrid)
 	} else {
-		iface.AddSubinterface(subifname)
-		iface.Subinterfaces[subifname].AddVrrp(vrid)
+		iface.addSubinterfaceNoLock(subifname).AddVrrp(af, vrid)
+	}
+}
+
+// UpdateVrrp Update VRRP by fn, and add subinterface and VRRP
+// if they don't exist.
+func (iface *Interface) UpdateVrrp(subifname string, af AddressFamily, vrid uint8,
+	fn func(vrrp *VRRP)) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	iface.addSubinterfaceNoLock(subifname).UpdateVrrp(af, vrid, fn)
+}
+
+// UpdateExistingVrrp Update VRRP by fn, only if it exists.
+func (iface *Interface) UpdateExistingVrrp(subifname string, af AddressFamily, vrid uint8,
+	fn func(vrrp *VRRP)) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	if subiface, ok := iface.Subinterfaces[subifname]; ok {
+		subiface.UpdateExistingVrrp(af, vrid, fn)
 	}
 }
 
 // DeleteVrrp Add VRRP.
-func (iface *Interface) DeleteVrrp(subifname string, vrid uint8) {
+func (iface *Interface) DeleteVrrp(subifname string, af AddressFamily, vrid uint8) {
 	iface.lock.Lock()
 	defer iface.lock.Unlock()
 
 	subiface, ret := iface.Subinterfaces[subifname]
 	if ret {
-		subiface.DeleteVrrp(vrid)
+		subiface.DeleteVrrp(af, vrid)
 	}
 }
 
 // SetVrrpPriority Set priority.
-func (iface *Interface) SetVrrpPriority(subifname string, vrid uint8, priority uint8) {
+func (iface *Interface) SetVrrpPriority(subifname string, af AddressFamily, vrid uint8, priority uint8) {
 	iface.lock.Lock()
 	defer iface.lock.Unlock()
 
 	subiface, ret := iface.Subinterfaces[subifname]
 	if ret {
-		subiface.SetVrrpPriority(vrid, priority)
+		subiface.SetVrrpPriority(af, vrid, priority)
 	} else {
-		iface.AddSubinterface(subifname)
-		iface.Subinterfaces[subifname].SetVrrpPriority(vrid, priority)
+		iface.addSubinterfaceNoLock(subifname).SetVrrpPriority(af, vrid, priority)
 	}
 }
 
 // SetDefaultVrrpPriority Set default priority.
-func (iface *Interface) SetDefaultVrrpPriority(subifname string, vrid uint8) {
+func (iface *Interface) SetDefaultVrrpPriority(subifname string, af AddressFamily, vrid uint8) {
 	iface.lock.Lock()
 	defer iface.lock.Unlock()
 
 	subiface, ret := iface.Subinterfaces[subifname]
 	if ret {
-		subiface.SetDefaultVrrpPriority(vrid)
+		subiface.SetDefaultVrrpPriority(af, vrid)
 	}
 }
 
 // SetVrrpPreempt Set preempt.
-func (iface *Interface) SetVrrpPreempt(subifname string, vrid uint8, preempt bool) {
+func (iface *Interface) SetVrrpPreempt(subifname string, af AddressFamily, vrid uint8, preempt bool) {
 	iface.lock.Lock()
 	defer iface.lock.Unlock()
 
 	subiface, ret := iface.Subinterfaces[subifname]
 	if ret {
-		subiface.SetVrrpPreempt(vrid, preempt)
+		subiface.SetVrrpPreempt(af, vrid, preempt)
 	} else {
-		iface.AddSubinterface(subifname)
-		iface.Subinterfaces[subifname].SetVrrpPreempt(vrid, preempt)
+		iface.addSubinterfaceNoLock(subifname).SetVrrpPreempt(af, vrid, preempt)
 	}
 }
 
 // SetDefaultVrrpPreempt Set default preempt.
-func (iface *Interface) SetDefaultVrrpPreempt(subifname string, vrid uint8) {
+func (iface *Interface) SetDefaultVrrpPreempt(subifname string, af AddressFamily, vrid uint8) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetDefaultVrrpPreempt(af, vrid)
+	}
+}
+
+// SetVrrpPreemptDelay Set preempt delay.
+func (iface *Interface) SetVrrpPreemptDelay(subifname string, af AddressFamily, vrid uint8, delay uint16) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetVrrpPreemptDelay(af, vrid, delay)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).SetVrrpPreemptDelay(af, vrid, delay)
+	}
+}
+
+// SetDefaultVrrpPreemptDelay Set default preempt delay.
+func (iface *Interface) SetDefaultVrrpPreemptDelay(subifname string, af AddressFamily, vrid uint8) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetDefaultVrrpPreemptDelay(af, vrid)
+	}
+}
+
+// SetVrrpPriorityDecrement Set priority decrement.
+func (iface *Interface) SetVrrpPriorityDecrement(subifname string, af AddressFamily, vrid uint8, decrement uint8) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetVrrpPriorityDecrement(af, vrid, decrement)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).SetVrrpPriorityDecrement(af, vrid, decrement)
+	}
+}
+
+// SetDefaultVrrpPriorityDecrement Set default priority decrement.
+func (iface *Interface) SetDefaultVrrpPriorityDecrement(subifname string, af AddressFamily, vrid uint8) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetDefaultVrrpPriorityDecrement(af, vrid)
+	}
+}
+
+// AddVrrpTrackInterface Add tracked interface.
+func (iface *Interface) AddVrrpTrackInterface(subifname string, af AddressFamily, vrid uint8, ifname string) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.AddVrrpTrackInterface(af, vrid, ifname)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).AddVrrpTrackInterface(af, vrid, ifname)
+	}
+}
+
+// DeleteVrrpTrackInterface Delete tracked interface.
+func (iface *Interface) DeleteVrrpTrackInterface(subifname string, af AddressFamily, vrid uint8, ifname string) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.DeleteVrrpTrackInterface(af, vrid, ifname)
+	}
+}
+
+// AddVrrpHealthCheck Add health check.
+func (iface *Interface) AddVrrpHealthCheck(subifname string, af AddressFamily, vrid uint8, name string) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.AddVrrpHealthCheck(af, vrid, name)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).AddVrrpHealthCheck(af, vrid, name)
+	}
+}
+
+// DeleteVrrpHealthCheck Delete health check.
+func (iface *Interface) DeleteVrrpHealthCheck(subifname string, af AddressFamily, vrid uint8, name string) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.DeleteVrrpHealthCheck(af, vrid, name)
+	}
+}
+
+// SetVrrpReachabilityDecrement Set priority decrement of reachability.
+func (iface *Interface) SetVrrpReachabilityDecrement(subifname string, af AddressFamily, vrid uint8, decrement uint8) {
 	iface.lock.Lock()
 	defer iface.lock.Unlock()
 
 	subiface, ret := iface.Subinterfaces[subifname]
 	if ret {
-		subiface.SetDefaultVrrpPreempt(vrid)
+		subiface.SetVrrpReachabilityDecrement(af, vrid, decrement)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).SetVrrpReachabilityDecrement(af, vrid, decrement)
+	}
+}
+
+// SetDefaultVrrpReachabilityDecrement Set default priority decrement of reachability.
+func (iface *Interface) SetDefaultVrrpReachabilityDecrement(subifname string, af AddressFamily, vrid uint8) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetDefaultVrrpReachabilityDecrement(af, vrid)
+	}
+}
+
+// AddVrrpReachabilityTarget Add reachability target.
+func (iface *Interface) AddVrrpReachabilityTarget(subifname string, af AddressFamily, vrid uint8, addr net.IP) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.AddVrrpReachabilityTarget(af, vrid, addr)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).AddVrrpReachabilityTarget(af, vrid, addr)
+	}
+}
+
+// DeleteVrrpReachabilityTarget Delete reachability target.
+func (iface *Interface) DeleteVrrpReachabilityTarget(subifname string, af AddressFamily, vrid uint8, addr net.IP) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.DeleteVrrpReachabilityTarget(af, vrid, addr)
+	}
+}
+
+// SetVrrpSyncGroup Set sync group.
+func (iface *Interface) SetVrrpSyncGroup(subifname string, af AddressFamily, vrid uint8, group string) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetVrrpSyncGroup(af, vrid, group)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).SetVrrpSyncGroup(af, vrid, group)
+	}
+}
+
+// DeleteVrrpSyncGroup Delete sync group.
+func (iface *Interface) DeleteVrrpSyncGroup(subifname string, af AddressFamily, vrid uint8) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.DeleteVrrpSyncGroup(af, vrid)
+	}
+}
+
+// SetVrrpNotifyScript Set notify script.
+func (iface *Interface) SetVrrpNotifyScript(subifname string, af AddressFamily, vrid uint8, name string) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetVrrpNotifyScript(af, vrid, name)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).SetVrrpNotifyScript(af, vrid, name)
+	}
+}
+
+// DeleteVrrpNotifyScript Delete notify script.
+func (iface *Interface) DeleteVrrpNotifyScript(subifname string, af AddressFamily, vrid uint8) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.DeleteVrrpNotifyScript(af, vrid)
+	}
+}
+
+// SetVrrpAccept Set accept.
+func (iface *Interface) SetVrrpAccept(subifname string, af AddressFamily, vrid uint8, accept bool) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetVrrpAccept(af, vrid, accept)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).SetVrrpAccept(af, vrid, accept)
+	}
+}
+
+// SetDefaultVrrpAccept Set default accept.
+func (iface *Interface) SetDefaultVrrpAccept(subifname string, af AddressFamily, vrid uint8) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetDefaultVrrpAccept(af, vrid)
+	}
+}
+
+// SetVrrpAllowOutOfPrefix Set allow out of prefix.
+func (iface *Interface) SetVrrpAllowOutOfPrefix(subifname string, af AddressFamily, vrid uint8, allowOutOfPrefix bool) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetVrrpAllowOutOfPrefix(af, vrid, allowOutOfPrefix)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).SetVrrpAllowOutOfPrefix(af, vrid, allowOutOfPrefix)
+	}
+}
+
+// SetDefaultVrrpAllowOutOfPrefix Set default allow out of prefix.
+func (iface *Interface) SetDefaultVrrpAllowOutOfPrefix(subifname string, af AddressFamily, vrid uint8) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetDefaultVrrpAllowOutOfPrefix(af, vrid)
 	}
 }
 
 // SetVrrpInterval Set interval.
-func (iface *Interface) SetVrrpInterval(subifname string, vrid uint8, interval uint16) {
+func (iface *Interface) SetVrrpInterval(subifname string, af AddressFamily, vrid uint8, interval uint16) {
 	iface.lock.Lock()
 	defer iface.lock.Unlock()
 
 	subiface, ret := iface.Subinterfaces[subifname]
 	if ret {
-		subiface.SetVrrpInterval(vrid, interval)
+		subiface.SetVrrpInterval(af, vrid, interval)
 	} else {
-		iface.AddSubinterface(subifname)
-		iface.Subinterfaces[subifname].SetVrrpInterval(vrid, interval)
+		iface.addSubinterfaceNoLock(subifname).SetVrrpInterval(af, vrid, interval)
 	}
 }
 
 // SetDefaultVrrpInterval Set default interval.
-func (iface *Interface) SetDefaultVrrpInterval(subifname string, vrid uint8) {
+func (iface *Interface) SetDefaultVrrpInterval(subifname string, af AddressFamily, vrid uint8) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetDefaultVrrpInterval(af, vrid)
+	}
+}
+
+// SetVrrpVersion Set version.
+func (iface *Interface) SetVrrpVersion(subifname string, af AddressFamily, vrid uint8, version string) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetVrrpVersion(af, vrid, version)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).SetVrrpVersion(af, vrid, version)
+	}
+}
+
+// SetDefaultVrrpVersion Set default version.
+func (iface *Interface) SetDefaultVrrpVersion(subifname string, af AddressFamily, vrid uint8) {
 	iface.lock.Lock()
 	defer iface.lock.Unlock()
 
 	subiface, ret := iface.Subinterfaces[subifname]
 	if ret {
-		subiface.SetDefaultVrrpInterval(vrid)
+		subiface.SetDefaultVrrpVersion(af, vrid)
 	}
 }
 
 // AddVrrpVirtualAddress Add virtual address.
-func (iface *Interface) AddVrrpVirtualAddress(subifname string, vrid uint8, addr net.IP) {
+func (iface *Interface) AddVrrpVirtualAddress(subifname string, af AddressFamily, vrid uint8, addr net.IP) {
 	iface.lock.Lock()
 	defer iface.lock.Unlock()
 
 	subiface, ret := iface.Subinterfaces[subifname]
 	if ret {
-		subiface.AddVrrpVirtualAddress(vrid, addr)
+		subiface.AddVrrpVirtualAddress(af, vrid, addr)
 	} else {
-		iface.AddSubinterface(subifname)
-		iface.Subinterfaces[subifname].AddVrrpVirtualAddress(vrid, addr)
+		iface.addSubinterfaceNoLock(subifname).AddVrrpVirtualAddress(af, vrid, addr)
 	}
 }
 
 // DeleteVrrpVirtualAddress Delete virtual address.
-func (iface *Interface) DeleteVrrpVirtualAddress(subifname string, vrid uint8, addr net.IP) {
+func (iface *Interface) DeleteVrrpVirtualAddress(subifname string, af AddressFamily, vrid uint8, addr net.IP) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.DeleteVrrpVirtualAddress(af, vrid, addr)
+	}
+}
+
+// SetVrrpVirtualLinkLocal Set virtual link-local address(IPv6).
+func (iface *Interface) SetVrrpVirtualLinkLocal(subifname string, vrid uint8, addr net.IP) {
+	iface.lock.Lock()
+	defer iface.lock.Unlock()
+
+	subiface, ret := iface.Subinterfaces[subifname]
+	if ret {
+		subiface.SetVrrpVirtualLinkLocal(vrid, addr)
+	} else {
+		iface.addSubinterfaceNoLock(subifname).SetVrrpVirtualLinkLocal(vrid, addr)
+	}
+}
+
+// DeleteVrrpVirtualLinkLocal Delete virtual link-local address(IPv6).
+func (iface *Interface) DeleteVrrpVirtualLinkLocal(subifname string, vrid uint8) {
 	iface.lock.Lock()
 	defer iface.lock.Unlock()
 
 	subiface, ret := iface.Subinterfaces[subifname]
 	if ret {
-		subiface.DeleteVrrpVirtualAddress(vrid, addr)
+		subiface.DeleteVrrpVirtualLinkLocal(vrid)
 	}
 }
 
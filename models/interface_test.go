@@ -31,10 +31,11 @@ type testInterfaceTestSuite struct {
 func createSubinterface1(suite *testInterfaceTestSuite) *Subinterface {
 	vrrp1 := NewVRRP()
 	vrrp1.Vrid = 1
-	vrrp1.Priority = 255
+	vrrp1.Priority = 254
 	vrrp1.Interval = 100
 	vrrp1.Preempt = true
 	vrrp1.VirtualAddresses = []net.IP{net.ParseIP("192.168.0.1").To4(), net.ParseIP("10.0.0.1").To4()}
+	vrrp1.AllowOutOfPrefix = true
 
 	vrrp2 := NewVRRP()
 	vrrp2.Vrid = 2
@@ -42,6 +43,7 @@ func createSubinterface1(suite *testInterfaceTestSuite) *Subinterface {
 	vrrp2.Interval = 500
 	vrrp2.Preempt = false
 	vrrp2.VirtualAddresses = []net.IP{net.ParseIP("10.0.0.10").To4(), net.ParseIP("192.168.0.10").To4()}
+	vrrp2.AllowOutOfPrefix = true
 
 	subiface := NewSubinterface()
 	subiface.Name = "subinterface01"
@@ -66,13 +68,15 @@ func createSubinterface2(suite *testInterfaceTestSuite) *Subinterface {
 	vrrp1.Interval = 1000
 	vrrp1.Preempt = true
 	vrrp1.VirtualAddresses = []net.IP{net.ParseIP("192.168.0.1").To4(), net.ParseIP("10.0.0.1").To4()}
+	vrrp1.AllowOutOfPrefix = true
 
 	vrrp2 := NewVRRP()
 	vrrp2.Vrid = 20
-	vrrp2.Priority = 255
-	vrrp2.Interval = 5000
+	vrrp2.Priority = 254
+	vrrp2.Interval = 4000
 	vrrp2.Preempt = false
 	vrrp2.VirtualAddresses = []net.IP{net.ParseIP("10.0.0.10").To4(), net.ParseIP("192.168.0.10").To4()}
+	vrrp2.AllowOutOfPrefix = true
 
 	subiface := NewSubinterface()
 	subiface.Name = "subinterface02"
@@ -118,6 +122,16 @@ func (suite *testInterfaceTestSuite) TestInterfaceCopy() {
 	suite.True(reflect.DeepEqual(src, dst))
 }
 
+func (suite *testInterfaceTestSuite) TestInterfaceSetSubifNotExist() {
+	iface := NewInterface()
+	iface.SetSubifIP("iface01-0", net.ParseIP("172.16.0.1"))
+	iface.SetVrrpAccept("iface01-1", AddressFamilyIPv4, 1, true)
+
+	suite.Equal("iface01-0", iface.Subinterfaces["iface01-0"].Name)
+	suite.Equal(net.ParseIP("172.16.0.1"), iface.Subinterfaces["iface01-0"].IP)
+	suite.True(iface.Subinterfaces["iface01-1"].VRRPs[1].Accept)
+}
+
 func TestInterfaceTestSuite(t *testing.T) {
 	suite.Run(t, new(testInterfaceTestSuite))
 }
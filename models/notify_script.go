@@ -0,0 +1,104 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// NotifyScript model
+// Commands are run on transition to each state, empty if not run.
+type NotifyScript struct {
+	Name       string
+	Master     string
+	Backup     string
+	Fault      string
+	Initialize string
+	Timeout    uint16
+	lock       sync.RWMutex
+}
+
+// NewNotifyScript New NotifyScript model.
+func NewNotifyScript() *NotifyScript {
+	return &NotifyScript{
+		Name:       "",
+		Master:     "",
+		Backup:     "",
+		Fault:      "",
+		Initialize: "",
+		Timeout:    DefaultNotifyScriptTimeout,
+	}
+}
+
+// IsValid Reports whether NotifyScript represents a valid value.
+func (ns *NotifyScript) IsValid() bool {
+	return len(ns.Validate()) == 0
+}
+
+// Validate Validate NotifyScript, and returns errors.
+func (ns *NotifyScript) Validate() ValidationErrors {
+	ns.lock.RLock()
+	defer ns.lock.RUnlock()
+
+	errs := ValidationErrors{}
+	field := strings.TrimSpace("notify-scripts " + ns.Name)
+	if ns.Name == "" {
+		errs = append(errs, newValidationError(field+" name", "is required"))
+	}
+	if ns.Master == "" && ns.Backup == "" && ns.Fault == "" && ns.Initialize == "" {
+		errs = append(errs, newValidationError(field,
+			"at least one of master, backup, fault and initialize is required"))
+	}
+	if ns.Timeout == 0 {
+		errs = append(errs, newValidationError(field+" timeout", "must be greater than 0"))
+	}
+
+	return errs
+}
+
+// Copy Copy NotifyScript model.
+func (ns *NotifyScript) Copy() *NotifyScript {
+	ns.lock.RLock()
+	defer ns.lock.RUnlock()
+
+	return &NotifyScript{
+		Name:       ns.Name,
+		Master:     ns.Master,
+		Backup:     ns.Backup,
+		Fault:      ns.Fault,
+		Initialize: ns.Initialize,
+		Timeout:    ns.Timeout,
+	}
+}
+
+// String Returns a string representation of the NotifyScript model.
+func (ns *NotifyScript) String() string {
+	ns.lock.RLock()
+	defer ns.lock.RUnlock()
+
+	var str string
+	str = fmt.Sprintf("Name: %s", ns.Name)
+	str = fmt.Sprintf("%s, Master: %s", str, ns.Master)
+	str = fmt.Sprintf("%s, Backup: %s", str, ns.Backup)
+	str = fmt.Sprintf("%s, Fault: %s", str, ns.Fault)
+	str = fmt.Sprintf("%s, Initialize: %s", str, ns.Initialize)
+	str = fmt.Sprintf("%s, Timeout: %d", str, ns.Timeout)
+
+	return str
+}
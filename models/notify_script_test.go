@@ -0,0 +1,67 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testNotifyScriptTestSuite struct {
+	suite.Suite
+}
+
+func (suite *testNotifyScriptTestSuite) TestNotifyScriptNewNotifyScript() {
+	ns := NewNotifyScript()
+	suite.Equal(uint16(DefaultNotifyScriptTimeout), ns.Timeout)
+	suite.False(ns.IsValid())
+}
+
+func (suite *testNotifyScriptTestSuite) TestNotifyScriptIsValid() {
+	ns := NewNotifyScript()
+	ns.Name = "script1"
+	suite.False(ns.IsValid())
+
+	ns.Backup = "/usr/bin/notify.sh"
+	suite.True(ns.IsValid())
+
+	ns.Timeout = 0
+	suite.False(ns.IsValid())
+}
+
+func (suite *testNotifyScriptTestSuite) TestNotifyScriptCopy() {
+	ns := NewNotifyScript()
+	ns.Name = "script1"
+	ns.Master = "/usr/bin/master.sh"
+	ns.Backup = "/usr/bin/backup.sh"
+	ns.Fault = "/usr/bin/fault.sh"
+	ns.Initialize = "/usr/bin/initialize.sh"
+	ns.Timeout = 5
+
+	dst := ns.Copy()
+	suite.Equal(ns.Name, dst.Name)
+	suite.Equal(ns.Master, dst.Master)
+	suite.Equal(ns.Backup, dst.Backup)
+	suite.Equal(ns.Fault, dst.Fault)
+	suite.Equal(ns.Initialize, dst.Initialize)
+	suite.Equal(ns.Timeout, dst.Timeout)
+}
+
+func TestNotifyScriptTestSuite(t *testing.T) {
+	suite.Run(t, new(testNotifyScriptTestSuite))
+}
@@ -25,45 +25,179 @@ import (
 
 // Subinterface model
 type Subinterface struct {
-	Name   string
-	Index  uint64
-	IP     net.IP
-	Prefix uint32
-	MAC    net.HardwareAddr
-	VRRPs  map[uint8]*VRRP
-	lock   sync.RWMutex
+	Name       string
+	Index      uint64
+	IP         net.IP
+	Prefix     uint32
+	IPv6       net.IP
+	IPv6Prefix uint32
+	MAC        net.HardwareAddr
+	VRRPs      map[uint8]*VRRP
+	IPv6VRRPs  map[uint8]*VRRP
+	lock       sync.RWMutex
 }
 
 // NewSubinterface New Subinterface model.
 func NewSubinterface() *Subinterface {
 	return &Subinterface{
-		Name:   "",
-		Index:  math.MaxUint64,
-		IP:     nil,
-		Prefix: math.MaxUint32,
-		MAC:    nil,
-		VRRPs:  map[uint8]*VRRP{},
+		Name:       "",
+		Index:      math.MaxUint64,
+		IP:         nil,
+		Prefix:     math.MaxUint32,
+		IPv6:       nil,
+		IPv6Prefix: math.MaxUint32,
+		MAC:        nil,
+		VRRPs:      map[uint8]*VRRP{},
+		IPv6VRRPs:  map[uint8]*VRRP{},
 	}
 }
 
 // IsValid Reports whether Subinterface represents a valid value.
 func (subif *Subinterface) IsValid() bool {
+	return len(subif.Validate()) == 0
+}
+
+// Validate Validate Subinterface, and returns errors.
+func (subif *Subinterface) Validate() ValidationErrors {
 	subif.lock.RLock()
 	defer subif.lock.RUnlock()
 
-	if len(subif.Name) > 0 && subif.IP != nil &&
-		subif.Index >= 0 && subif.Index <= math.MaxUint32 &&
-		subif.Prefix >= 0 && subif.Prefix <= 32 &&
-		len(subif.VRRPs) >= 0 {
-		for _, vrrp := range subif.VRRPs {
-			if vrrp.IsValid() == false {
-				return false
+	errs := ValidationErrors{}
+	if len(subif.Name) == 0 {
+		errs = append(errs, newValidationError("name", "is required"))
+	}
+	if subif.IP == nil && subif.IPv6 == nil {
+		errs = append(errs, newValidationError("address",
+			"IPv4 or IPv6 address is required"))
+	}
+	if subif.Index > math.MaxUint32 {
+		errs = append(errs, newValidationError("index", "is required"))
+	}
+
+	// IPv4
+	if subif.IP != nil && subif.Prefix > 32 {
+		errs = append(errs, newValidationError("ipv4 prefix-length",
+			"%d must be 0-32", subif.Prefix))
+	}
+	for _, vrid := range sortedVrids(subif.VRRPs) {
+		vrrp := subif.VRRPs[vrid]
+		if subif.IP == nil {
+			errs = append(errs, &ValidationError{
+				Vrid:   vrid,
+				Reason: "IPv4 address of subinterface is required",
+			})
+		} else {
+			errs = append(errs, validateSubifVRRP(subif.IP, subif.Prefix, 32,
+				vrid, vrrp)...)
+		}
+		errs = append(errs, vrrp.Validate()...)
+	}
+
+	// IPv6
+	if subif.IPv6 != nil && subif.IPv6Prefix > 128 {
+		errs = append(errs, newValidationError("ipv6 prefix-length",
+			"%d must be 0-128", subif.IPv6Prefix))
+	}
+	for _, vrid := range sortedVrids(subif.IPv6VRRPs) {
+		vrrp := subif.IPv6VRRPs[vrid]
+		if subif.IPv6 == nil {
+			errs = append(errs, &ValidationError{
+				AF:     AddressFamilyIPv6,
+				Vrid:   vrid,
+				Reason: "IPv6 address of subinterface is required",
+			})
+		}
+		vrrpErrs := vrrp.Validate()
+		if subif.IPv6 != nil {
+			vrrpErrs = append(vrrpErrs, validateSubifVRRP(subif.IPv6,
+				subif.IPv6Prefix, 128, vrid, vrrp)...)
+		}
+		// VRRPv2 is IPv4 only.
+		if vrrp.Version != VRRPVersion3 {
+			vrrpErrs = append(vrrpErrs, &ValidationError{
+				Vrid:   vrid,
+				Field:  "version",
+				Reason: fmt.Sprintf("version %v is IPv4 only", vrrp.Version),
+			})
+		}
+		for _, e := range vrrpErrs {
+			e.AF = AddressFamilyIPv6
+		}
+		errs = append(errs, vrrpErrs...)
+	}
+
+	for _, e := range errs {
+		e.Subinterface = subif.Name
+	}
+
+	return errs
+}
+
+// validateSubifVRRP Validate VRRP with address of subinterface.
+func validateSubifVRRP(ip net.IP, prefix uint32, bits int,
+	vrid uint8, vrrp *VRRP) ValidationErrors {
+	errs := ValidationErrors{}
+	newError := func(field string, format string, a ...interface{}) {
+		errs = append(errs, &ValidationError{
+			Vrid:   vrid,
+			Field:  field,
+			Reason: fmt.Sprintf(format, a...),
+		})
+	}
+
+	vrrp.lock.RLock()
+	defer vrrp.lock.RUnlock()
+
+	owner := false
+	for _, vaddr := range vrrp.VirtualAddresses {
+		if vaddr.Equal(ip) {
+			owner = true
+		}
+	}
+	if vrrp.Priority == OwnerPriority && !owner {
+		newError("priority", "%d is allowed only for address owner", OwnerPriority)
+	}
+	// VifEntry of DataPlane has no accept-mode, and only address owner
+	// accepts packets to virtual addresses as its own addresses.
+	if vrrp.Accept && !owner {
+		newError("accept-mode", "true is allowed only for address owner, "+
+			"DataPlane doesn't support accept-mode")
+	}
+
+	if prefix > uint32(bits) {
+		return errs
+	}
+	network := &net.IPNet{
+		IP:   ip.Mask(net.CIDRMask(int(prefix), bits)),
+		Mask: net.CIDRMask(int(prefix), bits),
+	}
+
+	if !vrrp.AllowOutOfPrefix {
+		for _, vaddr := range vrrp.VirtualAddresses {
+			if !network.Contains(vaddr) {
+				newError("virtual-address", "%v is out of prefix %v", vaddr, network)
 			}
 		}
-		return true
 	}
 
-	return false
+	onLink := func(addr net.IP) bool {
+		return network.Contains(addr) ||
+			(bits == 128 && addr.To4() == nil && addr.IsLinkLocalUnicast())
+	}
+
+	// MAC address of gateway is resolved by ARP/NS, so it must be on-link.
+	gateway := vrrp.ReachabilityGateway
+	if gateway != nil && !onLink(gateway) {
+		newError("reachability-gateway", "%v is not on-link of prefix %v", gateway, network)
+	}
+	for _, target := range vrrp.ReachabilityTargets {
+		if !onLink(target) && gateway == nil {
+			newError("reachability-target",
+				"%v is not on-link of prefix %v, and requires gateway", target, network)
+		}
+	}
+
+	return errs
 }
 
 // Copy Copy Subinterface model.
@@ -79,14 +213,48 @@ func (subif *Subinterface) Copy() *Subinterface {
 		vrrps[vrrp.Vrid] = vrrp.Copy()
 	}
 
+	ipv6Vrrps := map[uint8]*VRRP{}
+	for _, vrrp := range subif.IPv6VRRPs {
+		ipv6Vrrps[vrrp.Vrid] = vrrp.Copy()
+	}
+
 	return &Subinterface{
-		Name:   subif.Name,
-		Index:  subif.Index,
-		IP:     dupIP(subif.IP),
-		Prefix: subif.Prefix,
-		MAC:    mac,
-		VRRPs:  vrrps,
+		Name:       subif.Name,
+		Index:      subif.Index,
+		IP:         dupIP(subif.IP),
+		Prefix:     subif.Prefix,
+		IPv6:       dupIP(subif.IPv6),
+		IPv6Prefix: subif.IPv6Prefix,
+		MAC:        mac,
+		VRRPs:      vrrps,
+		IPv6VRRPs:  ipv6Vrrps,
+	}
+}
+
+func (subif *Subinterface) vrrpTableNoLock(af AddressFamily) map[uint8]*VRRP {
+	if af == AddressFamilyIPv6 {
+		return subif.IPv6VRRPs
 	}
+	return subif.VRRPs
+}
+
+// VRRPTable Get VRRP table of address family.
+func (subif *Subinterface) VRRPTable(af AddressFamily) map[uint8]*VRRP {
+	subif.lock.RLock()
+	defer subif.lock.RUnlock()
+
+	return subif.vrrpTableNoLock(af)
+}
+
+// Address Get IP and prefix of address family.
+func (subif *Subinterface) Address(af AddressFamily) (net.IP, uint32) {
+	subif.lock.RLock()
+	defer subif.lock.RUnlock()
+
+	if af == AddressFamilyIPv6 {
+		return subif.IPv6, subif.IPv6Prefix
+	}
+	return subif.IP, subif.Prefix
 }
 
 // SetIndex Set Index.
@@ -137,125 +305,242 @@ func (subif *Subinterface) DeletePrefix() {
 	subif.Prefix = math.MaxUint32
 }
 
-// AddVrrp Add VRRP.
-func (subif *Subinterface) AddVrrp(vrid uint8) {
+// SetIPv6 Set IPv6.
+func (subif *Subinterface) SetIPv6(ip net.IP) {
 	subif.lock.Lock()
 	defer subif.lock.Unlock()
 
-	_, ret := subif.VRRPs[vrid]
-	if ret == false {
-		vrrp := NewVRRP()
-		vrrp.Vrid = vrid
-		subif.VRRPs[vrid] = vrrp
-	}
+	subif.IPv6 = ip
 }
 
-// DeleteVrrp Delete VRRP.
-func (subif *Subinterface) DeleteVrrp(vrid uint8) {
+// DeleteIPv6 Delete IPv6.
+func (subif *Subinterface) DeleteIPv6() {
 	subif.lock.Lock()
 	defer subif.lock.Unlock()
 
-	delete(subif.VRRPs, vrid)
+	subif.IPv6 = nil
 }
 
-// SetVrrpPriority Set VRRP priority.
-func (subif *Subinterface) SetVrrpPriority(vrid uint8, priority uint8) {
+// SetIPv6Prefix Set IPv6 prefix.
+func (subif *Subinterface) SetIPv6Prefix(prefix uint32) {
 	subif.lock.Lock()
 	defer subif.lock.Unlock()
 
-	vrrp, ret := subif.VRRPs[vrid]
-	if ret {
-		vrrp.SetPriority(priority)
-	} else {
-		subif.AddVrrp(vrid)
-		subif.VRRPs[vrid].SetPriority(priority)
-	}
+	subif.IPv6Prefix = prefix
 }
 
-// SetDefaultVrrpPriority Set default VRRP priority.
-func (subif *Subinterface) SetDefaultVrrpPriority(vrid uint8) {
+// DeleteIPv6Prefix Delete IPv6 prefix.
+func (subif *Subinterface) DeleteIPv6Prefix() {
 	subif.lock.Lock()
 	defer subif.lock.Unlock()
 
-	vrrp, ret := subif.VRRPs[vrid]
-	if ret {
-		vrrp.SetDefaultPriority()
-	}
+	subif.IPv6Prefix = math.MaxUint32
 }
 
-// SetVrrpPreempt Set VRRP preempt.
-func (subif *Subinterface) SetVrrpPreempt(vrid uint8, preempt bool) {
+// AddVrrp Add VRRP.
+func (subif *Subinterface) AddVrrp(af AddressFamily, vrid uint8) {
 	subif.lock.Lock()
 	defer subif.lock.Unlock()
 
-	vrrp, ret := subif.VRRPs[vrid]
-	if ret {
-		vrrp.SetPreempt(preempt)
-	} else {
-		subif.AddVrrp(vrid)
-		subif.VRRPs[vrid].SetPreempt(preempt)
+	subif.addVrrpNoLock(af, vrid)
+}
+
+// addVrrpNoLock Add VRRP if it doesn't exist, and returns it.
+func (subif *Subinterface) addVrrpNoLock(af AddressFamily, vrid uint8) *VRRP {
+	vrrps := subif.vrrpTableNoLock(af)
+	vrrp, ret := vrrps[vrid]
+	if ret == false {
+		vrrp = NewVRRP()
+		vrrp.Vrid = vrid
+		vrrps[vrid] = vrrp
 	}
+	return vrrp
 }
 
-// SetDefaultVrrpPreempt Set default VRRP preempt.
-func (subif *Subinterface) SetDefaultVrrpPreempt(vrid uint8) {
+// UpdateVrrp Update VRRP by fn, and add VRRP if it doesn't exist.
+func (subif *Subinterface) UpdateVrrp(af AddressFamily, vrid uint8, fn func(vrrp *VRRP)) {
 	subif.lock.Lock()
 	defer subif.lock.Unlock()
 
-	vrrp, ret := subif.VRRPs[vrid]
-	if ret {
-		vrrp.SetDefaultPreempt()
-	}
+	fn(subif.addVrrpNoLock(af, vrid))
 }
 
-// SetVrrpInterval Set VRRP interval.
-func (subif *Subinterface) SetVrrpInterval(vrid uint8, interval uint16) {
+// UpdateExistingVrrp Update VRRP by fn, only if it exists.
+func (subif *Subinterface) UpdateExistingVrrp(af AddressFamily, vrid uint8, fn func(vrrp *VRRP)) {
 	subif.lock.Lock()
 	defer subif.lock.Unlock()
 
-	vrrp, ret := subif.VRRPs[vrid]
-	if ret {
-		vrrp.SetInterval(interval)
-	} else {
-		subif.AddVrrp(vrid)
-		subif.VRRPs[vrid].SetInterval(interval)
+	if vrrp, ok := subif.vrrpTableNoLock(af)[vrid]; ok {
+		fn(vrrp)
 	}
 }
 
-// SetDefaultVrrpInterval Set default VRRP interval.
-func (subif *Subinterface) SetDefaultVrrpInterval(vrid uint8) {
+// DeleteVrrp Delete VRRP.
+func (subif *Subinterface) DeleteVrrp(af AddressFamily, vrid uint8) {
 	subif.lock.Lock()
 	defer subif.lock.Unlock()
 
-	vrrp, ret := subif.VRRPs[vrid]
-	if ret {
-		vrrp.SetDefaultInterval()
-	}
+	delete(subif.vrrpTableNoLock(af), vrid)
 }
 
-// AddVrrpVirtualAddress Add VRRP virtual address.
-func (subif *Subinterface) AddVrrpVirtualAddress(vrid uint8, addr net.IP) {
-	subif.lock.Lock()
-	defer subif.lock.Unlock()
+// SetVrrpPriority Set VRRP priority.
+func (subif *Subinterface) SetVrrpPriority(af AddressFamily, vrid uint8, priority uint8) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetPriority(priority) })
+}
 
-	vrrp, ret := subif.VRRPs[vrid]
-	if ret {
-		vrrp.AddVirtualAddress(addr)
-	} else {
-		subif.AddVrrp(vrid)
-		subif.VRRPs[vrid].AddVirtualAddress(addr)
-	}
+// SetDefaultVrrpPriority Set default VRRP priority.
+func (subif *Subinterface) SetDefaultVrrpPriority(af AddressFamily, vrid uint8) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetDefaultPriority() })
+}
+
+// SetVrrpPreempt Set VRRP preempt.
+func (subif *Subinterface) SetVrrpPreempt(af AddressFamily, vrid uint8, preempt bool) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetPreempt(preempt) })
+}
+
+// SetDefaultVrrpPreempt Set default VRRP preempt.
+func (subif *Subinterface) SetDefaultVrrpPreempt(af AddressFamily, vrid uint8) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetDefaultPreempt() })
+}
+
+// SetVrrpPreemptDelay Set VRRP preempt delay.
+func (subif *Subinterface) SetVrrpPreemptDelay(af AddressFamily, vrid uint8, delay uint16) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetPreemptDelay(delay) })
+}
+
+// SetDefaultVrrpPreemptDelay Set default VRRP preempt delay.
+func (subif *Subinterface) SetDefaultVrrpPreemptDelay(af AddressFamily, vrid uint8) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetDefaultPreemptDelay() })
+}
+
+// SetVrrpPriorityDecrement Set VRRP priority decrement.
+func (subif *Subinterface) SetVrrpPriorityDecrement(af AddressFamily, vrid uint8, decrement uint8) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetPriorityDecrement(decrement) })
+}
+
+// SetDefaultVrrpPriorityDecrement Set default VRRP priority decrement.
+func (subif *Subinterface) SetDefaultVrrpPriorityDecrement(af AddressFamily, vrid uint8) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetDefaultPriorityDecrement() })
+}
+
+// AddVrrpTrackInterface Add VRRP tracked interface.
+func (subif *Subinterface) AddVrrpTrackInterface(af AddressFamily, vrid uint8, ifname string) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.AddTrackInterface(ifname) })
+}
+
+// DeleteVrrpTrackInterface Delete VRRP tracked interface.
+func (subif *Subinterface) DeleteVrrpTrackInterface(af AddressFamily, vrid uint8, ifname string) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.DeleteTrackInterface(ifname) })
+}
+
+// AddVrrpHealthCheck Add VRRP health check.
+func (subif *Subinterface) AddVrrpHealthCheck(af AddressFamily, vrid uint8, name string) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.AddHealthCheck(name) })
+}
+
+// DeleteVrrpHealthCheck Delete VRRP health check.
+func (subif *Subinterface) DeleteVrrpHealthCheck(af AddressFamily, vrid uint8, name string) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.DeleteHealthCheck(name) })
+}
+
+// SetVrrpReachabilityDecrement Set VRRP priority decrement of reachability.
+func (subif *Subinterface) SetVrrpReachabilityDecrement(af AddressFamily, vrid uint8, decrement uint8) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetReachabilityDecrement(decrement) })
+}
+
+// SetDefaultVrrpReachabilityDecrement Set default VRRP priority decrement of reachability.
+func (subif *Subinterface) SetDefaultVrrpReachabilityDecrement(af AddressFamily, vrid uint8) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetDefaultReachabilityDecrement() })
+}
+
+// AddVrrpReachabilityTarget Add VRRP reachability target.
+func (subif *Subinterface) AddVrrpReachabilityTarget(af AddressFamily, vrid uint8, addr net.IP) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.AddReachabilityTarget(addr) })
+}
+
+// DeleteVrrpReachabilityTarget Delete VRRP reachability target.
+func (subif *Subinterface) DeleteVrrpReachabilityTarget(af AddressFamily, vrid uint8, addr net.IP) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.DeleteReachabilityTarget(addr) })
+}
+
+// SetVrrpSyncGroup Set VRRP sync group.
+func (subif *Subinterface) SetVrrpSyncGroup(af AddressFamily, vrid uint8, group string) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetSyncGroup(group) })
+}
+
+// DeleteVrrpSyncGroup Delete VRRP sync group.
+func (subif *Subinterface) DeleteVrrpSyncGroup(af AddressFamily, vrid uint8) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.DeleteSyncGroup() })
+}
+
+// SetVrrpNotifyScript Set VRRP notify script.
+func (subif *Subinterface) SetVrrpNotifyScript(af AddressFamily, vrid uint8, name string) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetNotifyScript(name) })
+}
+
+// DeleteVrrpNotifyScript Delete VRRP notify script.
+func (subif *Subinterface) DeleteVrrpNotifyScript(af AddressFamily, vrid uint8) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.DeleteNotifyScript() })
+}
+
+// SetVrrpAccept Set VRRP accept.
+func (subif *Subinterface) SetVrrpAccept(af AddressFamily, vrid uint8, accept bool) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetAccept(accept) })
+}
+
+// SetDefaultVrrpAccept Set default VRRP accept.
+func (subif *Subinterface) SetDefaultVrrpAccept(af AddressFamily, vrid uint8) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetDefaultAccept() })
+}
+
+// SetVrrpAllowOutOfPrefix Set VRRP allow out of prefix.
+func (subif *Subinterface) SetVrrpAllowOutOfPrefix(af AddressFamily, vrid uint8, allowOutOfPrefix bool) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetAllowOutOfPrefix(allowOutOfPrefix) })
+}
+
+// SetDefaultVrrpAllowOutOfPrefix Set default VRRP allow out of prefix.
+func (subif *Subinterface) SetDefaultVrrpAllowOutOfPrefix(af AddressFamily, vrid uint8) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetDefaultAllowOutOfPrefix() })
+}
+
+// SetVrrpInterval Set VRRP interval.
+func (subif *Subinterface) SetVrrpInterval(af AddressFamily, vrid uint8, interval uint16) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetInterval(interval) })
+}
+
+// SetDefaultVrrpInterval Set default VRRP interval.
+func (subif *Subinterface) SetDefaultVrrpInterval(af AddressFamily, vrid uint8) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetDefaultInterval() })
+}
+
+// SetVrrpVersion Set VRRP version.
+func (subif *Subinterface) SetVrrpVersion(af AddressFamily, vrid uint8, version string) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetVersion(version) })
+}
+
+// SetDefaultVrrpVersion Set default VRRP version.
+func (subif *Subinterface) SetDefaultVrrpVersion(af AddressFamily, vrid uint8) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.SetDefaultVersion() })
+}
+
+// AddVrrpVirtualAddress Add VRRP virtual address.
+func (subif *Subinterface) AddVrrpVirtualAddress(af AddressFamily, vrid uint8, addr net.IP) {
+	subif.UpdateVrrp(af, vrid, func(vrrp *VRRP) { vrrp.AddVirtualAddress(addr) })
 }
 
 // DeleteVrrpVirtualAddress Delete VRRP virtual address.
-func (subif *Subinterface) DeleteVrrpVirtualAddress(vrid uint8, addr net.IP) {
-	subif.lock.Lock()
-	defer subif.lock.Unlock()
+func (subif *Subinterface) DeleteVrrpVirtualAddress(af AddressFamily, vrid uint8, addr net.IP) {
+	subif.UpdateExistingVrrp(af, vrid, func(vrrp *VRRP) { vrrp.DeleteVirtualAddress(addr) })
+}
 
-	vrrp, ret := subif.VRRPs[vrid]
-	if ret {
-		vrrp.DeleteVirtualAddress(addr)
-	}
+// SetVrrpVirtualLinkLocal Set VRRP virtual link-local address(IPv6).
+func (subif *Subinterface) SetVrrpVirtualLinkLocal(vrid uint8, addr net.IP) {
+	subif.UpdateVrrp(AddressFamilyIPv6, vrid, func(vrrp *VRRP) { vrrp.SetVirtualLinkLocal(addr) })
+}
+
+// DeleteVrrpVirtualLinkLocal Delete VRRP virtual link-local address(IPv6).
+func (subif *Subinterface) DeleteVrrpVirtualLinkLocal(vrid uint8) {
+	subif.UpdateExistingVrrp(AddressFamilyIPv6, vrid, func(vrrp *VRRP) { vrrp.DeleteVirtualLinkLocal() })
 }
 
 // String Returns a string representation of the Interface model.
@@ -268,10 +553,15 @@ func (subif *Subinterface) String() string {
 	str = fmt.Sprintf("%s, Index: %d", str, subif.Index)
 	str = fmt.Sprintf("%s, IP: %s", str, subif.IP.String())
 	str = fmt.Sprintf("%s, Prefix: %d", str, subif.Prefix)
+	str = fmt.Sprintf("%s, IPv6: %s", str, subif.IPv6.String())
+	str = fmt.Sprintf("%s, IPv6Prefix: %d", str, subif.IPv6Prefix)
 	str = fmt.Sprintf("%s, MAC: %s", str, subif.MAC.String())
 	for _, vrrp := range subif.VRRPs {
 		str = fmt.Sprintf("%s, VRRPs(%d): {%s}", str, vrrp.Vrid, vrrp.String())
 	}
+	for _, vrrp := range subif.IPv6VRRPs {
+		str = fmt.Sprintf("%s, IPv6VRRPs(%d): {%s}", str, vrrp.Vrid, vrrp.String())
+	}
 
 	return str
 }
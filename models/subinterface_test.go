@@ -113,6 +113,67 @@ func (suite *testSubinterfaceTestSuite) TestSubinterfaceIsValid2() {
 	iface.Prefix = 32
 	vrrps = map[uint8]*VRRP{vrrp1.Vrid: vrrp1, vrrp2.Vrid: vrrp2}
 	iface.VRRPs = vrrps
+	// virtual addresses are out of prefix.
+	suite.False(iface.IsValid())
+
+	iface.Prefix = 0
+	suite.True(iface.IsValid())
+
+	// priority of non-owner.
+	vrrp2.Priority = OwnerPriority
+	suite.False(iface.IsValid())
+
+	vrrp2.Priority = 100
+	vrrp1.Priority = OwnerPriority
+	suite.True(iface.IsValid())
+
+	// accept-mode of non-owner.
+	vrrp2.Accept = true
+	suite.False(iface.IsValid())
+
+	vrrp2.Accept = false
+	vrrp1.Accept = true
+	suite.True(iface.IsValid())
+}
+
+func (suite *testSubinterfaceTestSuite) TestSubinterfaceIsValidReachabilityTarget() {
+	iface := NewSubinterface()
+	iface.Name = "iface01"
+	iface.Index = 0
+	iface.IP = net.ParseIP("192.168.0.1").To4()
+	iface.Prefix = 24
+	vrrp := NewVRRP()
+	vrrp.Vrid = 1
+	vrrp.VirtualAddresses = []net.IP{net.ParseIP("192.168.0.254").To4()}
+	iface.VRRPs = map[uint8]*VRRP{vrrp.Vrid: vrrp}
+
+	vrrp.AddReachabilityTarget(net.ParseIP("192.168.0.100"))
+	suite.True(iface.IsValid())
+
+	// off-link target requires gateway.
+	vrrp.AddReachabilityTarget(net.ParseIP("10.0.0.1"))
+	suite.False(iface.IsValid())
+	vrrp.SetReachabilityGateway(net.ParseIP("192.168.0.254"))
+	suite.True(iface.IsValid())
+
+	// off-link gateway.
+	vrrp.SetReachabilityGateway(net.ParseIP("10.0.0.254"))
+	suite.False(iface.IsValid())
+
+	iface.IPv6 = net.ParseIP("2001:db8::1")
+	iface.IPv6Prefix = 64
+	vrrp6 := NewVRRP()
+	vrrp6.Vrid = 1
+	vrrp6.VirtualAddresses = []net.IP{net.ParseIP("2001:db8::254")}
+	vrrp6.AddReachabilityTarget(net.ParseIP("fe80::1"))
+	vrrp6.AddReachabilityTarget(net.ParseIP("2001:db8::100"))
+	iface.VRRPs = map[uint8]*VRRP{}
+	iface.IPv6VRRPs = map[uint8]*VRRP{vrrp6.Vrid: vrrp6}
+	suite.True(iface.IsValid())
+
+	vrrp6.AddReachabilityTarget(net.ParseIP("2001:db8:1::100"))
+	suite.False(iface.IsValid())
+	vrrp6.SetReachabilityGateway(net.ParseIP("fe80::1"))
 	suite.True(iface.IsValid())
 }
 
@@ -122,6 +183,75 @@ func (suite *testSubinterfaceTestSuite) TestSubinterfaceCopy() {
 	suite.True(reflect.DeepEqual(src, dst))
 }
 
+func (suite *testSubinterfaceTestSuite) TestSubinterfaceIsValidIPv6() {
+	iface := NewSubinterface()
+	iface.Name = "iface01"
+	iface.Index = 0
+
+	vrrp := NewVRRP()
+	vrrp.Vrid = 1
+	vrrp.VirtualAddresses = []net.IP{net.ParseIP("2001:db8::1")}
+	suite.True(vrrp.IsValid())
+	iface.IPv6VRRPs = map[uint8]*VRRP{vrrp.Vrid: vrrp}
+	suite.False(iface.IsValid())
+
+	iface.IPv6 = net.ParseIP("2001:db8::10")
+	iface.IPv6Prefix = 129
+	suite.False(iface.IsValid())
+
+	iface.IPv6Prefix = 64
+	suite.True(iface.IsValid())
+
+	// VRRPv2 is IPv4 only.
+	vrrp.Version = VRRPVersion3Compat
+	suite.False(iface.IsValid())
+	vrrp.Version = VRRPVersion3
+
+	// IPv4 VRRP without IPv4 address.
+	iface.VRRPs = map[uint8]*VRRP{1: createVRRP1()}
+	suite.False(iface.IsValid())
+}
+
+func (suite *testSubinterfaceTestSuite) TestSubinterfaceCopyIPv6() {
+	src := createSubinterface(suite)
+	src.IPv6 = net.ParseIP("2001:db8::10")
+	src.IPv6Prefix = 64
+	vrrp := NewVRRP()
+	vrrp.Vrid = 1
+	vrrp.VirtualAddresses = []net.IP{net.ParseIP("2001:db8::1")}
+	vrrp.VirtualLinkLocal = net.ParseIP("fe80::1")
+	src.IPv6VRRPs = map[uint8]*VRRP{vrrp.Vrid: vrrp}
+
+	dst := src.Copy()
+	suite.True(reflect.DeepEqual(src, dst))
+	suite.Equal(vrrp.String(), dst.VRRPTable(AddressFamilyIPv6)[1].String())
+}
+
+func (suite *testSubinterfaceTestSuite) TestSubinterfaceSetVrrpNotExist() {
+	subif := NewSubinterface()
+	subif.SetVrrpAccept(AddressFamilyIPv4, 1, true)
+	subif.SetVrrpPriority(AddressFamilyIPv4, 2, 200)
+	subif.SetVrrpVirtualLinkLocal(3, net.ParseIP("fe80::1"))
+
+	suite.True(subif.VRRPs[1].Accept)
+	suite.Equal(uint8(1), subif.VRRPs[1].Vrid)
+	suite.Equal(uint8(200), subif.VRRPs[2].Priority)
+	suite.Equal(net.ParseIP("fe80::1"), subif.IPv6VRRPs[3].VirtualLinkLocal)
+}
+
+func (suite *testSubinterfaceTestSuite) TestSubinterfaceUpdateExistingVrrp() {
+	subif := NewSubinterface()
+	subif.SetDefaultVrrpPriority(AddressFamilyIPv4, 1)
+	subif.DeleteVrrpVirtualLinkLocal(1)
+	suite.Empty(subif.VRRPs)
+	suite.Empty(subif.IPv6VRRPs)
+
+	subif.SetVrrpPriority(AddressFamilyIPv4, 1, 200)
+	subif.SetDefaultVrrpPriority(AddressFamilyIPv4, 1)
+	suite.Equal(uint8(DefaultPriority), subif.VRRPs[1].Priority)
+	suite.Empty(subif.IPv6VRRPs)
+}
+
 func TestSubinterfaceTestSuite(t *testing.T) {
 	suite.Run(t, new(testSubinterfaceTestSuite))
 }
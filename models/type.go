@@ -29,6 +29,57 @@ const (
 	IfTypeTunnel
 )
 
+// AddressFamily Type of address family.
+type AddressFamily uint8
+
+const (
+	// AddressFamilyIPv4 IPv4.
+	AddressFamilyIPv4 AddressFamily = iota
+	// AddressFamilyIPv6 IPv6.
+	AddressFamilyIPv6
+)
+
+// AddressFamilies All address families.
+var AddressFamilies = []AddressFamily{AddressFamilyIPv4, AddressFamilyIPv6}
+
+func (af AddressFamily) String() string {
+	switch af {
+	case AddressFamilyIPv4:
+		return "ipv4"
+	case AddressFamilyIPv6:
+		return "ipv6"
+	default:
+		return "unknown"
+	}
+}
+
+// VRRPVersion Version of VRRP.
+type VRRPVersion uint8
+
+const (
+	// VRRPVersionUnknown Unknown.
+	VRRPVersionUnknown VRRPVersion = iota
+	// VRRPVersion2 VRRPv2(RFC 3768).
+	VRRPVersion2
+	// VRRPVersion3 VRRPv3(RFC 5798).
+	VRRPVersion3
+	// VRRPVersion3Compat VRRPv3 with VRRPv2 compatibility(RFC 5798 section 8.4).
+	VRRPVersion3Compat
+)
+
+func (v VRRPVersion) String() string {
+	switch v {
+	case VRRPVersion2:
+		return "2"
+	case VRRPVersion3:
+		return "3"
+	case VRRPVersion3Compat:
+		return "3-compat"
+	default:
+		return "unknown"
+	}
+}
+
 // VRRP
 const (
 	// DefaultPriority Default priority.
@@ -37,8 +88,62 @@ const (
 	DefaultPreempt = true
 	// DefaultAccept Default accept.
 	DefaultAccept = false
+	// DefaultPreemptDelay Default preempt delay(seconds).
+	DefaultPreemptDelay = 0
 	// DefaultInterval Default interval.
 	DefaultInterval = 100
+	// DefaultVersion Default version.
+	DefaultVersion = VRRPVersion3
+	// DefaultPriorityDecrement Default priority decrement.
+	DefaultPriorityDecrement = 0
+	// DefaultReachabilityDecrement Default priority decrement of reachability.
+	DefaultReachabilityDecrement = 0
+	// DefaultAllowOutOfPrefix Default allow virtual addresses out of prefix.
+	DefaultAllowOutOfPrefix = false
+	// OwnerPriority Priority of address owner.
+	OwnerPriority = 255
+	// MaxVirtualAddresses Max number of virtual addresses.
+	MaxVirtualAddresses = 255
+	// MinInterval Min advertisement interval(centiseconds).
+	MinInterval = 1
+	// MaxInterval Max advertisement interval(centiseconds).
+	MaxInterval = 4095
+)
+
+// HealthCheck
+const (
+	// DefaultHealthCheckInterval Default interval(seconds).
+	DefaultHealthCheckInterval = 5
+	// DefaultHealthCheckTimeout Default timeout(seconds).
+	DefaultHealthCheckTimeout = 3
+	// DefaultHealthCheckRise Default number of successes to be up.
+	DefaultHealthCheckRise = 1
+	// DefaultHealthCheckFall Default number of failures to be down.
+	DefaultHealthCheckFall = 1
+	// DefaultHealthCheckWeight Default weight(0: fault when down).
+	DefaultHealthCheckWeight = 0
+)
+
+// NotifyScript
+const (
+	// NotifyScriptGlobal Name of global notify script.
+	NotifyScriptGlobal = "global"
+	// DefaultNotifyScriptTimeout Default timeout(seconds).
+	DefaultNotifyScriptTimeout = 10
+)
+
+// Webhook
+const (
+	// WebhookEventStateChange Event of state change.
+	WebhookEventStateChange = "state-change"
+	// WebhookEventProtoError Event of protocol error(alarm).
+	WebhookEventProtoError = "proto-error"
+	// DefaultWebhookTimeout Default timeout of delivery(seconds).
+	DefaultWebhookTimeout = 5
+	// DefaultWebhookRetries Default number of retries of delivery.
+	DefaultWebhookRetries = 3
+	// DefaultWebhookQueueSize Default size of delivery queue.
+	DefaultWebhookQueueSize = 100
 )
 
 func toIfType(str string) IfType {
@@ -51,3 +156,16 @@ func toIfType(str string) IfType {
 		return IfTypeUnknown
 	}
 }
+
+func toVRRPVersion(str string) VRRPVersion {
+	switch strings.ToLower(str) {
+	case "2":
+		return VRRPVersion2
+	case "3":
+		return VRRPVersion3
+	case "3-compat":
+		return VRRPVersion3Compat
+	default:
+		return VRRPVersionUnknown
+	}
+}
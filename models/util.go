@@ -18,10 +18,50 @@ package models
 
 import (
 	"net"
+	"sort"
 )
 
 func dupIP(src net.IP) net.IP {
+	if src == nil {
+		return nil
+	}
+
 	dst := make(net.IP, len(src))
 	copy(dst, src)
 	return dst
 }
+
+// hasNetdev Report whether kernel netdev of interface exists.
+func hasNetdev(ifname string) bool {
+	_, err := net.InterfaceByName(ifname)
+	return err == nil
+}
+
+// ToAddressFamily Address family of IP.
+func ToAddressFamily(ip net.IP) AddressFamily {
+	if ip.To4() != nil {
+		return AddressFamilyIPv4
+	}
+	return AddressFamilyIPv6
+}
+
+// sortedVrids Get sorted VRIDs of VRRP table.
+func sortedVrids(vrrps map[uint8]*VRRP) []uint8 {
+	vrids := make([]uint8, 0, len(vrrps))
+	for vrid := range vrrps {
+		vrids = append(vrids, vrid)
+	}
+	sort.Slice(vrids, func(i, j int) bool {
+		return vrids[i] < vrids[j]
+	})
+	return vrids
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
@@ -38,6 +38,16 @@ func (suite *testUtilTestSuite) TestDupIP() {
 	suite.True(bytes.Compare(srcIP2, dstIP2) == 0)
 }
 
+func (suite *testUtilTestSuite) TestDupIPNil() {
+	suite.Nil(dupIP(nil))
+}
+
+func (suite *testUtilTestSuite) TestToAddressFamily() {
+	suite.Equal(AddressFamilyIPv4, ToAddressFamily(net.ParseIP("172.16.110.1")))
+	suite.Equal(AddressFamilyIPv4, ToAddressFamily(net.ParseIP("172.16.110.1").To4()))
+	suite.Equal(AddressFamilyIPv6, ToAddressFamily(net.ParseIP("2001:db8::1")))
+}
+
 func TestUtilTestSuite(t *testing.T) {
 	suite.Run(t, new(testUtilTestSuite))
 }
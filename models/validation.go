@@ -0,0 +1,81 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError Error of validation with path of config.
+type ValidationError struct {
+	Interface    string
+	Subinterface string
+	AF           AddressFamily
+	Vrid         uint8
+	Field        string
+	Reason       string
+}
+
+// Path Returns path of config.
+func (e *ValidationError) Path() string {
+	paths := []string{}
+	if e.Interface != "" {
+		paths = append(paths, "interface "+e.Interface)
+	}
+	if e.Subinterface != "" {
+		paths = append(paths, "subinterface "+e.Subinterface)
+	}
+	if e.Vrid > 0 {
+		paths = append(paths, fmt.Sprintf("%v vrrp %d", e.AF, e.Vrid))
+	}
+	if e.Field != "" {
+		paths = append(paths, e.Field)
+	}
+	return strings.Join(paths, " ")
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path(), e.Reason)
+}
+
+// ValidationErrors List of ValidationError.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	strs := make([]string, len(errs))
+	for i, e := range errs {
+		strs[i] = e.Error()
+	}
+	return strings.Join(strs, "; ")
+}
+
+// Strings Returns string representations of errors.
+func (errs ValidationErrors) Strings() []string {
+	strs := make([]string, len(errs))
+	for i, e := range errs {
+		strs[i] = e.Error()
+	}
+	return strs
+}
+
+func newValidationError(field string, format string, a ...interface{}) *ValidationError {
+	return &ValidationError{
+		Field:  field,
+		Reason: fmt.Sprintf(format, a...),
+	}
+}
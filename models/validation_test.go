@@ -0,0 +1,86 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testValidationTestSuite struct {
+	suite.Suite
+}
+
+func (suite *testValidationTestSuite) TestValidationVRRP() {
+	vrrp := NewVRRP()
+	vrrp.Vrid = 1
+	vrrp.Version = VRRPVersion2
+	vrrp.Interval = 150
+
+	errs := vrrp.Validate()
+	suite.Equal(2, len(errs))
+	suite.Equal("virtual-address", errs[0].Field)
+	suite.Equal("advertise-interval", errs[1].Field)
+	suite.Equal(uint8(1), errs[1].Vrid)
+	suite.Equal("ipv4 vrrp 1 advertise-interval", errs[1].Path())
+}
+
+func (suite *testValidationTestSuite) TestValidationInterface() {
+	vrrp := NewVRRP()
+	vrrp.Vrid = 10
+	vrrp.Version = VRRPVersion2
+	vrrp.VirtualAddresses = []net.IP{net.ParseIP("2001:db8::1")}
+
+	subif := NewSubinterface()
+	subif.Name = "0"
+	subif.Index = 0
+	subif.IPv6 = net.ParseIP("2001:db8::2")
+	subif.IPv6Prefix = 64
+	subif.IPv6VRRPs[vrrp.Vrid] = vrrp
+
+	iface := NewInterface()
+	iface.Name = "if0"
+	iface.Subinterfaces[subif.Name] = subif
+
+	errs := iface.Validate()
+	suite.Equal(1, len(errs))
+	suite.Equal(&ValidationError{
+		Interface:    "if0",
+		Subinterface: "0",
+		AF:           AddressFamilyIPv6,
+		Vrid:         10,
+		Field:        "version",
+		Reason:       "version 2 is IPv4 only",
+	}, errs[0])
+	suite.Equal("interface if0 subinterface 0 ipv6 vrrp 10 version: version 2 is IPv4 only",
+		errs.Error())
+	suite.Equal([]string{errs[0].Error()}, errs.Strings())
+
+	vrrp.Version = VRRPVersion3
+	suite.Empty(iface.Validate())
+
+	// tunnel interface is ignored.
+	vrrp.Version = VRRPVersion2
+	iface.Type = IfTypeTunnel
+	suite.Empty(iface.Validate())
+}
+
+func TestValidationTestSuite(t *testing.T) {
+	suite.Run(t, new(testValidationTestSuite))
+}
@@ -18,43 +18,143 @@ package models
 
 import (
 	"fmt"
+	"math"
 	"net"
 	"sync"
 )
 
 // VRRP model
 type VRRP struct {
-	Vrid             uint8
-	Priority         uint8
-	Preempt          bool
-	Accept           bool
-	Interval         uint16
-	VirtualAddresses []net.IP
+	Vrid              uint8
+	Priority          uint8
+	Preempt           bool
+	PreemptDelay      uint16
+	Accept            bool
+	Interval          uint16
+	Version           VRRPVersion
+	VirtualAddresses  []net.IP
+	VirtualLinkLocal  net.IP
+	TrackInterfaces   []string
+	PriorityDecrement uint8
+	HealthChecks      []string
+	// targets of ICMP echo probe.
+	ReachabilityTargets []net.IP
+	// next hop of off-link reachability targets.
+	ReachabilityGateway   net.IP
+	ReachabilityDecrement uint8
+	SyncGroup             string
+	NotifyScript          string
+	// allow virtual addresses outside of subinterface prefix.
+	AllowOutOfPrefix bool
 	lock             sync.RWMutex
 }
 
 // NewVRRP New VRRP model.
 func NewVRRP() *VRRP {
 	return &VRRP{
-		Vrid:             0,
-		Priority:         DefaultPriority,
-		Preempt:          DefaultPreempt,
-		Accept:           DefaultAccept,
-		Interval:         DefaultInterval,
-		VirtualAddresses: []net.IP{},
+		Vrid:                  0,
+		Priority:              DefaultPriority,
+		Preempt:               DefaultPreempt,
+		PreemptDelay:          DefaultPreemptDelay,
+		Accept:                DefaultAccept,
+		Interval:              DefaultInterval,
+		Version:               DefaultVersion,
+		VirtualAddresses:      []net.IP{},
+		VirtualLinkLocal:      nil,
+		TrackInterfaces:       []string{},
+		PriorityDecrement:     DefaultPriorityDecrement,
+		HealthChecks:          []string{},
+		ReachabilityTargets:   []net.IP{},
+		ReachabilityGateway:   nil,
+		ReachabilityDecrement: DefaultReachabilityDecrement,
+		SyncGroup:             "",
+		NotifyScript:          "",
+		AllowOutOfPrefix:      DefaultAllowOutOfPrefix,
 	}
 }
 
 // IsValid Reports whether VRRP represents a valid value.
 func (vrrp *VRRP) IsValid() bool {
+	return len(vrrp.Validate()) == 0
+}
+
+// Validate Validate VRRP, and returns errors.
+func (vrrp *VRRP) Validate() ValidationErrors {
 	vrrp.lock.RLock()
 	defer vrrp.lock.RUnlock()
 
-	if vrrp.Vrid > 0 && len(vrrp.VirtualAddresses) > 0 {
-		return true
+	errs := ValidationErrors{}
+	if vrrp.Vrid == 0 {
+		errs = append(errs, newValidationError("virtual-router-id",
+			"must be 1-255"))
+	}
+	if len(vrrp.VirtualAddresses) == 0 {
+		errs = append(errs, newValidationError("virtual-address",
+			"at least one virtual address is required"))
+	}
+	if len(vrrp.VirtualAddresses) > MaxVirtualAddresses {
+		errs = append(errs, newValidationError("virtual-address",
+			"%d addresses exceed max %d", len(vrrp.VirtualAddresses),
+			MaxVirtualAddresses))
+	}
+	if vrrp.Interval < MinInterval || vrrp.Interval > MaxInterval {
+		errs = append(errs, newValidationError("advertise-interval",
+			"%d must be %d-%d", vrrp.Interval, MinInterval, MaxInterval))
+	}
+	// operational state is tracked by kernel netdev.
+	for _, ifname := range vrrp.TrackInterfaces {
+		if !hasNetdev(ifname) {
+			errs = append(errs, newValidationError("track-interface",
+				"%s has no kernel netdev, and can't be tracked", ifname))
+		}
+	}
+	switch vrrp.Version {
+	case VRRPVersion3:
+	case VRRPVersion2, VRRPVersion3Compat:
+		// Advertisement interval of VRRPv2 is in seconds.
+		if vrrp.Interval%100 != 0 || vrrp.Interval/100 > math.MaxUint8 {
+			errs = append(errs, newValidationError("advertise-interval",
+				"%d must be a multiple of 100 and at most %d in version %v",
+				vrrp.Interval, math.MaxUint8*100, vrrp.Version))
+		}
+	default:
+		errs = append(errs, newValidationError("version",
+			"unknown version %v", vrrp.Version))
+	}
+
+	for _, e := range errs {
+		e.Vrid = vrrp.Vrid
 	}
 
-	return false
+	return errs
+}
+
+// ValidateReferences Validate names of health checks and notify script
+// referred by VRRP, and returns errors.
+func (vrrp *VRRP) ValidateReferences(healthChecks map[string]*HealthCheck,
+	notifyScripts map[string]*NotifyScript) ValidationErrors {
+	vrrp.lock.RLock()
+	defer vrrp.lock.RUnlock()
+
+	errs := ValidationErrors{}
+	for _, name := range vrrp.HealthChecks {
+		if _, ok := healthChecks[name]; !ok {
+			errs = append(errs, newValidationError("health-check",
+				"%s is not defined", name))
+		}
+	}
+	if vrrp.NotifyScript != "" {
+		if _, ok := notifyScripts[vrrp.NotifyScript]; !ok {
+			errs = append(errs, newValidationError("notify-script",
+				"%s is not defined", vrrp.NotifyScript))
+		}
+	}
+
+	for _, e := range errs {
+		e.Vrid = vrrp.Vrid
+	}
+
+	return errs
 }
 
 // Copy Copy VRRP model.
@@ -64,14 +164,32 @@ func (vrrp *VRRP) Copy() *VRRP {
 
 	vas := make([]net.IP, len(vrrp.VirtualAddresses))
 	copy(vas, vrrp.VirtualAddresses)
+	tifs := make([]string, len(vrrp.TrackInterfaces))
+	copy(tifs, vrrp.TrackInterfaces)
+	hcs := make([]string, len(vrrp.HealthChecks))
+	copy(hcs, vrrp.HealthChecks)
+	rts := make([]net.IP, len(vrrp.ReachabilityTargets))
+	copy(rts, vrrp.ReachabilityTargets)
 
 	return &VRRP{
-		Vrid:             vrrp.Vrid,
-		Priority:         vrrp.Priority,
-		Preempt:          vrrp.Preempt,
-		Accept:           vrrp.Accept,
-		Interval:         vrrp.Interval,
-		VirtualAddresses: vas,
+		Vrid:                  vrrp.Vrid,
+		Priority:              vrrp.Priority,
+		Preempt:               vrrp.Preempt,
+		PreemptDelay:          vrrp.PreemptDelay,
+		Accept:                vrrp.Accept,
+		Interval:              vrrp.Interval,
+		Version:               vrrp.Version,
+		VirtualAddresses:      vas,
+		VirtualLinkLocal:      dupIP(vrrp.VirtualLinkLocal),
+		TrackInterfaces:       tifs,
+		PriorityDecrement:     vrrp.PriorityDecrement,
+		HealthChecks:          hcs,
+		ReachabilityTargets:   rts,
+		ReachabilityGateway:   dupIP(vrrp.ReachabilityGateway),
+		ReachabilityDecrement: vrrp.ReachabilityDecrement,
+		SyncGroup:             vrrp.SyncGroup,
+		NotifyScript:          vrrp.NotifyScript,
+		AllowOutOfPrefix:      vrrp.AllowOutOfPrefix,
 	}
 }
 
@@ -107,6 +225,54 @@ func (vrrp *VRRP) SetDefaultPreempt() {
 	vrrp.Preempt = DefaultPreempt
 }
 
+// SetPreemptDelay Set preempt delay.
+func (vrrp *VRRP) SetPreemptDelay(delay uint16) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.PreemptDelay = delay
+}
+
+// SetDefaultPreemptDelay Set default preempt delay.
+func (vrrp *VRRP) SetDefaultPreemptDelay() {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.PreemptDelay = DefaultPreemptDelay
+}
+
+// SetAccept Set accept.
+func (vrrp *VRRP) SetAccept(accept bool) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.Accept = accept
+}
+
+// SetDefaultAccept Set default accept.
+func (vrrp *VRRP) SetDefaultAccept() {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.Accept = DefaultAccept
+}
+
+// SetAllowOutOfPrefix Set allow out of prefix.
+func (vrrp *VRRP) SetAllowOutOfPrefix(allowOutOfPrefix bool) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.AllowOutOfPrefix = allowOutOfPrefix
+}
+
+// SetDefaultAllowOutOfPrefix Set default allow out of prefix.
+func (vrrp *VRRP) SetDefaultAllowOutOfPrefix() {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.AllowOutOfPrefix = DefaultAllowOutOfPrefix
+}
+
 // SetInterval Set interval.
 func (vrrp *VRRP) SetInterval(interval uint16) {
 	vrrp.lock.Lock()
@@ -123,6 +289,22 @@ func (vrrp *VRRP) SetDefaultInterval() {
 	vrrp.Interval = DefaultInterval
 }
 
+// SetVersion Set version.
+func (vrrp *VRRP) SetVersion(version string) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.Version = toVRRPVersion(version)
+}
+
+// SetDefaultVersion Set default version.
+func (vrrp *VRRP) SetDefaultVersion() {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.Version = DefaultVersion
+}
+
 // AddVirtualAddress Add virtual address.
 func (vrrp *VRRP) AddVirtualAddress(addr net.IP) {
 	vrrp.lock.Lock()
@@ -154,6 +336,192 @@ func (vrrp *VRRP) DeleteVirtualAddress(addr net.IP) {
 	vrrp.VirtualAddresses = tmp
 }
 
+// SetVirtualLinkLocal Set virtual link-local address(IPv6).
+func (vrrp *VRRP) SetVirtualLinkLocal(addr net.IP) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.VirtualLinkLocal = addr
+}
+
+// DeleteVirtualLinkLocal Delete virtual link-local address(IPv6).
+func (vrrp *VRRP) DeleteVirtualLinkLocal() {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.VirtualLinkLocal = nil
+}
+
+// AddTrackInterface Add tracked interface.
+func (vrrp *VRRP) AddTrackInterface(ifname string) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	for _, tif := range vrrp.TrackInterfaces {
+		if tif == ifname {
+			return
+		}
+	}
+
+	vrrp.TrackInterfaces = append(vrrp.TrackInterfaces, ifname)
+}
+
+// DeleteTrackInterface Delete tracked interface.
+func (vrrp *VRRP) DeleteTrackInterface(ifname string) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	tmp := []string{}
+
+	for _, tif := range vrrp.TrackInterfaces {
+		if tif != ifname {
+			tmp = append(tmp, tif)
+		}
+	}
+
+	vrrp.TrackInterfaces = tmp
+}
+
+// SetPriorityDecrement Set priority decrement.
+func (vrrp *VRRP) SetPriorityDecrement(decrement uint8) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.PriorityDecrement = decrement
+}
+
+// SetDefaultPriorityDecrement Set default priority decrement.
+func (vrrp *VRRP) SetDefaultPriorityDecrement() {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.PriorityDecrement = DefaultPriorityDecrement
+}
+
+// AddHealthCheck Add health check.
+func (vrrp *VRRP) AddHealthCheck(name string) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	for _, hc := range vrrp.HealthChecks {
+		if hc == name {
+			return
+		}
+	}
+
+	vrrp.HealthChecks = append(vrrp.HealthChecks, name)
+}
+
+// DeleteHealthCheck Delete health check.
+func (vrrp *VRRP) DeleteHealthCheck(name string) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	tmp := []string{}
+
+	for _, hc := range vrrp.HealthChecks {
+		if hc != name {
+			tmp = append(tmp, hc)
+		}
+	}
+
+	vrrp.HealthChecks = tmp
+}
+
+// AddReachabilityTarget Add reachability target.
+func (vrrp *VRRP) AddReachabilityTarget(addr net.IP) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	for _, target := range vrrp.ReachabilityTargets {
+		if target.Equal(addr) {
+			return
+		}
+	}
+
+	vrrp.ReachabilityTargets = append(vrrp.ReachabilityTargets, addr)
+}
+
+// DeleteReachabilityTarget Delete reachability target.
+func (vrrp *VRRP) DeleteReachabilityTarget(addr net.IP) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	tmp := []net.IP{}
+
+	for _, target := range vrrp.ReachabilityTargets {
+		if !target.Equal(addr) {
+			tmp = append(tmp, target)
+		}
+	}
+
+	vrrp.ReachabilityTargets = tmp
+}
+
+// SetReachabilityGateway Set gateway of reachability.
+func (vrrp *VRRP) SetReachabilityGateway(addr net.IP) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.ReachabilityGateway = addr
+}
+
+// DeleteReachabilityGateway Delete gateway of reachability.
+func (vrrp *VRRP) DeleteReachabilityGateway() {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.ReachabilityGateway = nil
+}
+
+// SetReachabilityDecrement Set priority decrement of reachability.
+func (vrrp *VRRP) SetReachabilityDecrement(decrement uint8) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.ReachabilityDecrement = decrement
+}
+
+// SetDefaultReachabilityDecrement Set default priority decrement of reachability.
+func (vrrp *VRRP) SetDefaultReachabilityDecrement() {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.ReachabilityDecrement = DefaultReachabilityDecrement
+}
+
+// SetSyncGroup Set sync group.
+func (vrrp *VRRP) SetSyncGroup(group string) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.SyncGroup = group
+}
+
+// DeleteSyncGroup Delete sync group.
+func (vrrp *VRRP) DeleteSyncGroup() {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.SyncGroup = ""
+}
+
+// SetNotifyScript Set notify script.
+func (vrrp *VRRP) SetNotifyScript(name string) {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.NotifyScript = name
+}
+
+// DeleteNotifyScript Delete notify script.
+func (vrrp *VRRP) DeleteNotifyScript() {
+	vrrp.lock.Lock()
+	defer vrrp.lock.Unlock()
+
+	vrrp.NotifyScript = ""
+}
+
 // IsMaster Report whether VRRP is Master.
 func (vrrp *VRRP) IsMaster(addr net.IP) bool {
 	vrrp.lock.RLock()
@@ -176,9 +544,21 @@ func (vrrp *VRRP) String() string {
 	str = fmt.Sprintf("Vrid: %d", vrrp.Vrid)
 	str = fmt.Sprintf("%s, Priority: %d", str, vrrp.Priority)
 	str = fmt.Sprintf("%s, Preempt: %t", str, vrrp.Preempt)
+	str = fmt.Sprintf("%s, PreemptDelay: %d", str, vrrp.PreemptDelay)
 	str = fmt.Sprintf("%s, Accept: %t", str, vrrp.Accept)
 	str = fmt.Sprintf("%s, Interval: %d", str, vrrp.Interval)
+	str = fmt.Sprintf("%s, Version: %v", str, vrrp.Version)
 	str = fmt.Sprintf("%s, VirtualAddresses: %v", str, vrrp.VirtualAddresses)
+	str = fmt.Sprintf("%s, VirtualLinkLocal: %v", str, vrrp.VirtualLinkLocal)
+	str = fmt.Sprintf("%s, TrackInterfaces: %v", str, vrrp.TrackInterfaces)
+	str = fmt.Sprintf("%s, PriorityDecrement: %d", str, vrrp.PriorityDecrement)
+	str = fmt.Sprintf("%s, HealthChecks: %v", str, vrrp.HealthChecks)
+	str = fmt.Sprintf("%s, ReachabilityTargets: %v", str, vrrp.ReachabilityTargets)
+	str = fmt.Sprintf("%s, ReachabilityGateway: %v", str, vrrp.ReachabilityGateway)
+	str = fmt.Sprintf("%s, ReachabilityDecrement: %d", str, vrrp.ReachabilityDecrement)
+	str = fmt.Sprintf("%s, SyncGroup: %s", str, vrrp.SyncGroup)
+	str = fmt.Sprintf("%s, NotifyScript: %s", str, vrrp.NotifyScript)
+	str = fmt.Sprintf("%s, AllowOutOfPrefix: %t", str, vrrp.AllowOutOfPrefix)
 
 	return str
 }
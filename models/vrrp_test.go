@@ -35,6 +35,7 @@ func (suite *testVRRPTestSuite) TestVRRPNewVRRP() {
 	suite.Equal(true, vrrp.Preempt)
 	suite.Equal(false, vrrp.Accept)
 	suite.Equal(uint16(100), vrrp.Interval)
+	suite.Equal(VRRPVersion3, vrrp.Version)
 	suite.EqualValues([]net.IP{}, vrrp.VirtualAddresses)
 }
 
@@ -53,6 +54,21 @@ func (suite *testVRRPTestSuite) TestVRRPIsValid() {
 	suite.False(vrrp.IsValid())
 }
 
+func (suite *testVRRPTestSuite) TestVRRPIsValidTrackInterface() {
+	vrrp := NewVRRP()
+	vrrp.Vrid = 1
+	vrrp.VirtualAddresses = []net.IP{net.ParseIP("192.168.0.1").To4()}
+
+	vrrp.AddTrackInterface("lo")
+	suite.True(vrrp.IsValid())
+
+	// no kernel netdev.
+	vrrp.AddTrackInterface("no-such-netdev0")
+	errs := vrrp.Validate()
+	suite.Equal(1, len(errs))
+	suite.Equal("track-interface", errs[0].Field)
+}
+
 func (suite *testVRRPTestSuite) TestVRRPCopy() {
 	src := NewVRRP()
 	src.Vrid = 1
@@ -77,6 +93,164 @@ func (suite *testVRRPTestSuite) TestVRRPIsMaster() {
 	suite.False(vrrp.IsMaster(net.ParseIP("10.0.0.1").To4()))
 }
 
+func (suite *testVRRPTestSuite) TestVRRPSetPreemptDelay() {
+	vrrp := NewVRRP()
+	suite.Equal(uint16(0), vrrp.PreemptDelay)
+
+	vrrp.SetPreemptDelay(60)
+	suite.Equal(uint16(60), vrrp.PreemptDelay)
+	suite.Equal(uint16(60), vrrp.Copy().PreemptDelay)
+
+	vrrp.SetDefaultPreemptDelay()
+	suite.Equal(uint16(DefaultPreemptDelay), vrrp.PreemptDelay)
+}
+
+func (suite *testVRRPTestSuite) TestVRRPTrackInterface() {
+	vrrp := NewVRRP()
+	vrrp.AddTrackInterface("eth1")
+	vrrp.AddTrackInterface("eth2")
+	vrrp.AddTrackInterface("eth1")
+	suite.Equal([]string{"eth1", "eth2"}, vrrp.TrackInterfaces)
+
+	dst := vrrp.Copy()
+	vrrp.DeleteTrackInterface("eth1")
+	suite.Equal([]string{"eth2"}, vrrp.TrackInterfaces)
+	suite.Equal([]string{"eth1", "eth2"}, dst.TrackInterfaces)
+}
+
+func (suite *testVRRPTestSuite) TestVRRPHealthCheck() {
+	vrrp := NewVRRP()
+	vrrp.AddHealthCheck("check1")
+	vrrp.AddHealthCheck("check1")
+	suite.Equal([]string{"check1"}, vrrp.HealthChecks)
+	suite.Equal([]string{"check1"}, vrrp.Copy().HealthChecks)
+
+	vrrp.DeleteHealthCheck("check1")
+	suite.Equal([]string{}, vrrp.HealthChecks)
+}
+
+func (suite *testVRRPTestSuite) TestVRRPReachability() {
+	vrrp := NewVRRP()
+	suite.Equal(uint8(0), vrrp.ReachabilityDecrement)
+
+	vrrp.AddReachabilityTarget(net.ParseIP("10.0.0.254"))
+	vrrp.AddReachabilityTarget(net.ParseIP("10.0.0.254"))
+	vrrp.SetReachabilityGateway(net.ParseIP("10.0.0.1"))
+	vrrp.SetReachabilityDecrement(30)
+	suite.Equal([]net.IP{net.ParseIP("10.0.0.254")}, vrrp.ReachabilityTargets)
+
+	dst := vrrp.Copy()
+	suite.Equal([]net.IP{net.ParseIP("10.0.0.254")}, dst.ReachabilityTargets)
+	suite.Equal(net.ParseIP("10.0.0.1"), dst.ReachabilityGateway)
+	suite.Equal(uint8(30), dst.ReachabilityDecrement)
+
+	vrrp.DeleteReachabilityTarget(net.ParseIP("10.0.0.254"))
+	vrrp.DeleteReachabilityGateway()
+	vrrp.SetDefaultReachabilityDecrement()
+	suite.Equal([]net.IP{}, vrrp.ReachabilityTargets)
+	suite.Nil(vrrp.ReachabilityGateway)
+	suite.Equal(uint8(DefaultReachabilityDecrement), vrrp.ReachabilityDecrement)
+}
+
+func (suite *testVRRPTestSuite) TestVRRPSyncGroup() {
+	vrrp := NewVRRP()
+	suite.Equal("", vrrp.SyncGroup)
+
+	vrrp.SetSyncGroup("group1")
+	suite.Equal("group1", vrrp.SyncGroup)
+	suite.Equal("group1", vrrp.Copy().SyncGroup)
+
+	vrrp.DeleteSyncGroup()
+	suite.Equal("", vrrp.SyncGroup)
+}
+
+func (suite *testVRRPTestSuite) TestVRRPNotifyScript() {
+	vrrp := NewVRRP()
+	suite.Equal("", vrrp.NotifyScript)
+
+	vrrp.SetNotifyScript("script1")
+	suite.Equal("script1", vrrp.NotifyScript)
+	suite.Equal("script1", vrrp.Copy().NotifyScript)
+
+	vrrp.DeleteNotifyScript()
+	suite.Equal("", vrrp.NotifyScript)
+}
+
+func (suite *testVRRPTestSuite) TestVRRPValidateReferences() {
+	vrrp := NewVRRP()
+	vrrp.Vrid = 1
+	hcs := map[string]*HealthCheck{"check1": NewHealthCheck()}
+	nss := map[string]*NotifyScript{"script1": NewNotifyScript()}
+	suite.Empty(vrrp.ValidateReferences(hcs, nss))
+
+	vrrp.AddHealthCheck("check1")
+	vrrp.SetNotifyScript("script1")
+	suite.Empty(vrrp.ValidateReferences(hcs, nss))
+
+	vrrp.AddHealthCheck("check2")
+	vrrp.SetNotifyScript("script2")
+	errs := vrrp.ValidateReferences(hcs, nss)
+	suite.Equal(2, len(errs))
+	suite.Equal("health-check", errs[0].Field)
+	suite.Equal("check2 is not defined", errs[0].Reason)
+	suite.Equal("notify-script", errs[1].Field)
+	suite.Equal("script2 is not defined", errs[1].Reason)
+	suite.Equal(uint8(1), errs[1].Vrid)
+}
+
+func (suite *testVRRPTestSuite) TestVRRPSetPriorityDecrement() {
+	vrrp := NewVRRP()
+	suite.Equal(uint8(0), vrrp.PriorityDecrement)
+
+	vrrp.SetPriorityDecrement(20)
+	suite.Equal(uint8(20), vrrp.PriorityDecrement)
+	suite.Equal(uint8(20), vrrp.Copy().PriorityDecrement)
+
+	vrrp.SetDefaultPriorityDecrement()
+	suite.Equal(uint8(DefaultPriorityDecrement), vrrp.PriorityDecrement)
+}
+
+func (suite *testVRRPTestSuite) TestVRRPSetAccept() {
+	vrrp := NewVRRP()
+	vrrp.SetAccept(true)
+	suite.True(vrrp.Accept)
+
+	dst := vrrp.Copy()
+	suite.True(dst.Accept)
+
+	vrrp.SetDefaultAccept()
+	suite.Equal(DefaultAccept, vrrp.Accept)
+}
+
+func (suite *testVRRPTestSuite) TestVRRPIsValidVersion() {
+	vrrp := NewVRRP()
+	vrrp.Vrid = 1
+	vrrp.VirtualAddresses = []net.IP{net.ParseIP("192.168.0.1").To4()}
+
+	vrrp.SetVersion("2")
+	suite.Equal(VRRPVersion2, vrrp.Version)
+	suite.True(vrrp.IsValid())
+
+	// VRRPv2: interval is in seconds.
+	vrrp.SetInterval(150)
+	suite.False(vrrp.IsValid())
+
+	vrrp.SetVersion("3-compat")
+	suite.Equal(VRRPVersion3Compat, vrrp.Version)
+	suite.False(vrrp.IsValid())
+
+	vrrp.SetVersion("3")
+	suite.Equal(VRRPVersion3, vrrp.Version)
+	suite.True(vrrp.IsValid())
+
+	vrrp.SetVersion("4")
+	suite.Equal(VRRPVersionUnknown, vrrp.Version)
+	suite.False(vrrp.IsValid())
+
+	vrrp.SetDefaultVersion()
+	suite.Equal(VRRPVersion3, vrrp.Version)
+}
+
 func TestVRRPTestSuite(t *testing.T) {
 	suite.Run(t, new(testVRRPTestSuite))
 }
@@ -0,0 +1,135 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// webhookEvents Events delivered by webhook.
+var webhookEvents = []string{WebhookEventStateChange, WebhookEventProtoError}
+
+// Webhook model
+// All events are delivered if Events is empty.
+// Secret is not marshaled to JSON.
+type Webhook struct {
+	Name      string
+	URL       string
+	Secret    string `json:"-"`
+	Events    []string
+	Timeout   uint16
+	Retries   uint8
+	QueueSize uint16
+	lock      sync.RWMutex
+}
+
+// NewWebhook New Webhook model.
+func NewWebhook() *Webhook {
+	return &Webhook{
+		Name:      "",
+		URL:       "",
+		Secret:    "",
+		Events:    []string{},
+		Timeout:   DefaultWebhookTimeout,
+		Retries:   DefaultWebhookRetries,
+		QueueSize: DefaultWebhookQueueSize,
+	}
+}
+
+// IsValid Reports whether Webhook represents a valid value.
+func (wh *Webhook) IsValid() bool {
+	return len(wh.Validate()) == 0
+}
+
+// Validate Validate Webhook, and returns errors.
+func (wh *Webhook) Validate() ValidationErrors {
+	wh.lock.RLock()
+	defer wh.lock.RUnlock()
+
+	errs := ValidationErrors{}
+	field := strings.TrimSpace("webhooks " + wh.Name)
+	if wh.Name == "" {
+		errs = append(errs, newValidationError(field+" name", "is required"))
+	}
+	if u, err := url.Parse(wh.URL); err != nil || u.Host == "" ||
+		(u.Scheme != "http" && u.Scheme != "https") {
+		errs = append(errs, newValidationError(field+" url",
+			"%q must be http or https URL", wh.URL))
+	}
+	for _, e := range wh.Events {
+		if !containsString(webhookEvents, e) {
+			errs = append(errs, newValidationError(field+" events",
+				"unknown event %q", e))
+		}
+	}
+	if wh.Timeout == 0 {
+		errs = append(errs, newValidationError(field+" timeout", "must be greater than 0"))
+	}
+	if wh.QueueSize == 0 {
+		errs = append(errs, newValidationError(field+" queue-size", "must be greater than 0"))
+	}
+
+	return errs
+}
+
+// Subscribes Report whether event is delivered.
+func (wh *Webhook) Subscribes(event string) bool {
+	wh.lock.RLock()
+	defer wh.lock.RUnlock()
+
+	return len(wh.Events) == 0 || containsString(wh.Events, event)
+}
+
+// Copy Copy Webhook model.
+func (wh *Webhook) Copy() *Webhook {
+	wh.lock.RLock()
+	defer wh.lock.RUnlock()
+
+	events := make([]string, len(wh.Events))
+	copy(events, wh.Events)
+
+	return &Webhook{
+		Name:      wh.Name,
+		URL:       wh.URL,
+		Secret:    wh.Secret,
+		Events:    events,
+		Timeout:   wh.Timeout,
+		Retries:   wh.Retries,
+		QueueSize: wh.QueueSize,
+	}
+}
+
+// String Returns a string representation of the Webhook model.
+// Secret is not shown.
+func (wh *Webhook) String() string {
+	wh.lock.RLock()
+	defer wh.lock.RUnlock()
+
+	var str string
+	str = fmt.Sprintf("Name: %s", wh.Name)
+	str = fmt.Sprintf("%s, URL: %s", str, wh.URL)
+	str = fmt.Sprintf("%s, Secret: %t", str, wh.Secret != "")
+	str = fmt.Sprintf("%s, Events: %v", str, wh.Events)
+	str = fmt.Sprintf("%s, Timeout: %d", str, wh.Timeout)
+	str = fmt.Sprintf("%s, Retries: %d", str, wh.Retries)
+	str = fmt.Sprintf("%s, QueueSize: %d", str, wh.QueueSize)
+
+	return str
+}
@@ -0,0 +1,99 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testWebhookTestSuite struct {
+	suite.Suite
+}
+
+func (suite *testWebhookTestSuite) TestWebhookNewWebhook() {
+	wh := NewWebhook()
+	suite.Equal(uint16(DefaultWebhookTimeout), wh.Timeout)
+	suite.Equal(uint8(DefaultWebhookRetries), wh.Retries)
+	suite.Equal(uint16(DefaultWebhookQueueSize), wh.QueueSize)
+	suite.False(wh.IsValid())
+}
+
+func (suite *testWebhookTestSuite) TestWebhookIsValid() {
+	wh := NewWebhook()
+	wh.Name = "hook1"
+	wh.URL = "https://example.com/hook"
+	suite.True(wh.IsValid())
+
+	wh.URL = "ftp://example.com/hook"
+	suite.False(wh.IsValid())
+
+	wh.URL = "http://example.com/hook"
+	wh.Events = []string{WebhookEventStateChange, "unknown"}
+	suite.False(wh.IsValid())
+
+	wh.Events = []string{WebhookEventProtoError}
+	suite.True(wh.IsValid())
+
+	wh.QueueSize = 0
+	suite.False(wh.IsValid())
+}
+
+func (suite *testWebhookTestSuite) TestWebhookSubscribes() {
+	wh := NewWebhook()
+	suite.True(wh.Subscribes(WebhookEventStateChange))
+	suite.True(wh.Subscribes(WebhookEventProtoError))
+
+	wh.Events = []string{WebhookEventStateChange}
+	suite.True(wh.Subscribes(WebhookEventStateChange))
+	suite.False(wh.Subscribes(WebhookEventProtoError))
+}
+
+func (suite *testWebhookTestSuite) TestWebhookCopy() {
+	wh := NewWebhook()
+	wh.Name = "hook1"
+	wh.URL = "https://example.com/hook"
+	wh.Secret = "secret"
+	wh.Events = []string{WebhookEventStateChange}
+	wh.Timeout = 10
+	wh.Retries = 5
+	wh.QueueSize = 1000
+
+	dst := wh.Copy()
+	suite.Equal(wh.Name, dst.Name)
+	suite.Equal(wh.URL, dst.URL)
+	suite.Equal(wh.Secret, dst.Secret)
+	suite.Equal(wh.Events, dst.Events)
+	suite.Equal(wh.Timeout, dst.Timeout)
+	suite.Equal(wh.Retries, dst.Retries)
+	suite.Equal(wh.QueueSize, dst.QueueSize)
+
+	dst.Events[0] = WebhookEventProtoError
+	suite.Equal(WebhookEventStateChange, wh.Events[0])
+}
+
+func (suite *testWebhookTestSuite) TestWebhookStringHidesSecret() {
+	wh := NewWebhook()
+	wh.Secret = "secret"
+	suite.NotContains(wh.String(), "secret,")
+	suite.Contains(wh.String(), "Secret: true")
+}
+
+func TestWebhookTestSuite(t *testing.T) {
+	suite.Run(t, new(testWebhookTestSuite))
+}
@@ -51,6 +51,17 @@ func GetModule(name string) Module {
 	return moduleMap[name]
 }
 
+// GetModuleNames Get names of modules in order of registration.
+func GetModuleNames() []string {
+	lock.Lock()
+	defer lock.Unlock()
+	names := make([]string, len(moduleArray))
+	for i, module := range moduleArray {
+		names[i] = module.Name()
+	}
+	return names
+}
+
 // GetState Get state.
 func GetState() State {
 	lock.Lock()
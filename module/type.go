@@ -27,3 +27,18 @@ const (
 	// StateSuspended Initialize.
 	StateSuspended
 )
+
+func (s State) String() string {
+	var str string
+	switch s {
+	case StateInitialize:
+		str = "Initialize"
+	case StateStarted:
+		str = "Started"
+	case StateSuspended:
+		str = "Suspended"
+	default:
+		str = "UNKNOWN"
+	}
+	return str
+}
@@ -0,0 +1,52 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package packets
+
+import (
+	"net"
+)
+
+// VirtualMAC Virtual router MAC address(IPv4).
+func VirtualMAC(vrid uint8) net.HardwareAddr {
+	return net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x01, vrid}
+}
+
+// VirtualMACIPv6 Virtual router MAC address(IPv6).
+func VirtualMACIPv6(vrid uint8) net.HardwareAddr {
+	return net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x02, vrid}
+}
+
+// LinkLocalAddr IPv6 link-local address(modified EUI-64).
+func LinkLocalAddr(mac net.HardwareAddr) net.IP {
+	if len(mac) != 6 {
+		return nil
+	}
+
+	ip := make(net.IP, net.IPv6len)
+	ip[0] = 0xfe
+	ip[1] = 0x80
+	ip[8] = mac[0] ^ 0x02
+	ip[9] = mac[1]
+	ip[10] = mac[2]
+	ip[11] = 0xff
+	ip[12] = 0xfe
+	ip[13] = mac[3]
+	ip[14] = mac[4]
+	ip[15] = mac[5]
+
+	return ip
+}
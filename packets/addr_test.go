@@ -0,0 +1,50 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package packets
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testAddrTestSuite struct {
+	suite.Suite
+}
+
+func (suite *testAddrTestSuite) TestVirtualMAC() {
+	suite.Equal(net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x01, 0x32},
+		VirtualMAC(50))
+	suite.Equal(net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x02, 0x32},
+		VirtualMACIPv6(50))
+}
+
+func (suite *testAddrTestSuite) TestLinkLocalAddr() {
+	suite.Equal(net.ParseIP("fe80::200:5eff:fe00:232"),
+		LinkLocalAddr(VirtualMACIPv6(50)))
+	suite.Equal(net.ParseIP("fe80::5054:ff:fedc:6598"),
+		LinkLocalAddr(net.HardwareAddr{0x52, 0x54, 0x00, 0xdc, 0x65, 0x98}))
+}
+
+func (suite *testAddrTestSuite) TestLinkLocalAddrErrorBadMAC() {
+	suite.Nil(LinkLocalAddr(net.HardwareAddr{0x00, 0x00}))
+}
+
+func TestAddrTestSuites(t *testing.T) {
+	suite.Run(t, new(testAddrTestSuite))
+}
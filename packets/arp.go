@@ -71,7 +71,5 @@ func SerializeARP(ip net.IP, mac net.HardwareAddr) ([]byte, error) {
 // SerializeVirtualMacARP Serialize ARP
 func SerializeVirtualMacARP(vrid uint8, ip net.IP) ([]byte, error) {
 	// virtual router mac address
-	vmac := net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x01, vrid}
-
-	return SerializeARP(ip, vmac)
+	return SerializeARP(ip, VirtualMAC(vrid))
 }
@@ -22,6 +22,7 @@ package layers
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/google/gopacket"
 	glayers "github.com/google/gopacket/layers"
@@ -44,6 +45,21 @@ func pseudoheaderIPv4(ip *glayers.IPv4) (csum uint32, err error) {
 	return csum, nil
 }
 
+func pseudoheaderIPv6(ip *glayers.IPv6) (csum uint32, err error) {
+	if err := ip.AddressTo16(); err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < net.IPv6len; i += 2 {
+		csum += uint32(ip.SrcIP[i]) << 8
+		csum += uint32(ip.SrcIP[i+1])
+		csum += uint32(ip.DstIP[i]) << 8
+		csum += uint32(ip.DstIP[i+1])
+	}
+
+	return csum, nil
+}
+
 func ipChecksum(b []byte, csum uint32) uint16 {
 	for ; len(b) >= 2; b = b[2:] {
 		csum += uint32(b[0])<<8 | uint32(b[1])
@@ -57,6 +73,14 @@ func ipChecksum(b []byte, csum uint32) uint16 {
 	return uint16(^csum)
 }
 
+// addressLen length of address for network layer(default: IPv4).
+func (c *checksumPseudoheader) addressLen() int {
+	if _, ok := c.pseudoheader.(*glayers.IPv6); ok {
+		return net.IPv6len
+	}
+	return net.IPv4len
+}
+
 // public funcs
 
 // ComputeChecksum compute checksum
@@ -75,6 +99,11 @@ func (c *checksumPseudoheader) ComputeChecksum(headerAndPayload []byte,
 		if err != nil {
 			return 0, err
 		}
+	case *glayers.IPv6:
+		csum, err = pseudoheaderIPv6(v)
+		if err != nil {
+			return 0, err
+		}
 	default:
 		return 0, fmt.Errorf("cannot use layer type for tcp checksum network layer")
 	}
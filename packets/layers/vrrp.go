@@ -0,0 +1,69 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package layers
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	glayers "github.com/google/gopacket/layers"
+)
+
+// NOTE: IPProtocolMetadata of gopacket(IPProtocolVRRP) is not overridden,
+//       so IPv4/IPv6 layers return glayers.LayerTypeVRRP as next layer type.
+//       VRRPv2Adv/VRRPv3Adv can decode it in DecodingLayerParser.
+
+var (
+	// LayerClassVRRPv2Adv LayerClass of VRRPv2Adv
+	LayerClassVRRPv2Adv = gopacket.NewLayerClass([]gopacket.LayerType{
+		LayerTypeVRRPv2Adv,
+		glayers.LayerTypeVRRP,
+	})
+	// LayerClassVRRPv3Adv LayerClass of VRRPv3Adv
+	LayerClassVRRPv3Adv = gopacket.NewLayerClass([]gopacket.LayerType{
+		LayerTypeVRRPv3Adv,
+		glayers.LayerTypeVRRP,
+	})
+
+	// DecodeVRRPAdv Decoder of VRRP advertisement(dispatched by version).
+	DecodeVRRPAdv = gopacket.DecodeFunc(decodeVRRPAdv)
+)
+
+// VRRPAdvVersion Get version of VRRP advertisement.
+func VRRPAdvVersion(data []byte) (uint8, error) {
+	if len(data) < 1 {
+		return 0, fmt.Errorf("Not a valid VRRP packet. Packet length is too small")
+	}
+
+	return data[0] >> 4, nil
+}
+
+func decodeVRRPAdv(data []byte, p gopacket.PacketBuilder) error {
+	version, err := VRRPAdvVersion(data)
+	if err != nil {
+		return err
+	}
+
+	switch version {
+	case VRRPv2Version:
+		return decodeVRRPv2Adv(data, p)
+	case VRRPv3Version:
+		return decodeVRRPv3Adv(data, p)
+	default:
+		return fmt.Errorf("Bad version: version = %d", version)
+	}
+}
@@ -0,0 +1,228 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// The following was in reference.
+// - https://github.com/google/gopacket
+
+package layers
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/google/gopacket"
+	glayers "github.com/google/gopacket/layers"
+)
+
+/*
+	This layer provides decoding for Virtual Router Redundancy Protocol (VRRP) v2.
+	https://tools.ietf.org/html/rfc3768#section-5.1
+     0                   1                   2                   3
+     0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+    |Version| Type  | Virtual Rtr ID|   Priority    | Count IP Addrs|
+    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+    |   Auth Type   |   Adver Int   |          Checksum             |
+    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+    |                         IP Address (1)                        |
+    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+    |                            .                                  |
+    |                            .                                  |
+    |                            .                                  |
+    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+    |                         IP Address (n)                        |
+    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+    |                     Authentication Data (1)                   |
+    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+    |                     Authentication Data (2)                   |
+    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+
+// VRRPv2AuthType type of authentication.
+type VRRPv2AuthType uint8
+
+const (
+	// VRRPv2Version Version
+	VRRPv2Version = 0x02
+	// VRRPv2Advertisement Advertisement type
+	VRRPv2Advertisement VRRPv3Type = 0x01
+	// VRRPv2LayerType Custom LayerType for VRRPv2
+	VRRPv2LayerType = 2002
+	// VRRPv2AuthNoAuth No Authentication
+	VRRPv2AuthNoAuth VRRPv2AuthType = 0x00
+	// VRRPv2AuthDataLen length of Authentication Data
+	VRRPv2AuthDataLen = 8
+)
+
+// VRRPv2Adv represents an VRRP v2 message.
+type VRRPv2Adv struct {
+	glayers.BaseLayer
+	Version      uint8                   // The version field specifies the VRRP protocol version of this packet (v2)
+	Type         VRRPv3Type              // The type field specifies the type of this VRRP packet.  The only type defined in v2 is ADVERTISEMENT
+	VirtualRtrID uint8                   // identifies the virtual router this packet is reporting status for
+	Priority     uint8                   // specifies the sending VRRP router's priority for the virtual router (100 = default)
+	CountIPAddr  uint8                   // The number of IP addresses contained in this VRRP advertisement.
+	AuthType     VRRPv2AuthType          // identifies the authentication method being utilized (0 = No Authentication)
+	AdverInt     uint8                   // The Advertisement interval indicates the time interval (in seconds) between ADVERTISEMENTS.  The default is 1 second
+	Checksum     uint16                  // used to detect data corruption in the VRRP message.
+	IPAddress    []net.IP                // one or more IPv4 addresses associated with the virtual router. Specified in the CountIPAddr field.
+	AuthData     [VRRPv2AuthDataLen]byte // authentication data (not used since RFC 3768)
+}
+
+var (
+	// LayerTypeVRRPv2Adv LayerType
+	LayerTypeVRRPv2Adv = gopacket.RegisterLayerType(
+		VRRPv2LayerType,
+		gopacket.LayerTypeMetadata{
+			Name:    "VRRPv2",
+			Decoder: gopacket.DecodeFunc(decodeVRRPv2Adv),
+		},
+	)
+)
+
+func decodeVRRPv2Adv(data []byte, p gopacket.PacketBuilder) error {
+	if len(data) < 8 {
+		return fmt.Errorf("Not a valid VRRP packet. Packet length is too small")
+	}
+
+	v := &VRRPv2Adv{}
+
+	if err := v.DecodeFromBytes(data, p); err != nil {
+		return err
+	}
+
+	p.AddLayer(v)
+
+	return nil
+}
+
+// public funcs
+
+// SerializeTo VRRP Serializer.
+func (v *VRRPv2Adv) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.SerializeOptions) error {
+	if v.Version != VRRPv2Version ||
+		VRRPv2Advertisement != v.Type {
+		return fmt.Errorf("Bad version or type: version = %d, type = %d",
+			v.Version, v.Type)
+	}
+
+	var bytes []byte
+	var err error
+	if bytes, err = b.PrependBytes(8 + (net.IPv4len * len(v.IPAddress)) +
+		VRRPv2AuthDataLen); err != nil {
+		return err
+	}
+
+	bytes[0] = (v.Version << 4) | uint8(v.Type)
+	bytes[1] = v.VirtualRtrID
+	bytes[2] = v.Priority
+	bytes[3] = v.CountIPAddr
+	bytes[4] = uint8(v.AuthType)
+	bytes[5] = v.AdverInt
+	bytes[6] = 0 // checksum
+	bytes[7] = 0
+	for i, ip := range v.IPAddress {
+		head := 8 + (i * net.IPv4len)
+		copy(bytes[head:head+net.IPv4len], ip.To4())
+	}
+	copy(bytes[8+(net.IPv4len*len(v.IPAddress)):], v.AuthData[:])
+
+	// checksum of VRRP message (without pseudo-header).
+	binary.BigEndian.PutUint16(bytes[6:], ipChecksum(bytes, 0))
+
+	return nil
+}
+
+// LayerType return layer type
+func (v *VRRPv2Adv) LayerType() gopacket.LayerType {
+	return LayerTypeVRRPv2Adv
+}
+
+// DecodeFromBytes decoder
+func (v *VRRPv2Adv) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 8 {
+		return fmt.Errorf("Not a valid VRRP packet. Packet length is too small")
+	}
+
+	v.Version = data[0] >> 4
+	v.Type = VRRPv3Type(data[0] & 0x0F)
+
+	if v.Version != VRRPv2Version ||
+		VRRPv2Advertisement != v.Type {
+		return fmt.Errorf("Bad version or type: version = %d, type = %d",
+			v.Version, v.Type)
+	}
+
+	v.VirtualRtrID = data[1]
+	v.Priority = data[2]
+
+	v.CountIPAddr = data[3]
+	if v.CountIPAddr < 1 {
+		return fmt.Errorf("VRRPv2 number of IP addresses is not valid")
+	}
+
+	v.AuthType = VRRPv2AuthType(data[4])
+	v.AdverInt = data[5]
+	v.Checksum = binary.BigEndian.Uint16(data[6:])
+
+	length := 8 + (net.IPv4len * int(v.CountIPAddr)) + VRRPv2AuthDataLen
+	if len(data) < length {
+		return fmt.Errorf("VRRPv2 length of IP addresses is not valid")
+	}
+
+	v.BaseLayer = glayers.BaseLayer{Contents: data[:length], Payload: data[length:]}
+
+	v.IPAddress = nil
+	for i := 0; i < int(v.CountIPAddr); i++ {
+		head := 8 + (i * net.IPv4len)
+		v.IPAddress = append(v.IPAddress, data[head:head+net.IPv4len])
+	}
+	copy(v.AuthData[:], data[length-VRRPv2AuthDataLen:length])
+
+	return nil
+}
+
+// ComputeChecksum compute checksum of VRRP message.
+func (v *VRRPv2Adv) ComputeChecksum() uint16 {
+	return ipChecksum(v.Contents, 0)
+}
+
+// NextLayerType return LayerTypeZero
+func (v *VRRPv2Adv) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypeZero
+}
+
+// CanDecode return LayerType VRRPv2Adv
+func (v *VRRPv2Adv) CanDecode() gopacket.LayerClass {
+	return LayerClassVRRPv2Adv
+}
+
+// ToVRRPv3Adv Convert to VRRPv3Adv.
+// Version is kept(v2), and MaxAdverInt is converted into centiseconds.
+func (v *VRRPv2Adv) ToVRRPv3Adv() *VRRPv3Adv {
+	return &VRRPv3Adv{
+		BaseLayer:    v.BaseLayer,
+		Version:      v.Version,
+		Type:         v.Type,
+		VirtualRtrID: v.VirtualRtrID,
+		Priority:     v.Priority,
+		CountIPAddr:  v.CountIPAddr,
+		MaxAdverInt:  uint16(v.AdverInt) * 100,
+		Checksum:     v.Checksum,
+		IPAddress:    v.IPAddress,
+	}
+}
@@ -0,0 +1,206 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package layers
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	glayers "github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/suite"
+)
+
+type testVRRPv2AdvTestSuite struct {
+	suite.Suite
+}
+
+var testVRRPv2AdvPacket = []byte{
+	//    L2 header
+	//<------------------------------------------------------------------>
+	0x01, 0x00, 0x5e, 0x00, 0x00, 0x12, 0x00, 0x00, 0x5e, 0x00, 0x01, 0x32,
+	//    L3 header
+	//<------------------------------------------------------------------>
+	0x08, 0x00, 0x45, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00, 0xff, 0x70,
+	//------------------------------------------------------->
+	//                                                          VRRP
+	//                                                          version/type
+	//                                                          <-->  VRID
+	//                                                                <-->
+	0xb5, 0xb8, 0xc0, 0xa8, 0x64, 0xee, 0xe0, 0x00, 0x00, 0x12, 0x21, 0x32,
+	//Priority
+	//<>   Count IP Addrs
+	//    <-->  Auth Type
+	//          <-->  Adver Int
+	//                <-->  Checksum
+	//                      <-------->    IPv4                    IPv4
+	//                                  <-------------------->  <--------
+	0xff, 0x02, 0x00, 0x01, 0xcb, 0xc6, 0x0a, 0x00, 0x00, 0x01, 0x0a, 0x00,
+	//------->  Authentication Data                       pad
+	//          <---------------------------------------> <-------->
+	0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+}
+
+func (suite *testVRRPv2AdvTestSuite) TestSerializeVRRPv2Adv() {
+	ethernet := &glayers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x01, 0x32},
+		DstMAC:       net.HardwareAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0x12},
+		EthernetType: glayers.EthernetTypeIPv4,
+	}
+	ip := &glayers.IPv4{
+		Version:  4,
+		IHL:      5,
+		TTL:      255,
+		Protocol: 112,
+		SrcIP:    net.IP{192, 168, 100, 238},
+		DstIP:    net.IP{224, 0, 0, 18},
+	}
+	vrrp := &VRRPv2Adv{
+		Version:      VRRPv2Version,
+		Type:         VRRPv2Advertisement,
+		VirtualRtrID: 50,
+		Priority:     255,
+		CountIPAddr:  2,
+		AuthType:     VRRPv2AuthNoAuth,
+		AdverInt:     1,
+		IPAddress: []net.IP{
+			net.IP{10, 0, 0, 1},
+			net.IP{10, 0, 0, 2}},
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	err := gopacket.SerializeLayers(buf, opts,
+		ethernet,
+		ip,
+		vrrp)
+	suite.Empty(err)
+	suite.Equal(testVRRPv2AdvPacket, buf.Bytes())
+}
+
+func (suite *testVRRPv2AdvTestSuite) TestSerializeVRRPv2AdvErrorBadVersion() {
+	vrrp := &VRRPv2Adv{
+		Version:      VRRPv3Version, // (3)
+		Type:         VRRPv2Advertisement,
+		VirtualRtrID: 50,
+		Priority:     255,
+		CountIPAddr:  1,
+		AdverInt:     1,
+		IPAddress: []net.IP{
+			net.IP{10, 0, 0, 1}},
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{}
+
+	err := gopacket.SerializeLayers(buf, opts,
+		vrrp)
+	suite.EqualError(err, "Bad version or type: version = 3, type = 1")
+}
+
+func (suite *testVRRPv2AdvTestSuite) TestVRRPv2AdvDecode() {
+	var eth glayers.Ethernet
+	var ip glayers.IPv4
+	var vrrp VRRPv2Adv
+
+	parser := gopacket.NewDecodingLayerParser(glayers.LayerTypeEthernet, &eth, &ip, &vrrp)
+	decoded := []gopacket.LayerType{}
+	err := parser.DecodeLayers(testVRRPv2AdvPacket, &decoded)
+	suite.Empty(err)
+
+	suite.Equal(uint8(VRRPv2Version), vrrp.Version)
+	suite.Equal(VRRPv2Advertisement, vrrp.Type)
+	suite.Equal(uint8(50), vrrp.VirtualRtrID)
+	suite.Equal(uint8(255), vrrp.Priority)
+	suite.Equal(uint8(2), vrrp.CountIPAddr)
+	suite.Equal(VRRPv2AuthNoAuth, vrrp.AuthType)
+	suite.Equal(uint8(1), vrrp.AdverInt)
+	suite.Equal(uint16(0xcbc6), vrrp.Checksum)
+	suite.Equal([]net.IP{
+		net.IP{10, 0, 0, 1},
+		net.IP{10, 0, 0, 2}}, vrrp.IPAddress)
+	suite.Equal(uint16(0), vrrp.ComputeChecksum())
+}
+
+func (suite *testVRRPv2AdvTestSuite) TestDecodeVRRPv2AdvErrorBadLength() {
+	packet := []byte{
+		//version/type
+		//<>  VRID
+		//    <-->  Priority
+		//          <-->  Count IP Addrs(2)
+		//                <-->  Auth Type
+		//                      <-->  Adver Int
+		//                            <-->  Checksum
+		//                                  <-------->  IPv4
+		//                                              <-------------------->
+		0x21, 0x32, 0xff, 0x02, 0x00, 0x01, 0xcb, 0xc6, 0x0a, 0x00, 0x00, 0x01,
+	}
+
+	var vrrp VRRPv2Adv
+	err := vrrp.DecodeFromBytes(packet, gopacket.NilDecodeFeedback)
+	suite.EqualError(err, "VRRPv2 length of IP addresses is not valid")
+}
+
+func (suite *testVRRPv2AdvTestSuite) TestDecodeVRRPAdv() {
+	// payload of IPv4.
+	v2 := gopacket.NewPacket(testVRRPv2AdvPacket[34:58], DecodeVRRPAdv, gopacket.Default)
+	suite.NotNil(v2.Layer(LayerTypeVRRPv2Adv))
+	suite.Nil(v2.ErrorLayer())
+
+	v3 := gopacket.NewPacket([]byte{
+		0x31, 0x32, 0xff, 0x01, 0x00, 0x64, 0xba, 0x35, 0x0a, 0x00, 0x00, 0x01,
+	}, DecodeVRRPAdv, gopacket.Default)
+	suite.NotNil(v3.Layer(LayerTypeVRRPv3Adv))
+
+	bad := gopacket.NewPacket([]byte{
+		0x41, 0x32, 0xff, 0x01, 0x00, 0x64, 0xba, 0x35, 0x0a, 0x00, 0x00, 0x01,
+	}, DecodeVRRPAdv, gopacket.Default)
+	suite.NotNil(bad.ErrorLayer())
+	suite.EqualError(bad.ErrorLayer().Error(), "Bad version: version = 4")
+}
+
+func (suite *testVRRPv2AdvTestSuite) TestConvertVRRPAdv() {
+	v2 := &VRRPv2Adv{
+		Version:      VRRPv2Version,
+		Type:         VRRPv2Advertisement,
+		VirtualRtrID: 50,
+		Priority:     100,
+		CountIPAddr:  1,
+		AdverInt:     3,
+		IPAddress: []net.IP{
+			net.IP{10, 0, 0, 1}},
+	}
+
+	v3 := v2.ToVRRPv3Adv()
+	suite.Equal(uint8(VRRPv2Version), v3.Version)
+	suite.Equal(uint16(300), v3.MaxAdverInt)
+	suite.Equal(v2.IPAddress, v3.IPAddress)
+
+	v3.MaxAdverInt = 50
+	suite.Equal(uint8(1), v3.ToVRRPv2Adv().AdverInt)
+	v3.MaxAdverInt = 4095
+	suite.Equal(uint8(40), v3.ToVRRPv2Adv().AdverInt)
+	suite.Equal(uint8(VRRPv2Version), v3.ToVRRPv2Adv().Version)
+}
+
+func TestVRRPv2AdvTestSuites(t *testing.T) {
+	suite.Run(t, new(testVRRPv2AdvTestSuite))
+}
@@ -76,6 +76,8 @@ type VRRPv3Adv struct {
 	MaxAdverInt  uint16     // The Advertisement interval indicates the time interval (in centiseconds) between ADVERTISEMENTS.  The default is 100 second
 	Checksum     uint16     // used to detect data corruption in the VRRP message.
 	IPAddress    []net.IP   // one or more IP addresses associated with the virtual router. Specified in the CountIPAddr field.
+	// NOTE: IPvX addresses are IPv6 addresses if the network layer
+	//       (SetNetworkLayerForChecksum()) is IPv6, otherwise IPv4 addresses.
 }
 
 var (
@@ -89,12 +91,6 @@ var (
 	)
 )
 
-func init() {
-	glayers.IPProtocolMetadata[glayers.IPProtocolVRRP] =
-		glayers.EnumMetadata{DecodeWith: gopacket.DecodeFunc(decodeVRRPv3Adv),
-			Name: "VRRPv3", LayerType: LayerTypeVRRPv3Adv}
-}
-
 func decodeVRRPv3Adv(data []byte, p gopacket.PacketBuilder) error {
 	if len(data) < 8 {
 		return fmt.Errorf("Not a valid VRRP packet. Packet length is too small")
@@ -102,7 +98,12 @@ func decodeVRRPv3Adv(data []byte, p gopacket.PacketBuilder) error {
 
 	v := &VRRPv3Adv{}
 
-	if err := v.DecodeFromBytes(data, p); err == nil {
+	// length of IPvX address depends on network layer.
+	if packet, ok := p.(gopacket.Packet); ok && packet.NetworkLayer() != nil {
+		v.SetNetworkLayerForChecksum(packet.NetworkLayer())
+	}
+
+	if err := v.DecodeFromBytes(data, p); err != nil {
 		return err
 	}
 
@@ -121,9 +122,11 @@ func (v *VRRPv3Adv) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.Serial
 			v.Version, v.Type)
 	}
 
+	addrLen := v.addressLen()
+
 	var bytes []byte
 	var err error
-	if bytes, err = b.PrependBytes(8 + (net.IPv4len * len(v.IPAddress))); err != nil {
+	if bytes, err = b.PrependBytes(8 + (addrLen * len(v.IPAddress))); err != nil {
 		return err
 	}
 
@@ -135,8 +138,12 @@ func (v *VRRPv3Adv) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.Serial
 	bytes[6] = 0 // checksum
 	bytes[7] = 0
 	for i, ip := range v.IPAddress {
-		head := 8 + (i * net.IPv4len)
-		copy(bytes[head:head+net.IPv4len], ip.To4())
+		head := 8 + (i * addrLen)
+		if addrLen == net.IPv6len {
+			copy(bytes[head:head+addrLen], ip.To16())
+		} else {
+			copy(bytes[head:head+addrLen], ip.To4())
+		}
 	}
 
 	var csum uint16
@@ -155,6 +162,10 @@ func (v *VRRPv3Adv) LayerType() gopacket.LayerType {
 
 // DecodeFromBytes decoder
 func (v *VRRPv3Adv) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 8 {
+		return fmt.Errorf("Not a valid VRRP packet. Packet length is too small")
+	}
+
 	v.BaseLayer = glayers.BaseLayer{Contents: data[:len(data)]}
 
 	v.Version = data[0] >> 4
@@ -177,14 +188,16 @@ func (v *VRRPv3Adv) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) err
 	v.MaxAdverInt = binary.BigEndian.Uint16(data[4:])
 	v.Checksum = binary.BigEndian.Uint16(data[6:])
 
+	addrLen := v.addressLen()
 	if len(data[8:]) < 1 && len(data[8:]) > math.MaxUint8 ||
-		len(data[8:])%net.IPv4len != 0 {
+		len(data[8:])%addrLen != 0 {
 		return fmt.Errorf("VRRPv3 length of IP addresses is not valid")
 	}
 
-	for i := 0; i < len(data[8:])/net.IPv4len; i++ {
-		head := 8 + (i * net.IPv4len)
-		v.IPAddress = append(v.IPAddress, data[head:head+net.IPv4len])
+	v.IPAddress = nil
+	for i := 0; i < len(data[8:])/addrLen; i++ {
+		head := 8 + (i * addrLen)
+		v.IPAddress = append(v.IPAddress, data[head:head+addrLen])
 	}
 
 	return nil
@@ -197,5 +210,27 @@ func (v *VRRPv3Adv) NextLayerType() gopacket.LayerType {
 
 // CanDecode return LayerType VRRPv3Adv
 func (v *VRRPv3Adv) CanDecode() gopacket.LayerClass {
-	return LayerTypeVRRPv3Adv
+	return LayerClassVRRPv3Adv
+}
+
+// ToVRRPv2Adv Convert to VRRPv2Adv.
+// MaxAdverInt is converted into seconds(at least 1 second).
+func (v *VRRPv3Adv) ToVRRPv2Adv() *VRRPv2Adv {
+	adverInt := v.MaxAdverInt / 100
+	if adverInt < 1 {
+		adverInt = 1
+	} else if adverInt > math.MaxUint8 {
+		adverInt = math.MaxUint8
+	}
+
+	return &VRRPv2Adv{
+		Version:      VRRPv2Version,
+		Type:         VRRPv2Advertisement,
+		VirtualRtrID: v.VirtualRtrID,
+		Priority:     v.Priority,
+		CountIPAddr:  v.CountIPAddr,
+		AuthType:     VRRPv2AuthNoAuth,
+		AdverInt:     uint8(adverInt),
+		IPAddress:    v.IPAddress,
+	}
 }
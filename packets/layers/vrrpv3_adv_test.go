@@ -354,6 +354,111 @@ func (suite *testVRRPv3AdvTestSuite) TestDecodeVRRPv3AdvErrorBadIPAddrs() {
 	suite.EqualError(err, "VRRPv3 length of IP addresses is not valid")
 }
 
+func (suite *testVRRPv3AdvTestSuite) TestSerializeVRRPv3AdvIPv6() {
+	expectedPacket := []byte{
+		//    L2 header
+		//<------------------------------------------------------------------>
+		0x33, 0x33, 0x00, 0x00, 0x00, 0x12, 0x00, 0x00, 0x5e, 0x00, 0x02, 0x32,
+		//          L3 header
+		//<-------> <---------------------------------------------------------
+		0x86, 0xdd, 0x60, 0x00, 0x00, 0x00, 0x00, 0x28, 0x70, 0xff, 0xfe, 0x80,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x01, 0xff, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  VRRP
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x12, 0x31, 0x32, 0xff, 0x02, 0x00, 0x64,
+		//Checksum  IPv6
+		//<------>  <---------------------------------------------------------
+		0x75, 0xc2, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  IPv6
+		//                                  <---------------------------------
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00,
+		//------------------------------------------------------------------->
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02,
+	}
+
+	ethernet := &glayers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x02, 0x32},
+		DstMAC:       net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x12},
+		EthernetType: glayers.EthernetTypeIPv6,
+	}
+	ip := &glayers.IPv6{
+		Version:    6,
+		HopLimit:   255,
+		NextHeader: 112,
+		SrcIP:      net.ParseIP("fe80::1"),
+		DstIP:      net.ParseIP("ff02::12"),
+	}
+	vrrp := &VRRPv3Adv{
+		Version:      VRRPv3Version,
+		Type:         VRRPv3Advertisement,
+		VirtualRtrID: 50,
+		Priority:     255,
+		CountIPAddr:  2,
+		MaxAdverInt:  100,
+		IPAddress: []net.IP{
+			net.ParseIP("2001:db8::1"),
+			net.ParseIP("2001:db8::2")},
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	vrrp.SetNetworkLayerForChecksum(ip)
+	err := gopacket.SerializeLayers(buf, opts,
+		ethernet,
+		ip,
+		vrrp)
+	suite.Empty(err)
+	suite.Equal(expectedPacket, buf.Bytes())
+}
+
+func (suite *testVRRPv3AdvTestSuite) TestVRRPv3AdvDecodeIPv6() {
+	packet := []byte{
+		//    L2 header
+		//<------------------------------------------------------------------>
+		0x33, 0x33, 0x00, 0x00, 0x00, 0x12, 0x00, 0x00, 0x5e, 0x00, 0x02, 0x32,
+		//          L3 header
+		//<-------> <---------------------------------------------------------
+		0x86, 0xdd, 0x60, 0x00, 0x00, 0x00, 0x00, 0x28, 0x70, 0xff, 0xfe, 0x80,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x01, 0xff, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  VRRP
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x12, 0x31, 0x32, 0xff, 0x02, 0x00, 0x64,
+		//Checksum  IPv6
+		//<------>  <---------------------------------------------------------
+		0x75, 0xc2, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  IPv6
+		//                                  <---------------------------------
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00,
+		//------------------------------------------------------------------->
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02,
+	}
+
+	var eth glayers.Ethernet
+	var ip glayers.IPv6
+	var vrrp VRRPv3Adv
+
+	// for length of IPv6 address.
+	vrrp.SetNetworkLayerForChecksum(&ip)
+	parser := gopacket.NewDecodingLayerParser(glayers.LayerTypeEthernet, &eth, &ip, &vrrp)
+	decoded := []gopacket.LayerType{}
+	err := parser.DecodeLayers(packet, &decoded)
+	suite.Empty(err)
+
+	suite.Equal(uint8(2), vrrp.CountIPAddr)
+	suite.Equal(uint16(0x75c2), vrrp.Checksum)
+	suite.Equal([]net.IP{
+		net.ParseIP("2001:db8::1"),
+		net.ParseIP("2001:db8::2")}, vrrp.IPAddress)
+
+	csum, err := vrrp.ComputeChecksum(vrrp.Contents, glayers.IPProtocolVRRP)
+	suite.Empty(err)
+	suite.Equal(uint16(0), csum)
+}
+
 func TestVRRPv3AdvTestSuites(t *testing.T) {
 	suite.Run(t, new(testVRRPv3AdvTestSuite))
 }
@@ -0,0 +1,93 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package packets
+
+import (
+	"net"
+
+	"github.com/google/gopacket"
+	glayers "github.com/google/gopacket/layers"
+)
+
+const (
+	// NDHopLimit Hop Limit of Neighbor Discovery
+	NDHopLimit = 255
+	// NAFlagRouter Router flag of Neighbor Advertisement
+	NAFlagRouter = 0x80
+	// NAFlagSolicited Solicited flag of Neighbor Advertisement
+	NAFlagSolicited = 0x40
+	// NAFlagOverride Override flag of Neighbor Advertisement
+	NAFlagOverride = 0x20
+)
+
+var (
+	// AllNodesMAC all-nodes multicast mac address
+	AllNodesMAC = net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x01}
+	// AllNodesIP all-nodes multicast address
+	AllNodesIP = net.ParseIP("ff02::1")
+)
+
+// SerializeUnsolicitedNA Serialize unsolicited Neighbor Advertisement
+func SerializeUnsolicitedNA(ip net.IP, mac net.HardwareAddr) ([]byte, error) {
+	// ethernet
+	ethernet := &glayers.Ethernet{
+		DstMAC:       AllNodesMAC,
+		SrcMAC:       mac,
+		EthernetType: glayers.EthernetTypeIPv6,
+	}
+
+	// IPv6
+	ipv6 := &glayers.IPv6{
+		Version:    VRRPAdvIPv6ver,
+		HopLimit:   NDHopLimit,
+		NextHeader: glayers.IPProtocolICMPv6,
+		SrcIP:      ip,
+		DstIP:      AllNodesIP,
+	}
+
+	// ICMPv6
+	icmp := &glayers.ICMPv6{
+		TypeCode: glayers.CreateICMPv6TypeCode(
+			glayers.ICMPv6TypeNeighborAdvertisement, 0),
+	}
+
+	// NA (R=1, S=0, O=1)
+	na := &glayers.ICMPv6NeighborAdvertisement{
+		Flags:         NAFlagRouter | NAFlagOverride,
+		TargetAddress: ip,
+		Options: glayers.ICMPv6Options{
+			glayers.ICMPv6Option{
+				Type: glayers.ICMPv6OptTargetAddress,
+				Data: mac,
+			},
+		},
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	icmp.SetNetworkLayerForChecksum(ipv6)
+	if err := gopacket.SerializeLayers(buf, opts,
+		ethernet, ipv6, icmp, na); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,81 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package packets
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	glayers "github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/suite"
+)
+
+type testNDTestSuite struct {
+	suite.Suite
+}
+
+func (suite *testNDTestSuite) TestSerializeUnsolicitedNA() {
+	expectedPacket := []byte{
+		//    L2 header
+		//<------------------------------------------------------------------>
+		0x33, 0x33, 0x00, 0x00, 0x00, 0x01, 0x52, 0x54, 0x00, 0xce, 0xd1, 0xa3,
+		//          L3 header
+		//<-------> <---------------------------------------------------------
+		0x86, 0xdd, 0x60, 0x00, 0x00, 0x00, 0x00, 0x20, 0x3a, 0xff, 0x20, 0x01,
+		0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x01, 0xff, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//                                  ICMPv6(NA)
+		//                                  Type  Code  Checksum    Flags(R/O)
+		//------------------------------->  <-->  <-->  <-------->  <---------
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x88, 0x00, 0x56, 0x65, 0xa0, 0x00,
+		//          Target Address
+		//------->  <---------------------------------------------------------
+		0x00, 0x00, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//                                  Option(Target link-layer address)
+		//------------------------------->  <---------------------------------
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x01, 0x52, 0x54, 0x00, 0xce,
+		//------->
+		0xd1, 0xa3,
+	}
+
+	buf, err := SerializeUnsolicitedNA(net.ParseIP("2001:db8::1"),
+		net.HardwareAddr{0x52, 0x54, 0x00, 0xce, 0xd1, 0xa3})
+	suite.Empty(err)
+
+	suite.Equal(expectedPacket, buf)
+}
+
+func (suite *testNDTestSuite) TestSerializeUnsolicitedNAFlags() {
+	buf, err := SerializeUnsolicitedNA(net.ParseIP("2001:db8::1"),
+		net.HardwareAddr{0x52, 0x54, 0x00, 0xce, 0xd1, 0xa3})
+	suite.Empty(err)
+
+	packet := gopacket.NewPacket(buf, glayers.LayerTypeEthernet, gopacket.Default)
+	layer := packet.Layer(glayers.LayerTypeICMPv6NeighborAdvertisement)
+	suite.NotNil(layer)
+
+	na := layer.(*glayers.ICMPv6NeighborAdvertisement)
+	suite.True(na.Router())
+	suite.False(na.Solicited())
+	suite.True(na.Override())
+	suite.Equal(net.ParseIP("2001:db8::1"), na.TargetAddress)
+}
+
+func TestNDTestSuites(t *testing.T) {
+	suite.Run(t, new(testNDTestSuite))
+}
@@ -0,0 +1,285 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package packets
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/google/gopacket"
+	glayers "github.com/google/gopacket/layers"
+)
+
+const (
+	// ProbeTTL TTL(Hop Limit) of ICMP echo probe
+	ProbeTTL = 64
+	// ProbeIPver IPv4
+	ProbeIPver = 4
+	// ProbeIHL IPv4 IHL
+	ProbeIHL = 5
+)
+
+var (
+	// ProbePayload payload of ICMP echo probe
+	ProbePayload = []byte("lagopus-vrrpd")
+)
+
+// ProbeReply Reply of probe (ICMP echo reply, ARP reply or Neighbor Advertisement).
+type ProbeReply struct {
+	SrcIP  net.IP
+	SrcMAC net.HardwareAddr
+	Echo   bool // true: echo reply, false: ARP reply or Neighbor Advertisement
+	ID     uint16
+	Seq    uint16
+}
+
+// SolicitedNodeAddr Solicited-node multicast address and mac address(IPv6).
+func SolicitedNodeAddr(ip net.IP) (net.IP, net.HardwareAddr) {
+	ip = ip.To16()
+	snip := net.ParseIP("ff02::1:ff00:0")
+	copy(snip[13:], ip[13:])
+	mac := net.HardwareAddr{0x33, 0x33, 0xff, ip[13], ip[14], ip[15]}
+
+	return snip, mac
+}
+
+// SerializeARPRequest Serialize ARP request for dstIP.
+func SerializeARPRequest(srcIP net.IP, dstIP net.IP, mac net.HardwareAddr) ([]byte, error) {
+	// ethernet
+	ethernet := &glayers.Ethernet{
+		DstMAC:       BroadcastMAC,
+		SrcMAC:       mac,
+		EthernetType: glayers.EthernetTypeARP,
+	}
+
+	// arp
+	arp := &glayers.ARP{
+		AddrType:          glayers.LinkTypeEthernet,
+		Protocol:          glayers.EthernetTypeIPv4,
+		HwAddressSize:     ARPHwAddressSize,
+		ProtAddressSize:   net.IPv4len,
+		Operation:         glayers.ARPRequest,
+		SourceHwAddress:   mac,
+		SourceProtAddress: srcIP.To4(),
+		DstHwAddress:      net.HardwareAddr{0, 0, 0, 0, 0, 0},
+		DstProtAddress:    dstIP.To4(),
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths: true,
+	}
+
+	if err := gopacket.SerializeLayers(buf, opts,
+		ethernet, arp); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SerializeNS Serialize Neighbor Solicitation for target.
+func SerializeNS(srcIP net.IP, target net.IP, mac net.HardwareAddr) ([]byte, error) {
+	dstIP, dstMAC := SolicitedNodeAddr(target)
+
+	// ethernet
+	ethernet := &glayers.Ethernet{
+		DstMAC:       dstMAC,
+		SrcMAC:       mac,
+		EthernetType: glayers.EthernetTypeIPv6,
+	}
+
+	// IPv6
+	ipv6 := &glayers.IPv6{
+		Version:    VRRPAdvIPv6ver,
+		HopLimit:   NDHopLimit,
+		NextHeader: glayers.IPProtocolICMPv6,
+		SrcIP:      srcIP,
+		DstIP:      dstIP,
+	}
+
+	// ICMPv6
+	icmp := &glayers.ICMPv6{
+		TypeCode: glayers.CreateICMPv6TypeCode(
+			glayers.ICMPv6TypeNeighborSolicitation, 0),
+	}
+
+	// NS
+	ns := &glayers.ICMPv6NeighborSolicitation{
+		TargetAddress: target,
+		Options: glayers.ICMPv6Options{
+			glayers.ICMPv6Option{
+				Type: glayers.ICMPv6OptSourceAddress,
+				Data: mac,
+			},
+		},
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	icmp.SetNetworkLayerForChecksum(ipv6)
+	if err := gopacket.SerializeLayers(buf, opts,
+		ethernet, ipv6, icmp, ns); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SerializeICMPEcho Serialize ICMP echo request(IPv4) or ICMPv6 echo request(IPv6).
+// The address family is selected by dstIP.
+func SerializeICMPEcho(srcIP net.IP, dstIP net.IP,
+	srcMAC net.HardwareAddr, dstMAC net.HardwareAddr,
+	id uint16, seq uint16) ([]byte, error) {
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	if dstIP.To4() != nil {
+		ethernet := &glayers.Ethernet{
+			DstMAC:       dstMAC,
+			SrcMAC:       srcMAC,
+			EthernetType: glayers.EthernetTypeIPv4,
+		}
+		ip := &glayers.IPv4{
+			Version:  ProbeIPver,
+			IHL:      ProbeIHL,
+			TTL:      ProbeTTL,
+			Protocol: glayers.IPProtocolICMPv4,
+			SrcIP:    srcIP.To4(),
+			DstIP:    dstIP.To4(),
+		}
+		icmp := &glayers.ICMPv4{
+			TypeCode: glayers.CreateICMPv4TypeCode(
+				glayers.ICMPv4TypeEchoRequest, 0),
+			Id:  id,
+			Seq: seq,
+		}
+		if err := gopacket.SerializeLayers(buf, opts,
+			ethernet, ip, icmp, gopacket.Payload(ProbePayload)); err != nil {
+			return nil, err
+		}
+	} else {
+		ethernet := &glayers.Ethernet{
+			DstMAC:       dstMAC,
+			SrcMAC:       srcMAC,
+			EthernetType: glayers.EthernetTypeIPv6,
+		}
+		ip := &glayers.IPv6{
+			Version:    VRRPAdvIPv6ver,
+			HopLimit:   ProbeTTL,
+			NextHeader: glayers.IPProtocolICMPv6,
+			SrcIP:      srcIP,
+			DstIP:      dstIP,
+		}
+		icmp := &glayers.ICMPv6{
+			TypeCode: glayers.CreateICMPv6TypeCode(
+				glayers.ICMPv6TypeEchoRequest, 0),
+		}
+		echo := &glayers.ICMPv6Echo{
+			Identifier: id,
+			SeqNumber:  seq,
+		}
+		icmp.SetNetworkLayerForChecksum(ip)
+		if err := gopacket.SerializeLayers(buf, opts,
+			ethernet, ip, icmp, echo, gopacket.Payload(ProbePayload)); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeProbeReply Decode ICMP(v6) echo reply, ARP reply or Neighbor Advertisement.
+func DecodeProbeReply(data []byte) (*ProbeReply, error) {
+	packet := gopacket.NewPacket(data, glayers.LayerTypeEthernet, gopacket.Default)
+
+	var srcMAC net.HardwareAddr
+	if l := packet.Layer(glayers.LayerTypeEthernet); l != nil {
+		srcMAC = l.(*glayers.Ethernet).SrcMAC
+	} else {
+		return nil, fmt.Errorf("Not a probe reply")
+	}
+
+	// ARP reply
+	if l := packet.Layer(glayers.LayerTypeARP); l != nil {
+		arp := l.(*glayers.ARP)
+		if arp.Operation == glayers.ARPReply {
+			return &ProbeReply{
+				SrcIP:  net.IP(arp.SourceProtAddress),
+				SrcMAC: net.HardwareAddr(arp.SourceHwAddress),
+			}, nil
+		}
+		return nil, fmt.Errorf("Not a probe reply")
+	}
+
+	// ICMP echo reply
+	if l := packet.Layer(glayers.LayerTypeICMPv4); l != nil {
+		icmp := l.(*glayers.ICMPv4)
+		ip := packet.Layer(glayers.LayerTypeIPv4)
+		if icmp.TypeCode.Type() == glayers.ICMPv4TypeEchoReply && ip != nil {
+			return &ProbeReply{
+				SrcIP:  ip.(*glayers.IPv4).SrcIP,
+				SrcMAC: srcMAC,
+				Echo:   true,
+				ID:     icmp.Id,
+				Seq:    icmp.Seq,
+			}, nil
+		}
+		return nil, fmt.Errorf("Not a probe reply")
+	}
+
+	// Neighbor Advertisement
+	if l := packet.Layer(glayers.LayerTypeICMPv6NeighborAdvertisement); l != nil {
+		na := l.(*glayers.ICMPv6NeighborAdvertisement)
+		mac := srcMAC
+		for _, opt := range na.Options {
+			if opt.Type == glayers.ICMPv6OptTargetAddress {
+				mac = net.HardwareAddr(opt.Data)
+			}
+		}
+		return &ProbeReply{
+			SrcIP:  na.TargetAddress,
+			SrcMAC: mac,
+		}, nil
+	}
+
+	// ICMPv6 echo reply
+	if l := packet.Layer(glayers.LayerTypeICMPv6Echo); l != nil {
+		echo := l.(*glayers.ICMPv6Echo)
+		icmp := packet.Layer(glayers.LayerTypeICMPv6)
+		ip := packet.Layer(glayers.LayerTypeIPv6)
+		if icmp != nil && ip != nil &&
+			icmp.(*glayers.ICMPv6).TypeCode.Type() == glayers.ICMPv6TypeEchoReply {
+			return &ProbeReply{
+				SrcIP:  ip.(*glayers.IPv6).SrcIP,
+				SrcMAC: srcMAC,
+				Echo:   true,
+				ID:     echo.Identifier,
+				Seq:    echo.SeqNumber,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Not a probe reply")
+}
@@ -0,0 +1,197 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package packets
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	glayers "github.com/google/gopacket/layers"
+	"github.com/lagopus/vrrpd/packets/layers"
+	"github.com/stretchr/testify/suite"
+)
+
+type testProbeTestSuite struct {
+	suite.Suite
+}
+
+var (
+	probeMAC1 = net.HardwareAddr{0x52, 0x54, 0x00, 0xce, 0xd1, 0xa3}
+	probeMAC2 = net.HardwareAddr{0x52, 0x54, 0x00, 0x11, 0x22, 0x33}
+)
+
+func (suite *testProbeTestSuite) TestSolicitedNodeAddr() {
+	ip, mac := SolicitedNodeAddr(net.ParseIP("2001:db8::12:3456"))
+	suite.Equal(net.ParseIP("ff02::1:ff12:3456"), ip)
+	suite.Equal(net.HardwareAddr{0x33, 0x33, 0xff, 0x12, 0x34, 0x56}, mac)
+}
+
+func (suite *testProbeTestSuite) TestSerializeARPRequest() {
+	buf, err := SerializeARPRequest(net.ParseIP("10.0.0.1"),
+		net.ParseIP("10.0.0.254"), probeMAC1)
+	suite.Empty(err)
+
+	packet := gopacket.NewPacket(buf, glayers.LayerTypeEthernet, gopacket.Default)
+	layer := packet.Layer(glayers.LayerTypeARP)
+	suite.NotNil(layer)
+
+	arp := layer.(*glayers.ARP)
+	suite.Equal(uint16(glayers.ARPRequest), arp.Operation)
+	suite.Equal([]byte(net.ParseIP("10.0.0.1").To4()), arp.SourceProtAddress)
+	suite.Equal([]byte(net.ParseIP("10.0.0.254").To4()), arp.DstProtAddress)
+	suite.Equal([]byte(probeMAC1), arp.SourceHwAddress)
+}
+
+func (suite *testProbeTestSuite) TestSerializeNS() {
+	buf, err := SerializeNS(net.ParseIP("2001:db8::1"),
+		net.ParseIP("2001:db8::fe"), probeMAC1)
+	suite.Empty(err)
+
+	packet := gopacket.NewPacket(buf, glayers.LayerTypeEthernet, gopacket.Default)
+	eth := packet.Layer(glayers.LayerTypeEthernet).(*glayers.Ethernet)
+	suite.Equal(net.HardwareAddr{0x33, 0x33, 0xff, 0x00, 0x00, 0xfe}, eth.DstMAC)
+
+	ip := packet.Layer(glayers.LayerTypeIPv6).(*glayers.IPv6)
+	suite.Equal(uint8(NDHopLimit), ip.HopLimit)
+	suite.Equal(net.ParseIP("ff02::1:ff00:fe"), ip.DstIP)
+
+	layer := packet.Layer(glayers.LayerTypeICMPv6NeighborSolicitation)
+	suite.NotNil(layer)
+	ns := layer.(*glayers.ICMPv6NeighborSolicitation)
+	suite.Equal(net.ParseIP("2001:db8::fe"), ns.TargetAddress)
+	suite.Equal(glayers.ICMPv6OptSourceAddress, ns.Options[0].Type)
+	suite.Equal([]byte(probeMAC1), ns.Options[0].Data)
+}
+
+func (suite *testProbeTestSuite) TestSerializeICMPEchoIPv4() {
+	buf, err := SerializeICMPEcho(net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.254"),
+		probeMAC1, probeMAC2, 100, 1)
+	suite.Empty(err)
+
+	packet := gopacket.NewPacket(buf, glayers.LayerTypeEthernet, gopacket.Default)
+	eth := packet.Layer(glayers.LayerTypeEthernet).(*glayers.Ethernet)
+	suite.Equal(probeMAC2, eth.DstMAC)
+
+	ip := packet.Layer(glayers.LayerTypeIPv4).(*glayers.IPv4)
+	suite.Equal(net.ParseIP("10.0.0.254").To4(), ip.DstIP)
+
+	icmp := packet.Layer(glayers.LayerTypeICMPv4).(*glayers.ICMPv4)
+	suite.Equal(uint8(glayers.ICMPv4TypeEchoRequest), icmp.TypeCode.Type())
+	suite.Equal(uint16(100), icmp.Id)
+	suite.Equal(uint16(1), icmp.Seq)
+}
+
+func (suite *testProbeTestSuite) TestSerializeICMPEchoIPv6() {
+	buf, err := SerializeICMPEcho(net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8::fe"),
+		probeMAC1, probeMAC2, 100, 2)
+	suite.Empty(err)
+
+	packet := gopacket.NewPacket(buf, glayers.LayerTypeEthernet, gopacket.Default)
+	icmp := packet.Layer(glayers.LayerTypeICMPv6).(*glayers.ICMPv6)
+	suite.Equal(uint8(glayers.ICMPv6TypeEchoRequest), icmp.TypeCode.Type())
+
+	echo := packet.Layer(glayers.LayerTypeICMPv6Echo).(*glayers.ICMPv6Echo)
+	suite.Equal(uint16(100), echo.Identifier)
+	suite.Equal(uint16(2), echo.SeqNumber)
+}
+
+func serializeEchoReply(suite *testProbeTestSuite, srcIP net.IP, id uint16, seq uint16) []byte {
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	ethernet := &glayers.Ethernet{
+		DstMAC:       probeMAC1,
+		SrcMAC:       probeMAC2,
+		EthernetType: glayers.EthernetTypeIPv4,
+	}
+	ip := &glayers.IPv4{
+		Version:  4,
+		IHL:      5,
+		TTL:      64,
+		Protocol: glayers.IPProtocolICMPv4,
+		SrcIP:    srcIP.To4(),
+		DstIP:    net.ParseIP("10.0.0.1").To4(),
+	}
+	icmp := &glayers.ICMPv4{
+		TypeCode: glayers.CreateICMPv4TypeCode(glayers.ICMPv4TypeEchoReply, 0),
+		Id:       id,
+		Seq:      seq,
+	}
+	err := gopacket.SerializeLayers(buf, opts, ethernet, ip, icmp)
+	suite.Empty(err)
+
+	return buf.Bytes()
+}
+
+func (suite *testProbeTestSuite) TestDecodeProbeReplyEcho() {
+	buf := serializeEchoReply(suite, net.ParseIP("10.0.0.254"), 100, 3)
+
+	reply, err := DecodeProbeReply(buf)
+	suite.Empty(err)
+	suite.True(reply.Echo)
+	suite.Equal(net.ParseIP("10.0.0.254").To4(), reply.SrcIP.To4())
+	suite.Equal(probeMAC2, reply.SrcMAC)
+	suite.Equal(uint16(100), reply.ID)
+	suite.Equal(uint16(3), reply.Seq)
+}
+
+func (suite *testProbeTestSuite) TestDecodeProbeReplyNA() {
+	buf, err := SerializeUnsolicitedNA(net.ParseIP("2001:db8::fe"), probeMAC2)
+	suite.Empty(err)
+
+	reply, err := DecodeProbeReply(buf)
+	suite.Empty(err)
+	suite.False(reply.Echo)
+	suite.Equal(net.ParseIP("2001:db8::fe"), reply.SrcIP)
+	suite.Equal(probeMAC2, reply.SrcMAC)
+}
+
+func (suite *testProbeTestSuite) TestDecodeProbeReplyBad() {
+	// ARP request
+	buf, err := SerializeARPRequest(net.ParseIP("10.0.0.1"),
+		net.ParseIP("10.0.0.254"), probeMAC1)
+	suite.Empty(err)
+	_, err = DecodeProbeReply(buf)
+	suite.NotEmpty(err)
+
+	// ICMP echo request
+	buf, err = SerializeICMPEcho(net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.254"),
+		probeMAC1, probeMAC2, 100, 1)
+	suite.Empty(err)
+	_, err = DecodeProbeReply(buf)
+	suite.NotEmpty(err)
+
+	// VRRP adv
+	adv := &layers.VRRPv3Adv{
+		VirtualRtrID: 1,
+		Priority:     100,
+		MaxAdverInt:  100,
+		IPAddress:    []net.IP{net.ParseIP("10.0.0.100").To4()},
+	}
+	buf, err = SerializeVRRPAdv(net.ParseIP("10.0.0.1"), adv)
+	suite.Empty(err)
+	_, err = DecodeProbeReply(buf)
+	suite.NotEmpty(err)
+}
+
+func TestProbeTestSuites(t *testing.T) {
+	suite.Run(t, new(testProbeTestSuite))
+}
@@ -32,6 +32,10 @@ const (
 	VRRPAdvIHL = 5
 	// VRRPAdvTTL TTL
 	VRRPAdvTTL = 255
+	// VRRPAdvIPv6ver IPv6
+	VRRPAdvIPv6ver = 6
+	// VRRPAdvHopLimit IPv6 Hop Limit
+	VRRPAdvHopLimit = 255
 )
 
 var (
@@ -39,22 +43,82 @@ var (
 	VRRPAdvDstMAC = net.HardwareAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0x12}
 	// VRRPAdvDstIP dst IP addr
 	VRRPAdvDstIP = net.IP{224, 0, 0, 18}
+	// VRRPAdvIPv6DstMAC dst MAC addr(IPv6)
+	VRRPAdvIPv6DstMAC = net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x12}
+	// VRRPAdvIPv6DstIP dst IP addr(IPv6)
+	VRRPAdvIPv6DstIP = net.ParseIP("ff02::12")
 )
 
-// SerializeVRRPAdv Serialize VRRPAdv
-func SerializeVRRPAdv(srcIP net.IP,
-	vrrp *layers.VRRPv3Adv) ([]byte, error) {
-	if vrrp == nil {
-		return nil, fmt.Errorf("Invalid args")
+// DecodeErrorReason Reason of error in decoding VRRP advertisement.
+type DecodeErrorReason uint8
+
+const (
+	// DecodeErrorOther Other error.
+	DecodeErrorOther DecodeErrorReason = iota
+	// DecodeErrorTTL Bad TTL/Hop Limit.
+	DecodeErrorTTL
+	// DecodeErrorChecksum Bad checksum.
+	DecodeErrorChecksum
+	// DecodeErrorVersion Bad version.
+	DecodeErrorVersion
+	// DecodeErrorType Bad type.
+	DecodeErrorType
+	// DecodeErrorAddressList Bad address list.
+	DecodeErrorAddressList
+	// DecodeErrorPacketLength Bad packet length.
+	DecodeErrorPacketLength
+)
+
+func (r DecodeErrorReason) String() string {
+	switch r {
+	case DecodeErrorTTL:
+		return "TTL"
+	case DecodeErrorChecksum:
+		return "Checksum"
+	case DecodeErrorVersion:
+		return "Version"
+	case DecodeErrorType:
+		return "Type"
+	case DecodeErrorAddressList:
+		return "AddressList"
+	case DecodeErrorPacketLength:
+		return "PacketLength"
+	default:
+		return "Other"
 	}
+}
+
+// DecodeError Error in decoding VRRP advertisement.
+// SrcIP and Vrid are set if VRRP header is decoded.
+type DecodeError struct {
+	Reason DecodeErrorReason
+	SrcIP  net.IP
+	Vrid   uint8
+	Err    error
+}
 
-	// virtual router mac address
-	vmac := net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x01, vrrp.VirtualRtrID}
+func newDecodeError(reason DecodeErrorReason, format string, a ...interface{}) *DecodeError {
+	return &DecodeError{
+		Reason: reason,
+		Err:    fmt.Errorf(format, a...),
+	}
+}
+
+func (e *DecodeError) Error() string {
+	return e.Err.Error()
+}
+
+// networkLayer IPv4/IPv6 layer.
+type networkLayer interface {
+	gopacket.NetworkLayer
+	SerializeTo(b gopacket.SerializeBuffer, opts gopacket.SerializeOptions) error
+}
 
+func newVRRPAdvIPv4(srcIP net.IP, vrid uint8) (*glayers.Ethernet, networkLayer) {
 	// ethernet
 	ethernet := &glayers.Ethernet{
 		DstMAC:       VRRPAdvDstMAC,
-		SrcMAC:       vmac,
+		SrcMAC:       VirtualMAC(vrid),
 		EthernetType: glayers.EthernetTypeIPv4,
 	}
 
@@ -72,6 +136,45 @@ func SerializeVRRPAdv(srcIP net.IP,
 		SrcIP:      srcIP,
 	}
 
+	return ethernet, ip
+}
+
+func newVRRPAdvIPv6(srcIP net.IP, vrid uint8) (*glayers.Ethernet, networkLayer) {
+	// ethernet
+	ethernet := &glayers.Ethernet{
+		DstMAC:       VRRPAdvIPv6DstMAC,
+		SrcMAC:       VirtualMACIPv6(vrid),
+		EthernetType: glayers.EthernetTypeIPv6,
+	}
+
+	// IPv6 (srcIP: link-local address)
+	ip := &glayers.IPv6{
+		Version:    VRRPAdvIPv6ver,
+		HopLimit:   VRRPAdvHopLimit,
+		NextHeader: glayers.IPProtocolVRRP,
+		DstIP:      VRRPAdvIPv6DstIP,
+		SrcIP:      srcIP,
+	}
+
+	return ethernet, ip
+}
+
+// SerializeVRRPAdv Serialize VRRPAdv
+// The address family of VRRPAdv is selected by srcIP.
+func SerializeVRRPAdv(srcIP net.IP,
+	vrrp *layers.VRRPv3Adv) ([]byte, error) {
+	if vrrp == nil {
+		return nil, fmt.Errorf("Invalid args")
+	}
+
+	var ethernet *glayers.Ethernet
+	var ip networkLayer
+	if srcIP.To4() != nil {
+		ethernet, ip = newVRRPAdvIPv4(srcIP, vrrp.VirtualRtrID)
+	} else {
+		ethernet, ip = newVRRPAdvIPv6(srcIP, vrrp.VirtualRtrID)
+	}
+
 	// VRRPAdv
 	// args: VirtualRtrID, MaxAdverInt, Priority, IPAddress
 	vrrp.Version = layers.VRRPv3Version
@@ -93,15 +196,55 @@ func SerializeVRRPAdv(srcIP net.IP,
 	return buf.Bytes(), nil
 }
 
-func checkVRRPAdv(ip *glayers.IPv4, vrrp *layers.VRRPv3Adv) error {
-	// TTL
-	if ip.TTL != VRRPAdvTTL {
-		return fmt.Errorf("Bad TTL: %v", ip.TTL)
+// SerializeVRRPv2Adv Serialize VRRPv2Adv(IPv4 only)
+func SerializeVRRPv2Adv(srcIP net.IP,
+	vrrp *layers.VRRPv2Adv) ([]byte, error) {
+	if vrrp == nil || srcIP.To4() == nil {
+		return nil, fmt.Errorf("Invalid args")
+	}
+
+	ethernet, ip := newVRRPAdvIPv4(srcIP, vrrp.VirtualRtrID)
+
+	// VRRPAdv
+	// args: VirtualRtrID, AdverInt, Priority, IPAddress
+	vrrp.Version = layers.VRRPv2Version
+	vrrp.Type = layers.VRRPv2Advertisement
+	vrrp.AuthType = layers.VRRPv2AuthNoAuth
+	vrrp.CountIPAddr = uint8(len(vrrp.IPAddress))
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	if err := gopacket.SerializeLayers(buf, opts,
+		ethernet, ip, vrrp); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func checkVRRPAdv(ip gopacket.NetworkLayer, vrrp *layers.VRRPv3Adv) error {
+	// TTL/Hop Limit
+	switch l := ip.(type) {
+	case *glayers.IPv4:
+		if l.TTL != VRRPAdvTTL {
+			return newDecodeError(DecodeErrorTTL, "Bad TTL: %v", l.TTL)
+		}
+	case *glayers.IPv6:
+		if l.HopLimit != VRRPAdvHopLimit {
+			return newDecodeError(DecodeErrorTTL, "Bad HopLimit: %v", l.HopLimit)
+		}
+	default:
+		return fmt.Errorf("Bad network layer")
 	}
 
 	// CountIPAddr
 	if vrrp.CountIPAddr != uint8(len(vrrp.IPAddress)) {
-		return fmt.Errorf("Bad CountIPAddr. CountIPAddr: %v, IPAddress: %v",
+		return newDecodeError(DecodeErrorAddressList,
+			"Bad CountIPAddr. CountIPAddr: %v, IPAddress: %v",
 			vrrp.CountIPAddr, uint8(len(vrrp.IPAddress)))
 	}
 
@@ -110,17 +253,59 @@ func checkVRRPAdv(ip *glayers.IPv4, vrrp *layers.VRRPv3Adv) error {
 	if csum, err := vrrp.ComputeChecksum(vrrp.Contents, glayers.IPProtocolVRRP); err != nil {
 		return err
 	} else if csum != 0 {
-		return fmt.Errorf("Bad checksum")
+		return newDecodeError(DecodeErrorChecksum, "Bad checksum")
 	}
 
 	return nil
 }
 
-// DecodeVRRPAdv Decode VRRPAdv
-func DecodeVRRPAdv(packet []byte) (*glayers.Ethernet, *glayers.IPv4, *layers.VRRPv3Adv, error) {
+func decodeVRRPAdv(packet []byte, ip networkLayer, vrrp *layers.VRRPv3Adv) (*glayers.Ethernet, error) {
+	var eth glayers.Ethernet
+
+	// for length of IPvX address.
+	vrrp.SetNetworkLayerForChecksum(ip)
+
+	parser := gopacket.NewDecodingLayerParser(glayers.LayerTypeEthernet,
+		&eth, ip.(gopacket.DecodingLayer), vrrp)
+	decoded := []gopacket.LayerType{}
+
+	if err := parser.DecodeLayers(packet, &decoded); err != nil {
+		return nil, err
+	}
+
+	if err := checkVRRPAdv(ip, vrrp); err != nil {
+		return nil, err
+	}
+
+	return &eth, nil
+}
+
+func checkVRRPv2Adv(ip *glayers.IPv4, vrrp *layers.VRRPv2Adv) error {
+	// TTL
+	if ip.TTL != VRRPAdvTTL {
+		return newDecodeError(DecodeErrorTTL, "Bad TTL: %v", ip.TTL)
+	}
+
+	// CountIPAddr
+	if vrrp.CountIPAddr != uint8(len(vrrp.IPAddress)) {
+		return newDecodeError(DecodeErrorAddressList,
+			"Bad CountIPAddr. CountIPAddr: %v, IPAddress: %v",
+			vrrp.CountIPAddr, uint8(len(vrrp.IPAddress)))
+	}
+
+	// checksum
+	if vrrp.ComputeChecksum() != 0 {
+		return newDecodeError(DecodeErrorChecksum, "Bad checksum")
+	}
+
+	return nil
+}
+
+// DecodeVRRPv2Adv Decode VRRPv2Adv
+func DecodeVRRPv2Adv(packet []byte) (*glayers.Ethernet, *glayers.IPv4, *layers.VRRPv2Adv, error) {
 	var eth glayers.Ethernet
 	var ip glayers.IPv4
-	var vrrp layers.VRRPv3Adv
+	var vrrp layers.VRRPv2Adv
 
 	parser := gopacket.NewDecodingLayerParser(glayers.LayerTypeEthernet,
 		&eth, &ip, &vrrp)
@@ -130,9 +315,158 @@ func DecodeVRRPAdv(packet []byte) (*glayers.Ethernet, *glayers.IPv4, *layers.VRR
 		return nil, nil, nil, err
 	}
 
-	if err := checkVRRPAdv(&ip, &vrrp); err != nil {
+	if err := checkVRRPv2Adv(&ip, &vrrp); err != nil {
 		return nil, nil, nil, err
 	}
 
 	return &eth, &ip, &vrrp, nil
 }
+
+// DecodeVRRPAdv Decode VRRPAdv
+func DecodeVRRPAdv(packet []byte) (*glayers.Ethernet, *glayers.IPv4, *layers.VRRPv3Adv, error) {
+	var ip glayers.IPv4
+	var vrrp layers.VRRPv3Adv
+
+	eth, err := decodeVRRPAdv(packet, &ip, &vrrp)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return eth, &ip, &vrrp, nil
+}
+
+// DecodeVRRPAdvIPv6 Decode VRRPAdv(IPv6)
+func DecodeVRRPAdvIPv6(packet []byte) (*glayers.Ethernet, *glayers.IPv6, *layers.VRRPv3Adv, error) {
+	var ip glayers.IPv6
+	var vrrp layers.VRRPv3Adv
+
+	eth, err := decodeVRRPAdv(packet, &ip, &vrrp)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return eth, &ip, &vrrp, nil
+}
+
+// checkVRRPAdvHeader Check header of VRRP advertisement before decoding.
+func checkVRRPAdvHeader(data []byte, addrLen int) error {
+	if len(data) < 8 {
+		return newDecodeError(DecodeErrorPacketLength,
+			"Bad packet length: %d", len(data))
+	}
+
+	version := data[0] >> 4
+	typ := layers.VRRPv3Type(data[0] & 0x0F)
+	count := int(data[3])
+
+	switch {
+	case version == layers.VRRPv3Version:
+		if typ != layers.VRRPv3Advertisement {
+			return newDecodeError(DecodeErrorType, "Bad type: %d", typ)
+		}
+		if len(data[8:])%addrLen != 0 {
+			return newDecodeError(DecodeErrorPacketLength,
+				"Bad packet length: %d", len(data))
+		}
+	case version == layers.VRRPv2Version && addrLen == net.IPv4len:
+		// VRRPv2 is IPv4 only.
+		if typ != layers.VRRPv2Advertisement {
+			return newDecodeError(DecodeErrorType, "Bad type: %d", typ)
+		}
+		if len(data) < 8+count*addrLen+layers.VRRPv2AuthDataLen {
+			return newDecodeError(DecodeErrorPacketLength,
+				"Bad packet length: %d", len(data))
+		}
+	default:
+		return newDecodeError(DecodeErrorVersion, "Bad version: %d", version)
+	}
+
+	if count < 1 {
+		return newDecodeError(DecodeErrorAddressList,
+			"Bad CountIPAddr: %d", count)
+	}
+
+	return nil
+}
+
+// toDecodeError Set source address and VRID to error.
+func toDecodeError(err error, srcIP net.IP, data []byte) error {
+	e, ok := err.(*DecodeError)
+	if !ok {
+		e = &DecodeError{
+			Reason: DecodeErrorOther,
+			Err:    err,
+		}
+	}
+	e.SrcIP = srcIP
+	if len(data) > 1 {
+		e.Vrid = data[1]
+	}
+	return e
+}
+
+// DecodeVRRPAdvPacket Decode VRRPAdv(IPv4/IPv6, VRRPv2/VRRPv3)
+// Returns source address of packet and VRRPAdv.
+// VRRPv2Adv is converted to VRRPv3Adv(Version is 2).
+// Errors in VRRP header are returned as *DecodeError.
+func DecodeVRRPAdvPacket(packet []byte) (net.IP, *layers.VRRPv3Adv, error) {
+	srcIP, vrrp, data, err := decodeVRRPAdvPacket(packet)
+	if err != nil && data != nil {
+		return nil, nil, toDecodeError(err, srcIP, data)
+	}
+	return srcIP, vrrp, err
+}
+
+// decodeVRRPAdvPacket Decode VRRPAdv, and returns payload of IP
+// if network layer is decoded.
+func decodeVRRPAdvPacket(packet []byte) (net.IP, *layers.VRRPv3Adv, []byte, error) {
+	var eth glayers.Ethernet
+	if err := eth.DecodeFromBytes(packet, gopacket.NilDecodeFeedback); err != nil {
+		return nil, nil, nil, err
+	}
+
+	switch eth.EthernetType {
+	case glayers.EthernetTypeIPv4:
+		var ipv4 glayers.IPv4
+		if err := ipv4.DecodeFromBytes(eth.Payload, gopacket.NilDecodeFeedback); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := checkVRRPAdvHeader(ipv4.Payload, net.IPv4len); err != nil {
+			return ipv4.SrcIP, nil, ipv4.Payload, err
+		}
+		version, err := layers.VRRPAdvVersion(ipv4.Payload)
+		if err != nil {
+			return ipv4.SrcIP, nil, ipv4.Payload, err
+		}
+
+		// VRRPv2 is IPv4 only.
+		if version == layers.VRRPv2Version {
+			_, ip, vrrp, err := DecodeVRRPv2Adv(packet)
+			if err != nil {
+				return ipv4.SrcIP, nil, ipv4.Payload, err
+			}
+			return ip.SrcIP, vrrp.ToVRRPv3Adv(), nil, nil
+		}
+
+		_, ip, vrrp, err := DecodeVRRPAdv(packet)
+		if err != nil {
+			return ipv4.SrcIP, nil, ipv4.Payload, err
+		}
+		return ip.SrcIP, vrrp, nil, nil
+	case glayers.EthernetTypeIPv6:
+		var ipv6 glayers.IPv6
+		if err := ipv6.DecodeFromBytes(eth.Payload, gopacket.NilDecodeFeedback); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := checkVRRPAdvHeader(ipv6.Payload, net.IPv6len); err != nil {
+			return ipv6.SrcIP, nil, ipv6.Payload, err
+		}
+		_, ip, vrrp, err := DecodeVRRPAdvIPv6(packet)
+		if err != nil {
+			return ipv6.SrcIP, nil, ipv6.Payload, err
+		}
+		return ip.SrcIP, vrrp, nil, nil
+	default:
+		return nil, nil, nil, fmt.Errorf("Bad EthernetType: %v", eth.EthernetType)
+	}
+}
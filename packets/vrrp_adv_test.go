@@ -257,6 +257,324 @@ func (suite *testVRRPAdvTestSuite) TestDecodeVRRPAdvErrorBadCountIPAddr() {
 	suite.EqualError(err, "Bad CountIPAddr. CountIPAddr: 3, IPAddress: 2")
 }
 
+func (suite *testVRRPAdvTestSuite) TestSerializeVRRPAdvIPv6() {
+	expectedPacket := []byte{
+		//    L2 header
+		//<------------------------------------------------------------------>
+		0x33, 0x33, 0x00, 0x00, 0x00, 0x12, 0x00, 0x00, 0x5e, 0x00, 0x02, 0x32,
+		//          L3 header
+		//<-------> <---------------------------------------------------------
+		0x86, 0xdd, 0x60, 0x00, 0x00, 0x00, 0x00, 0x28, 0x70, 0xff, 0xfe, 0x80,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x01, 0xff, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  VRRPAdv
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x12, 0x31, 0x32, 0xff, 0x02, 0x00, 0x64,
+		//Checksum  IPv6
+		//<------>  <---------------------------------------------------------
+		0x75, 0xc2, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  IPv6
+		//                                  <---------------------------------
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00,
+		//------------------------------------------------------------------->
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02,
+	}
+
+	vrrp := &layers.VRRPv3Adv{
+		VirtualRtrID: 50,
+		Priority:     255,
+		MaxAdverInt:  100,
+		IPAddress: []net.IP{
+			net.ParseIP("2001:db8::1"),
+			net.ParseIP("2001:db8::2")},
+	}
+
+	buf, err := SerializeVRRPAdv(
+		net.ParseIP("fe80::1"),
+		vrrp)
+	suite.Empty(err)
+
+	suite.Equal(expectedPacket, buf)
+}
+
+func (suite *testVRRPAdvTestSuite) TestDecodeVRRPAdvIPv6() {
+	expectedVRRPAdv := &layers.VRRPv3Adv{
+		Version:      3,
+		Type:         layers.VRRPv3Advertisement,
+		VirtualRtrID: 50,
+		Priority:     255,
+		CountIPAddr:  2,
+		MaxAdverInt:  100,
+		Checksum:     0x75c2,
+		IPAddress: []net.IP{
+			net.ParseIP("2001:db8::1"),
+			net.ParseIP("2001:db8::2")},
+	}
+
+	packet := []byte{
+		//    L2 header
+		//<------------------------------------------------------------------>
+		0x33, 0x33, 0x00, 0x00, 0x00, 0x12, 0x00, 0x00, 0x5e, 0x00, 0x02, 0x32,
+		//          L3 header
+		//<-------> <---------------------------------------------------------
+		0x86, 0xdd, 0x60, 0x00, 0x00, 0x00, 0x00, 0x28, 0x70, 0xff, 0xfe, 0x80,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x01, 0xff, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  VRRPAdv
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x12, 0x31, 0x32, 0xff, 0x02, 0x00, 0x64,
+		//Checksum  IPv6
+		//<------>  <---------------------------------------------------------
+		0x75, 0xc2, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  IPv6
+		//                                  <---------------------------------
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00,
+		//------------------------------------------------------------------->
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02,
+	}
+
+	eth, ip, vrrp, err := DecodeVRRPAdvIPv6(packet)
+	suite.Empty(err)
+
+	// ethernet
+	suite.Equal(VRRPAdvIPv6DstMAC, eth.DstMAC)
+	suite.Equal(glayers.EthernetTypeIPv6, eth.EthernetType)
+
+	// ipv6
+	suite.Equal(uint8(6), ip.Version)
+	suite.Equal(uint8(255), ip.HopLimit)
+	suite.Equal(glayers.IPProtocolVRRP, ip.NextHeader)
+	suite.Equal(net.ParseIP("fe80::1"), ip.SrcIP)
+	suite.Equal(VRRPAdvIPv6DstIP, ip.DstIP)
+
+	// vrrp
+	suite.Equal(expectedVRRPAdv.Version, vrrp.Version)
+	suite.Equal(expectedVRRPAdv.Type, vrrp.Type)
+	suite.Equal(expectedVRRPAdv.VirtualRtrID, vrrp.VirtualRtrID)
+	suite.Equal(expectedVRRPAdv.Priority, vrrp.Priority)
+	suite.Equal(expectedVRRPAdv.CountIPAddr, vrrp.CountIPAddr)
+	suite.Equal(expectedVRRPAdv.MaxAdverInt, vrrp.MaxAdverInt)
+	suite.Equal(expectedVRRPAdv.Checksum, vrrp.Checksum)
+	suite.Equal(expectedVRRPAdv.IPAddress, vrrp.IPAddress)
+}
+
+func (suite *testVRRPAdvTestSuite) TestDecodeVRRPAdvIPv6ErrorBadHopLimit() {
+	packet := []byte{
+		//    L2 header
+		//<------------------------------------------------------------------>
+		0x33, 0x33, 0x00, 0x00, 0x00, 0x12, 0x00, 0x00, 0x5e, 0x00, 0x02, 0x32,
+		//          L3 header
+		//<-------> <---------------------------------------------------------
+		0x86, 0xdd, 0x60, 0x00, 0x00, 0x00, 0x00, 0x28, 0x70, 0xff, 0xfe, 0x80,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x01, 0xff, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  VRRPAdv
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x12, 0x31, 0x32, 0xff, 0x02, 0x00, 0x64,
+		//Checksum  IPv6
+		//<------>  <---------------------------------------------------------
+		0x75, 0xc2, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  IPv6
+		//                                  <---------------------------------
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00,
+		//------------------------------------------------------------------->
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02,
+	}
+	// Hop Limit
+	packet[21] = 0x01
+
+	_, _, _, err := DecodeVRRPAdvIPv6(packet)
+	suite.EqualError(err, "Bad HopLimit: 1")
+}
+
+func (suite *testVRRPAdvTestSuite) TestDecodeVRRPAdvPacket() {
+	packet := []byte{
+		//    L2 header
+		//<------------------------------------------------------------------>
+		0x33, 0x33, 0x00, 0x00, 0x00, 0x12, 0x00, 0x00, 0x5e, 0x00, 0x02, 0x32,
+		//          L3 header
+		//<-------> <---------------------------------------------------------
+		0x86, 0xdd, 0x60, 0x00, 0x00, 0x00, 0x00, 0x28, 0x70, 0xff, 0xfe, 0x80,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x01, 0xff, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  VRRPAdv
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x12, 0x31, 0x32, 0xff, 0x02, 0x00, 0x64,
+		//Checksum  IPv6
+		//<------>  <---------------------------------------------------------
+		0x75, 0xc2, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		//------------------------------->  IPv6
+		//                                  <---------------------------------
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00,
+		//------------------------------------------------------------------->
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02,
+	}
+
+	srcIP, vrrp, err := DecodeVRRPAdvPacket(packet)
+	suite.Empty(err)
+	suite.Equal(net.ParseIP("fe80::1"), srcIP)
+	suite.Equal(uint8(50), vrrp.VirtualRtrID)
+	suite.Equal(uint8(2), vrrp.CountIPAddr)
+}
+
+func (suite *testVRRPAdvTestSuite) TestDecodeVRRPAdvPacketError() {
+	newPacket := func() []byte {
+		return []byte{
+			// L2 header
+			0x01, 0x00, 0x5e, 0x00, 0x00, 0x12, 0x52, 0x54, 0x00, 0xdc, 0x65, 0x98,
+			// L3 header
+			0x08, 0x00, 0x45, 0xc0, 0x00, 0x24, 0x00, 0x01, 0x00, 0x00, 0xff, 0x70,
+			0xb4, 0xff, 0xc0, 0xa8, 0x64, 0xee, 0xe0, 0x00, 0x00, 0x12,
+			// VRRPAdv(bad checksum)
+			0x31, 0x32, 0xff, 0x02, 0x00, 0x64, 0xb5, 0x38, 0x0a, 0x00,
+			0x00, 0x01, 0x0a, 0x00, 0x00, 0x02,
+			// pad
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		}
+	}
+	tests := []struct {
+		offset int
+		value  byte
+		reason DecodeErrorReason
+	}{
+		{offset: 34, value: 0x31, reason: DecodeErrorChecksum},
+		{offset: 34, value: 0x32, reason: DecodeErrorType},
+		{offset: 34, value: 0x41, reason: DecodeErrorVersion},
+		{offset: 37, value: 0x00, reason: DecodeErrorAddressList},
+		{offset: 37, value: 0x03, reason: DecodeErrorAddressList},
+		{offset: 22, value: 0xfe, reason: DecodeErrorTTL},
+	}
+
+	for _, test := range tests {
+		packet := newPacket()
+		packet[test.offset] = test.value
+		_, _, err := DecodeVRRPAdvPacket(packet)
+		e, ok := err.(*DecodeError)
+		suite.True(ok, "offset: %d, value: %d", test.offset, test.value)
+		suite.Equal(test.reason, e.Reason, "offset: %d, value: %d", test.offset, test.value)
+		suite.Equal(uint8(0x32), e.Vrid)
+		suite.Equal(net.IP{0xc0, 0xa8, 0x64, 0xee}, e.SrcIP.To4())
+	}
+}
+
+func (suite *testVRRPAdvTestSuite) TestSerializeVRRPv2Adv() {
+	expectedPacket := []byte{
+		//    L2 header
+		//<------------------------------------------------------------------>
+		0x01, 0x00, 0x5e, 0x00, 0x00, 0x12, 0x00, 0x00, 0x5e, 0x00, 0x01, 0x32,
+		//    L3 header
+		//<------------------------------------------------------------------>
+		0x08, 0x00, 0x45, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00, 0xff, 0x70,
+		//------------------------------------------------------->
+		//                                                          VRRPv2Adv
+		//                                                          version/type
+		//                                                          <-->  VRID
+		//                                                                <-->
+		0xb5, 0xb8, 0xc0, 0xa8, 0x64, 0xee, 0xe0, 0x00, 0x00, 0x12, 0x21, 0x32,
+		//Priority
+		//<>   Count IP Addrs
+		//    <-->  Auth Type
+		//          <-->  Adver Int
+		//                <-->  Checksum
+		//                      <-------->    IPv4                    IPv4
+		//                                  <-------------------->  <--------
+		0xff, 0x02, 0x00, 0x01, 0xcb, 0xc6, 0x0a, 0x00, 0x00, 0x01, 0x0a, 0x00,
+		//------->  Authentication Data                       pad
+		//          <---------------------------------------> <-------->
+		0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	vrrp := &layers.VRRPv2Adv{
+		VirtualRtrID: 50,
+		Priority:     255,
+		AdverInt:     1,
+		IPAddress: []net.IP{
+			net.IP{10, 0, 0, 1},
+			net.IP{10, 0, 0, 2}},
+	}
+
+	buf, err := SerializeVRRPv2Adv(
+		net.IP{192, 168, 100, 238},
+		vrrp)
+	suite.Empty(err)
+
+	suite.Equal(expectedPacket, buf)
+}
+
+func (suite *testVRRPAdvTestSuite) TestSerializeVRRPv2AdvErrorInvalidArgs() {
+	_, err := SerializeVRRPv2Adv(
+		net.ParseIP("fe80::1"),
+		&layers.VRRPv2Adv{})
+	suite.EqualError(err, "Invalid args")
+}
+
+func (suite *testVRRPAdvTestSuite) TestDecodeVRRPAdvPacketVRRPv2() {
+	packet := []byte{
+		//    L2 header
+		//<------------------------------------------------------------------>
+		0x01, 0x00, 0x5e, 0x00, 0x00, 0x12, 0x00, 0x00, 0x5e, 0x00, 0x01, 0x32,
+		//    L3 header
+		//<------------------------------------------------------------------>
+		0x08, 0x00, 0x45, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00, 0xff, 0x70,
+		//------------------------------------------------------->
+		//                                                          VRRPv2Adv
+		//                                                          version/type
+		//                                                          <-->  VRID
+		//                                                                <-->
+		0xb5, 0xb8, 0xc0, 0xa8, 0x64, 0xee, 0xe0, 0x00, 0x00, 0x12, 0x21, 0x32,
+		//Priority
+		//<>   Count IP Addrs
+		//    <-->  Auth Type
+		//          <-->  Adver Int
+		//                <-->  Checksum
+		//                      <-------->    IPv4                    IPv4
+		//                                  <-------------------->  <--------
+		0xff, 0x02, 0x00, 0x01, 0xcb, 0xc6, 0x0a, 0x00, 0x00, 0x01, 0x0a, 0x00,
+		//------->  Authentication Data                       pad
+		//          <---------------------------------------> <-------->
+		0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	srcIP, vrrp, err := DecodeVRRPAdvPacket(packet)
+	suite.Empty(err)
+	suite.Equal(net.IP{192, 168, 100, 238}, srcIP)
+	suite.Equal(uint8(layers.VRRPv2Version), vrrp.Version)
+	suite.Equal(uint8(50), vrrp.VirtualRtrID)
+	suite.Equal(uint16(100), vrrp.MaxAdverInt)
+	suite.Equal([]net.IP{
+		net.IP{10, 0, 0, 1},
+		net.IP{10, 0, 0, 2}}, vrrp.IPAddress)
+}
+
+func (suite *testVRRPAdvTestSuite) TestDecodeVRRPv2AdvErrorBadChecksum() {
+	packet := []byte{
+		//    L2 header
+		//<------------------------------------------------------------------>
+		0x01, 0x00, 0x5e, 0x00, 0x00, 0x12, 0x00, 0x00, 0x5e, 0x00, 0x01, 0x32,
+		//    L3 header
+		//<------------------------------------------------------------------>
+		0x08, 0x00, 0x45, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00, 0xff, 0x70,
+		//------------------------------------------------------->
+		//                                                          VRRPv2Adv
+		//                                                          version/type
+		//                                                          <-->  VRID
+		//                                                                <-->
+		0xb5, 0xb8, 0xc0, 0xa8, 0x64, 0xee, 0xe0, 0x00, 0x00, 0x12, 0x21, 0x32,
+		//Priority
+		//<>   Count IP Addrs
+		//    <-->  Auth Type
+		//          <-->  Adver Int
+		//                <-->  Checksum
+		//                      <-------->    IPv4                    IPv4
+		//                                  <-------------------->  <--------
+		0xff, 0x02, 0x00, 0x01, 0xcb, 0xc6, 0x0a, 0x00, 0x00, 0x01, 0x0a, 0x00,
+		//------->  Authentication Data                       pad
+		//          <---------------------------------------> <-------->
+		0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+	// Checksum
+	packet[39] = 0xc7
+
+	_, _, _, err := DecodeVRRPv2Adv(packet)
+	suite.EqualError(err, "Bad checksum")
+}
+
 func TestVRRPAdvTestSuites(t *testing.T) {
 	suite.Run(t, new(testVRRPAdvTestSuite))
 }
@@ -17,17 +17,21 @@
 package rpc
 
 import (
+	"fmt"
 	"sync"
+	"time"
 
 	ocd "github.com/coreswitch/openconfigd/proto"
 	"github.com/lagopus/vrrpd/config"
+	"github.com/lagopus/vrrpd/models"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 )
 
 // DatastoreCallbackType Type of Callback func for Handler.
-type DatastoreCallbackType func(conf *config.AgentConfig)
+// It returns result of applying config.
+type DatastoreCallbackType func(conf *config.AgentConfig) error
 
 // Datastore Datastore.
 type Datastore struct {
@@ -58,6 +62,11 @@ func NewDatastore(addr string, port int, f DatastoreCallbackType, wg *sync.WaitG
 	}
 }
 
+// ConnState Get state of gRPC connection.
+func (d *Datastore) ConnState() string {
+	return d.conn.State()
+}
+
 func (d *Datastore) recvConfig(stream ocd.Config_DoConfigClient) {
 	conf, err := stream.Recv()
 	if err != nil {
@@ -67,9 +76,9 @@ func (d *Datastore) recvConfig(stream ocd.Config_DoConfigClient) {
 	d.recvChannel <- conf
 }
 
-func (d *Datastore) recvLoop() {
-	defer d.wg.Done()
-
+// subscribe Open config stream, and subscribe config of interfaces.
+// openconfigd sends whole config of the path on subscription.
+func (d *Datastore) subscribe(msg *ocd.ConfigRequest) (ocd.Config_DoConfigClient, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	d.cancelFunc = cancel
 
@@ -77,9 +86,57 @@ func (d *Datastore) recvLoop() {
 
 	stream, err := d.client.DoConfig(ctx, opts...)
 	if err != nil {
-		log.Errorf("DoConfig error: %v", err)
-		return
+		cancel()
+		return nil, fmt.Errorf("DoConfig error: %v", err)
+	}
+
+	if err = stream.Send(msg); err != nil {
+		cancel()
+		return nil, fmt.Errorf("subscribe send error: %v", err)
+	}
+
+	return stream, nil
+}
+
+// closeStream Close config stream.
+func (d *Datastore) closeStream(stream ocd.Config_DoConfigClient) {
+	if d.cancelFunc != nil {
+		d.cancelFunc()
+	}
+	_ = stream.CloseSend()
+}
+
+// resync Subscribe again after rollback of commit.
+// openconfigd has no result of commit, and it keeps the commit,
+// so whole config is received again to be in sync with openconfigd.
+// It returns false if Datastore is stopped.
+func (d *Datastore) resync(stream ocd.Config_DoConfigClient,
+	msg *ocd.ConfigRequest) (ocd.Config_DoConfigClient, bool) {
+	d.closeStream(stream)
+
+	for {
+		log.Infof("resync with openconfigd after %v", ResyncInterval)
+		select {
+		case <-time.After(ResyncInterval):
+		case <-d.stopChannel:
+			log.Infof("Stop recvConfig loop.")
+			d.configHandler.Reset()
+			d.conn.Disconnect()
+			return nil, false
+		}
+
+		d.configHandler.Reset()
+		d.configMgr.ResetForResync()
+		stream, err := d.subscribe(msg)
+		if err == nil {
+			return stream, true
+		}
+		log.Errorf("resync failure: %v", err)
 	}
+}
+
+func (d *Datastore) recvLoop() {
+	defer d.wg.Done()
 
 	// subscribe
 	msg := &ocd.ConfigRequest{
@@ -87,9 +144,9 @@ func (d *Datastore) recvLoop() {
 		Module: "vrrp-agent",
 		Path:   []string{"interfaces", "interface"},
 	}
-	err = stream.Send(msg)
+	stream, err := d.subscribe(msg)
 	if err != nil {
-		log.Errorf("subscribe send error: %v", err)
+		log.Error(err)
 		return
 	}
 
@@ -103,39 +160,62 @@ func (d *Datastore) recvLoop() {
 			err := d.configHandler.NextState(conf.Type, conf.Path)
 			switch conf.Type {
 			case ocd.ConfigType_VALIDATE_END:
+				result := &ocd.ConfigRequest{
+					Module: msg.Module,
+					Path:   msg.Path,
+				}
 				if err == nil {
-					msg.Type = ocd.ConfigType_VALIDATE_SUCCESS
+					result.Type = ocd.ConfigType_VALIDATE_SUCCESS
 				} else {
-					msg.Type = ocd.ConfigType_VALIDATE_FAILED
-
-					log.Errorf("validate failure: %v", err)
+					// openconfigd has no field for reasons,
+					// so report each error in log.
+					result.Type = ocd.ConfigType_VALIDATE_FAILED
+					if errs, ok := err.(models.ValidationErrors); ok {
+						for _, e := range errs.Strings() {
+							log.Errorf("validate failure: %v", e)
+						}
+					} else {
+						log.Errorf("validate failure: %v", err)
+					}
 				}
 
 				// send validate result.
-				err = stream.Send(msg)
+				err = stream.Send(result)
 				if err != nil {
 					log.Errorf("validation send error: %v", err)
 					return
 				}
 			case ocd.ConfigType_COMMIT_END:
 				if err == nil {
-					log.Info("commit success")
-					d.callbackFunc(d.configMgr.GetCurrentConfig())
+					err = d.configHandler.Apply(config.ApplyFunc(d.callbackFunc))
+					if err == nil {
+						log.Info("commit success")
+					} else {
+						log.Errorf("commit failure, rollback: %v", err)
+
+						var ok bool
+						if stream, ok = d.resync(stream, msg); !ok {
+							return
+						}
+					}
 				} else {
 					log.Errorf("commit failure: %v", err)
 				}
 			default:
 				// NOP
 			}
+		case <-d.configMgr.ResyncChannel():
+			log.Warn("commit is rolled back by confirm timeout")
+
+			var ok bool
+			if stream, ok = d.resync(stream, msg); !ok {
+				return
+			}
 		case <-d.stopChannel:
 			log.Infof("Stop recvConfig loop.")
 
 			d.configHandler.Reset()
-
-			if d.cancelFunc != nil {
-				d.cancelFunc()
-			}
-			_ = stream.CloseSend()
+			d.closeStream(stream)
 			d.conn.Disconnect()
 			return
 		}
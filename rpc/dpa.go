@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	rpc "github.com/lagopus/vsw/agents/vrrp/rpc"
 	log "github.com/sirupsen/logrus"
@@ -33,6 +34,7 @@ type DPAgent struct {
 	client     rpc.VrrpClient
 	cancelFunc context.CancelFunc
 	isRunning  bool
+	latency    *Histograms
 	wg         *sync.WaitGroup
 	lock       sync.Mutex
 }
@@ -40,11 +42,22 @@ type DPAgent struct {
 // NewDPAgent New DPAgent.
 func NewDPAgent(addr string, port int, wg *sync.WaitGroup) *DPAgent {
 	return &DPAgent{
-		conn: NewConnection(addr, port),
-		wg:   wg,
+		conn:    NewConnection(addr, port),
+		latency: NewHistograms(DefaultLatencyBuckets),
+		wg:      wg,
 	}
 }
 
+// ConnState Get state of gRPC connection.
+func (d *DPAgent) ConnState() string {
+	return d.conn.State()
+}
+
+// Latency Get histograms of RPC latency by method.
+func (d *DPAgent) Latency() map[string]HistogramSnapshot {
+	return d.latency.Snapshot()
+}
+
 func arrayToVifInfo(vifs []string) *rpc.VifInfo {
 	entries := []*rpc.VifEntry{}
 
@@ -67,8 +80,8 @@ func createVifInfo(name string, phyaddr string, vaddr []string) *rpc.VifInfo {
 	entries := []*rpc.VifEntry{}
 
 	// name    : vif name
-	// phyaddr : IPv4 address(e.g. 192.168.0.1/24)
-	// vaddr   : IPv4 addresses(e.g. 192.168.0.1/24)
+	// phyaddr : IPv4/IPv6 address(e.g. 192.168.0.1/24, 2001:db8::1/64)
+	// vaddr   : IPv4/IPv6 addresses(e.g. 192.168.0.1/24, 2001:db8::1/64)
 	for _, addr := range vaddr {
 		entry := &rpc.VifEntry{
 			Name: name,
@@ -131,7 +144,10 @@ func (d *DPAgent) GetVifMacaddrs(vifs []string) (map[string]net.HardwareAddr, er
 
 	var retInfo *rpc.VifInfo
 	var err error
-	if retInfo, err = d.client.GetVifInfo(ctx, info, opts...); err != nil {
+	start := time.Now()
+	retInfo, err = d.client.GetVifInfo(ctx, info, opts...)
+	d.latency.Observe("GetVifInfo", time.Since(start))
+	if err != nil {
 		log.Errorf("GetVifMacaddrs failed: %v", err)
 		return nil, err
 	}
@@ -151,7 +167,10 @@ func (d *DPAgent) ToMaster(name string, phyaddr string, vaddr []string) error {
 
 	info := createVifInfo(name, phyaddr, vaddr)
 
-	if _, err := d.client.ToMaster(ctx, info, opts...); err != nil {
+	start := time.Now()
+	_, err := d.client.ToMaster(ctx, info, opts...)
+	d.latency.Observe("ToMaster", time.Since(start))
+	if err != nil {
 		log.Errorf("ToMaster failed: %v", err)
 		return err
 	}
@@ -171,7 +190,10 @@ func (d *DPAgent) ToBackup(name string, phyaddr string, vaddr []string) error {
 
 	info := createVifInfo(name, phyaddr, vaddr)
 
-	if _, err := d.client.ToBackup(ctx, info, opts...); err != nil {
+	start := time.Now()
+	_, err := d.client.ToBackup(ctx, info, opts...)
+	d.latency.Observe("ToBackup", time.Since(start))
+	if err != nil {
 		log.Errorf("ToBackup failed: %v", err)
 		return err
 	}
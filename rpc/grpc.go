@@ -18,6 +18,7 @@ package rpc
 
 import (
 	"fmt"
+	"sync"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -29,6 +30,7 @@ type Connection struct {
 	port       int
 	conn       *grpc.ClientConn
 	cancelFunc context.CancelFunc
+	lock       sync.Mutex
 }
 
 // NewConnection New gRPC connection.
@@ -60,18 +62,35 @@ func (c *Connection) Connect() error {
 		return err
 	}
 
+	c.lock.Lock()
 	c.conn = conn
+	c.lock.Unlock()
 
 	return nil
 }
 
+// State Get state of connection(e.g. READY, CONNECTING).
+func (c *Connection) State() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.conn == nil {
+		return ConnStateDisconnected
+	}
+	return c.conn.GetState().String()
+}
+
 // Disconnect disconnect gRPC server.
 func (c *Connection) Disconnect() {
 	if c.cancelFunc != nil {
 		c.cancelFunc()
 	}
 
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	if c.conn != nil {
 		_ = c.conn.Close()
+		c.conn = nil
 	}
 }
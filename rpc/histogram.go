@@ -0,0 +1,98 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package rpc
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets Default upper bounds(seconds) of latency histogram.
+var DefaultLatencyBuckets = []float64{
+	0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1,
+}
+
+// HistogramSnapshot Snapshot of histogram.
+// Counts are cumulative counts of buckets(the same length of Buckets).
+type HistogramSnapshot struct {
+	Buckets []float64
+	Counts  []uint64
+	Count   uint64
+	Sum     float64
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Histograms Histograms of durations by name.
+type Histograms struct {
+	buckets    []float64
+	histograms map[string]*histogram
+	lock       sync.Mutex
+}
+
+// NewHistograms New Histograms.
+func NewHistograms(buckets []float64) *Histograms {
+	return &Histograms{
+		buckets:    buckets,
+		histograms: map[string]*histogram{},
+	}
+}
+
+// Observe Observe duration.
+func (hs *Histograms) Observe(name string, d time.Duration) {
+	hs.lock.Lock()
+	defer hs.lock.Unlock()
+
+	h, ok := hs.histograms[name]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(hs.buckets))}
+		hs.histograms[name] = h
+	}
+
+	sec := d.Seconds()
+	for i, b := range hs.buckets {
+		if sec <= b {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += sec
+}
+
+// Snapshot Get snapshot of histograms.
+func (hs *Histograms) Snapshot() map[string]HistogramSnapshot {
+	hs.lock.Lock()
+	defer hs.lock.Unlock()
+
+	snapshots := map[string]HistogramSnapshot{}
+	for name, h := range hs.histograms {
+		counts := make([]uint64, len(h.counts))
+		copy(counts, h.counts)
+		snapshots[name] = HistogramSnapshot{
+			Buckets: hs.buckets,
+			Counts:  counts,
+			Count:   h.count,
+			Sum:     h.sum,
+		}
+	}
+
+	return snapshots
+}
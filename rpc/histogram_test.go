@@ -0,0 +1,54 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testHistogramTestSuite struct {
+	suite.Suite
+}
+
+func (suite *testHistogramTestSuite) TestObserve() {
+	hs := NewHistograms([]float64{0.001, 0.01, 0.1})
+	suite.Empty(hs.Snapshot())
+
+	hs.Observe("ToMaster", 500*time.Microsecond)
+	hs.Observe("ToMaster", 5*time.Millisecond)
+	hs.Observe("ToMaster", time.Second)
+	hs.Observe("ToBackup", 50*time.Millisecond)
+
+	snapshots := hs.Snapshot()
+	suite.Equal(2, len(snapshots))
+
+	s := snapshots["ToMaster"]
+	suite.Equal([]uint64{1, 2, 2}, s.Counts)
+	suite.Equal(uint64(3), s.Count)
+	suite.InDelta(1.0055, s.Sum, 1e-9)
+
+	s = snapshots["ToBackup"]
+	suite.Equal([]uint64{0, 0, 1}, s.Counts)
+	suite.Equal(uint64(1), s.Count)
+}
+
+func TestHistogramTestSuite(t *testing.T) {
+	suite.Run(t, new(testHistogramTestSuite))
+}
@@ -108,6 +108,16 @@ func (h *Hostif) sendLoop() {
 	}
 }
 
+// SendQueueDepth Get number of packets waiting to be sent.
+func (h *Hostif) SendQueueDepth() int {
+	return len(h.sendChannel)
+}
+
+// ConnState Get state of gRPC connection.
+func (h *Hostif) ConnState() string {
+	return h.conn.State()
+}
+
 // PacketoutBulk PacketoutBulk.
 func (h *Hostif) PacketoutBulk(bps *BulkPackets) {
 	entry := &entry{
@@ -41,4 +41,10 @@ const (
 
 	// ConnectInterval interval(1s)
 	ConnectInterval time.Duration = time.Duration(1) * time.Second
+
+	// ResyncInterval interval(5s) before subscribing again after rollback.
+	ResyncInterval time.Duration = time.Duration(5) * time.Second
+
+	// ConnStateDisconnected State of connection not connected.
+	ConnStateDisconnected = "DISCONNECTED"
 )
@@ -0,0 +1,426 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/lagopus/vrrpd/mgmt"
+	"golang.org/x/net/context"
+)
+
+// filterArgs Positional args to filter virtual routers.
+type filterArgs struct {
+	Subinterface string `positional-arg-name:"subif" description:"Subinterface"`
+	Vrid         uint8  `positional-arg-name:"vrid" description:"Virtual router ID"`
+}
+
+var out io.Writer = os.Stdout
+
+// printJSON Print message in JSON.
+func printJSON(pb proto.Message) error {
+	m := &jsonpb.Marshaler{EmitDefaults: true, OrigName: true, Indent: "  "}
+	if err := m.Marshal(out, pb); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(out)
+	return err
+}
+
+// centiseconds Format centiseconds as duration.
+func centiseconds(cs uint32) string {
+	return (time.Duration(cs) * 10 * time.Millisecond).String()
+}
+
+func afString(af mgmt.AddressFamily) string {
+	return strings.ToLower(af.String())
+}
+
+func stateString(s mgmt.State) string {
+	str := strings.ToLower(s.String())
+	return strings.ToUpper(str[:1]) + str[1:]
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// show vrrp.
+
+type showVRRPCommand struct {
+	Detail bool       `short:"d" long:"detail" description:"Show detail"`
+	Args   filterArgs `positional-args:"yes"`
+}
+
+func printVRRPBrief(vrrps []*mgmt.VRRP) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "Subinterface\tAF\tVRID\tState\tPriority\tMaster\tVirtual addresses")
+	for _, v := range vrrps {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%d\t%s\t%s\n",
+			v.Subinterface, afString(v.Af), v.Vrid, stateString(v.State),
+			v.EffectivePriority, orNone(v.MasterAddress),
+			strings.Join(v.VirtualAddresses, ","))
+	}
+	return w.Flush()
+}
+
+func printVRRPDetail(vrrps []*mgmt.VRRP) error {
+	for i, v := range vrrps {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		since := time.Duration(v.SinceLastTransition) * time.Millisecond
+		fmt.Fprintf(out, "%s %s VRID %d\n", v.Subinterface, afString(v.Af), v.Vrid)
+		w := tabwriter.NewWriter(out, 0, 8, 1, ' ', 0)
+		fmt.Fprintf(w, "  State:\t%s (since %v)\n", stateString(v.State), since)
+		if v.State == mgmt.State_ERROR {
+			fmt.Fprintf(w, "  Error:\t%s (retry in %v)\n", v.Error,
+				time.Duration(v.NextRetry)*time.Millisecond)
+		}
+		fmt.Fprintf(w, "  Version:\t%s\n", v.Version)
+		fmt.Fprintf(w, "  Priority:\t%d (effective %d)\n", v.Priority, v.EffectivePriority)
+		fmt.Fprintf(w, "  Preempt:\t%v\n", v.Preempt)
+		fmt.Fprintf(w, "  Accept:\t%v\n", v.Accept)
+		fmt.Fprintf(w, "  Advertisement interval:\t%s (master %s)\n",
+			centiseconds(v.AdvertisementInterval),
+			centiseconds(v.MasterAdvertisementInterval))
+		fmt.Fprintf(w, "  Master down interval:\t%s\n", centiseconds(v.MasterDownInterval))
+		fmt.Fprintf(w, "  Skew time:\t%s\n", centiseconds(v.SkewTime))
+		fmt.Fprintf(w, "  Primary address:\t%s\n", orNone(v.PrimaryAddress))
+		fmt.Fprintf(w, "  Master address:\t%s\n", orNone(v.MasterAddress))
+		fmt.Fprintf(w, "  Virtual MAC:\t%s\n", orNone(v.VirtualMac))
+		fmt.Fprintf(w, "  Virtual addresses:\t%s\n", strings.Join(v.VirtualAddresses, ", "))
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Execute Show virtual routers.
+func (c *showVRRPCommand) Execute(args []string) error {
+	return call(func(ctx context.Context, client mgmt.ManagementClient) error {
+		reply, err := client.GetVRRPStates(ctx, &mgmt.GetVRRPStatesRequest{
+			Subinterface: c.Args.Subinterface,
+			Vrid:         uint32(c.Args.Vrid),
+		})
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case opts.JSON:
+			return printJSON(reply)
+		case c.Detail:
+			return printVRRPDetail(reply.Vrrps)
+		}
+		return printVRRPBrief(reply.Vrrps)
+	})
+}
+
+// show statistics.
+
+type showStatisticsCommand struct {
+	Args filterArgs `positional-args:"yes"`
+}
+
+func printStatistics(reply *mgmt.GetStatisticsReply) error {
+	for _, s := range reply.Vrrps {
+		fmt.Fprintf(out, "%s %s VRID %d (since %v)\n", s.Subinterface, afString(s.Af),
+			s.Vrid, time.Unix(s.DiscontinuityTime, 0).Format(time.RFC3339))
+		w := tabwriter.NewWriter(out, 0, 8, 1, ' ', 0)
+		fmt.Fprintf(w, "  Master transitions:\t%d\n", s.MasterTransitions)
+		fmt.Fprintf(w, "  Advertisements received:\t%d\n", s.RcvdAdvertisements)
+		fmt.Fprintf(w, "  Advertisements sent:\t%d\n", s.SentAdvertisements)
+		fmt.Fprintf(w, "  Priority zero received:\t%d\n", s.RcvdPriZeroPackets)
+		fmt.Fprintf(w, "  Priority zero sent:\t%d\n", s.SentPriZeroPackets)
+		fmt.Fprintf(w, "  Interval errors:\t%d\n", s.AdvIntervalErrors)
+		fmt.Fprintf(w, "  TTL errors:\t%d\n", s.IpTtlErrors)
+		fmt.Fprintf(w, "  Checksum errors:\t%d\n", s.ChecksumErrors)
+		fmt.Fprintf(w, "  Version errors:\t%d\n", s.VersionErrors)
+		fmt.Fprintf(w, "  Invalid type packets:\t%d\n", s.RcvdInvalidTypePackets)
+		fmt.Fprintf(w, "  Address list errors:\t%d\n", s.AddressListErrors)
+		fmt.Fprintf(w, "  Packet length errors:\t%d\n", s.PacketLengthErrors)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+
+	g := reply.Global
+	if g == nil {
+		g = &mgmt.GlobalStatistics{}
+	}
+	fmt.Fprintln(out, "Global")
+	w := tabwriter.NewWriter(out, 0, 8, 1, ' ', 0)
+	fmt.Fprintf(w, "  Checksum errors:\t%d\n", g.ChecksumErrors)
+	fmt.Fprintf(w, "  Version errors:\t%d\n", g.VersionErrors)
+	fmt.Fprintf(w, "  VRID errors:\t%d\n", g.VridErrors)
+	fmt.Fprintf(w, "  Other errors:\t%d\n", g.OtherErrors)
+	return w.Flush()
+}
+
+// Execute Show statistics.
+func (c *showStatisticsCommand) Execute(args []string) error {
+	return call(func(ctx context.Context, client mgmt.ManagementClient) error {
+		reply, err := client.GetStatistics(ctx, &mgmt.GetStatisticsRequest{
+			Subinterface: c.Args.Subinterface,
+			Vrid:         uint32(c.Args.Vrid),
+		})
+		if err != nil {
+			return err
+		}
+
+		if opts.JSON {
+			return printJSON(reply)
+		}
+		return printStatistics(reply)
+	})
+}
+
+// show config.
+
+type showConfigCommand struct {
+	Candidate bool `short:"c" long:"candidate" description:"Show candidate config"`
+}
+
+// Execute Show current and candidate config.
+func (c *showConfigCommand) Execute(args []string) error {
+	return call(func(ctx context.Context, client mgmt.ManagementClient) error {
+		reply, err := client.GetConfig(ctx, &mgmt.GetConfigRequest{})
+		if err != nil {
+			return err
+		}
+
+		conf := reply.Current
+		if c.Candidate {
+			conf = reply.Candidate
+		}
+
+		if opts.JSON {
+			b, err := json.MarshalIndent(struct {
+				Config           json.RawMessage `json:"config"`
+				ConfirmPending   bool            `json:"confirm_pending"`
+				ConfirmRemaining uint64          `json:"confirm_remaining"`
+				LastRollback     int64           `json:"last_rollback"`
+			}{json.RawMessage(conf), reply.ConfirmPending, reply.ConfirmRemaining,
+				reply.LastRollback}, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(out, string(b))
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err = json.Indent(&buf, []byte(conf), "", "  "); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, buf.String())
+		if reply.ConfirmPending {
+			remaining := time.Duration(reply.ConfirmRemaining) * time.Millisecond
+			fmt.Fprintf(out, "Commit is not confirmed, rollback in %v.\n", remaining)
+		}
+		if reply.LastRollback != 0 {
+			fmt.Fprintf(out, "Commit was rolled back by confirm timeout at %s.\n",
+				time.Unix(0, reply.LastRollback).Format(time.RFC3339))
+		}
+		return nil
+	})
+}
+
+// show modules.
+
+type showModulesCommand struct{}
+
+// Execute Show state of modules.
+func (c *showModulesCommand) Execute(args []string) error {
+	return call(func(ctx context.Context, client mgmt.ManagementClient) error {
+		reply, err := client.GetModuleStates(ctx, &mgmt.GetModuleStatesRequest{})
+		if err != nil {
+			return err
+		}
+
+		if opts.JSON {
+			return printJSON(reply)
+		}
+		fmt.Fprintf(out, "State: %s\n", reply.State)
+		fmt.Fprintln(out, "Modules:")
+		for _, name := range reply.Modules {
+			fmt.Fprintf(out, "  %s\n", name)
+		}
+		return nil
+	})
+}
+
+// watch.
+
+type watchCommand struct {
+	Args filterArgs `positional-args:"yes"`
+}
+
+func printEvent(e *mgmt.Event) {
+	if e.Dropped > 0 {
+		fmt.Fprintf(out, "(%d events dropped)\n", e.Dropped)
+	}
+	fmt.Fprintf(out, "%s %s %s vrid %d: %s -> %s (%s",
+		time.Unix(0, e.Timestamp).Format(time.RFC3339Nano),
+		e.Subinterface, afString(e.Af), e.Vrid,
+		stateString(e.OldState), stateString(e.NewState), e.Trigger)
+	if e.AdvSource != "" {
+		fmt.Fprintf(out, ", advertisement from %s priority %d", e.AdvSource, e.AdvPriority)
+	}
+	fmt.Fprintln(out, ")")
+}
+
+// Execute Watch state change events until interrupted.
+func (c *watchCommand) Execute(args []string) error {
+	timeout := time.Duration(opts.Timeout) * time.Second
+	dialCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn, err := dial(dialCtx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	client := mgmt.NewManagementClient(conn)
+	stream, err := client.WatchEvents(context.Background(), &mgmt.WatchEventsRequest{
+		Subinterface: c.Args.Subinterface,
+		Vrid:         uint32(c.Args.Vrid),
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if opts.JSON {
+			m := &jsonpb.Marshaler{EmitDefaults: true, OrigName: true}
+			if err = m.Marshal(out, e); err != nil {
+				return err
+			}
+			fmt.Fprintln(out)
+			continue
+		}
+		printEvent(e)
+	}
+}
+
+// set log-level.
+
+type setLogLevelCommand struct {
+	Args struct {
+		Level string `positional-arg-name:"level" description:"debug, info, warning, error, fatal or panic"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// Execute Set log level.
+func (c *setLogLevelCommand) Execute(args []string) error {
+	return call(func(ctx context.Context, client mgmt.ManagementClient) error {
+		reply, err := client.SetLogLevel(ctx, &mgmt.SetLogLevelRequest{Level: c.Args.Level})
+		if err != nil {
+			return err
+		}
+
+		if opts.JSON {
+			return printJSON(reply)
+		}
+		fmt.Fprintf(out, "Log level: %s -> %s\n", reply.Previous, c.Args.Level)
+		return nil
+	})
+}
+
+// reload.
+
+type reloadCommand struct{}
+
+// Execute Reload config file of vrrpd.
+func (c *reloadCommand) Execute(args []string) error {
+	return call(func(ctx context.Context, client mgmt.ManagementClient) error {
+		reply, err := client.ReloadConfig(ctx, &mgmt.ReloadConfigRequest{})
+		if err != nil {
+			return err
+		}
+
+		if opts.JSON {
+			return printJSON(reply)
+		}
+		changed := len(reply.Added) > 0 || len(reply.Deleted) > 0 ||
+			len(reply.Changed) > 0 || reply.NotifyScripts
+		if !changed {
+			fmt.Fprintln(out, "Config is not changed")
+		}
+		for _, vr := range reply.Added {
+			fmt.Fprintf(out, "Added:   %s\n", vr)
+		}
+		for _, vr := range reply.Deleted {
+			fmt.Fprintf(out, "Deleted: %s\n", vr)
+		}
+		for _, vr := range reply.Changed {
+			fmt.Fprintf(out, "Changed: %s\n", vr)
+		}
+		if reply.NotifyScripts {
+			fmt.Fprintln(out, "Notify scripts are changed")
+		}
+		if len(reply.RestartRequired) > 0 {
+			fmt.Fprintf(out, "Restart is required to apply: %s\n",
+				strings.Join(reply.RestartRequired, ", "))
+		}
+		return nil
+	})
+}
+
+// confirm.
+
+type confirmCommand struct{}
+
+// Execute Confirm pending commit of vrrpd.
+func (c *confirmCommand) Execute(args []string) error {
+	return call(func(ctx context.Context, client mgmt.ManagementClient) error {
+		reply, err := client.Confirm(ctx, &mgmt.ConfirmRequest{})
+		if err != nil {
+			return err
+		}
+
+		if opts.JSON {
+			return printJSON(reply)
+		}
+		fmt.Fprintln(out, "Commit is confirmed")
+		return nil
+	})
+}
@@ -0,0 +1,97 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lagopus/vrrpd/agent"
+	"github.com/lagopus/vrrpd/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type testCommandsTestSuite struct {
+	suite.Suite
+	dir    string
+	server *agent.MgmtServer
+	wg     *sync.WaitGroup
+}
+
+func (suite *testCommandsTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "vrrpctl")
+	suite.Require().Empty(err)
+	suite.dir = dir
+
+	opts.Socket = filepath.Join(dir, "vrrpd.sock")
+	opts.Timeout = 5
+	opts.JSON = false
+	suite.wg = &sync.WaitGroup{}
+	suite.server = agent.NewMgmtServer("", 0, opts.Socket, suite.wg)
+	suite.Require().Empty(suite.server.Start())
+}
+
+func (suite *testCommandsTestSuite) TearDownTest() {
+	suite.server.Stop()
+	suite.wg.Wait()
+	out = os.Stdout
+	_ = os.RemoveAll(suite.dir)
+}
+
+func (suite *testCommandsTestSuite) TestConfirm() {
+	var buf bytes.Buffer
+	out = &buf
+
+	cmgr := config.GetMgr()
+	timeout := cmgr.GetConfirmTimeout()
+	defer cmgr.SetConfirmTimeout(timeout)
+	cmgr.SetConfirmTimeout(100 * time.Millisecond)
+
+	applied := make(chan *config.AgentConfig, 2)
+	suite.Empty(cmgr.Apply(func(conf *config.AgentConfig) error {
+		applied <- conf
+		return nil
+	}))
+	<-applied
+	pending, _ := cmgr.PendingConfirm()
+	suite.True(pending)
+
+	cmd := &confirmCommand{}
+	suite.Empty(cmd.Execute(nil))
+	suite.Equal("Commit is confirmed\n", buf.String())
+	pending, _ = cmgr.PendingConfirm()
+	suite.False(pending)
+
+	// not rolled back after timeout.
+	select {
+	case <-applied:
+		suite.Fail("rolled back after confirm")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	// not pending.
+	suite.NotEmpty(cmd.Execute(nil))
+}
+
+func TestCommandsTestSuite(t *testing.T) {
+	suite.Run(t, new(testCommandsTestSuite))
+}
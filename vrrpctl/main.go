@@ -0,0 +1,114 @@
+//
+// Copyright 2017 Nippon Telegraph and Telephone Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/lagopus/vrrpd/mgmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+var (
+	version   string
+	revision  string
+	builddate string
+	goversion string
+)
+
+var opts struct {
+	Socket  string `short:"s" long:"socket" default:"/var/run/vrrpd.sock" description:"Path to control socket of vrrpd"`
+	JSON    bool   `short:"j" long:"json" description:"Output in JSON"`
+	Timeout uint   `short:"t" long:"timeout" default:"5" description:"Timeout in seconds"`
+	Version bool   `short:"v" long:"version" description:"Show version"`
+
+	Show struct {
+		VRRP       showVRRPCommand       `command:"vrrp" description:"Show virtual routers"`
+		Statistics showStatisticsCommand `command:"statistics" description:"Show statistics"`
+		Config     showConfigCommand     `command:"config" description:"Show current and candidate config"`
+		Modules    showModulesCommand    `command:"modules" description:"Show state of modules"`
+	} `command:"show" description:"Show state of vrrpd"`
+
+	Watch watchCommand `command:"watch" description:"Watch state change events"`
+
+	Reload reloadCommand `command:"reload" description:"Reload config file"`
+
+	Confirm confirmCommand `command:"confirm" description:"Confirm pending commit"`
+
+	Set struct {
+		LogLevel setLogLevelCommand `command:"log-level" description:"Set log level"`
+	} `command:"set" description:"Set parameters of vrrpd"`
+}
+
+func versionString() string {
+	return fmt.Sprintf("vrrpctl: %s-%s(build at %s, %s)",
+		version, revision, builddate, goversion)
+}
+
+// dial Connect to control socket.
+func dial(ctx context.Context) (*grpc.ClientConn, error) {
+	dialer := func(addr string, timeout time.Duration) (net.Conn, error) {
+		return net.DialTimeout("unix", addr, timeout)
+	}
+	conn, err := grpc.DialContext(ctx, opts.Socket,
+		grpc.WithInsecure(), grpc.WithBlock(), grpc.WithDialer(dialer))
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to %s: %v", opts.Socket, err)
+	}
+	return conn, nil
+}
+
+// call Call management service via control socket.
+func call(f func(ctx context.Context, client mgmt.ManagementClient) error) error {
+	timeout := time.Duration(opts.Timeout) * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn, err := dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	return f(ctx, mgmt.NewManagementClient(conn))
+}
+
+func main() {
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.SubcommandsOptional = true
+	if _, err := parser.Parse(); err != nil {
+		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
+			return
+		}
+		os.Exit(1)
+	}
+
+	if opts.Version {
+		fmt.Println(versionString())
+		return
+	}
+
+	if parser.Active == nil {
+		parser.WriteHelp(os.Stderr)
+		os.Exit(1)
+	}
+}